@@ -8,10 +8,12 @@ import (
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
 	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/admin"
 	"github.com/rss3-network/global-indexer/internal/service/hub"
 	"github.com/rss3-network/global-indexer/internal/service/indexer"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler"
 	"github.com/rss3-network/global-indexer/internal/service/settler"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/fx"
@@ -58,6 +60,26 @@ var indexCommand = &cobra.Command{
 	},
 }
 
+var indexBackfillCommand = &cobra.Command{
+	Use:   "backfill",
+	Short: "Index a range of finalized blocks of a chain, the blocks are fetched in parallel",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		viper.Set(flag.KeyBackfill, true)
+
+		server := service.NewServer(
+			indexer.Module,
+			fx.Provide(indexer.NewServer),
+		)
+
+		if err := server.Start(cmd.Context()); err != nil {
+			return fmt.Errorf("start server: %w", err)
+		}
+
+		// The backfill is finished once the server is started.
+		return server.Stop(cmd.Context())
+	},
+}
+
 var schedulerCommand = &cobra.Command{
 	Use: "scheduler",
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -88,12 +110,77 @@ var settlerCommand = &cobra.Command{
 			return fmt.Errorf("start server: %w", err)
 		}
 
+		// The dry run is finished once the server is started.
+		if viper.GetBool(flag.KeyDryRun) {
+			return server.Stop(cmd.Context())
+		}
+
 		server.Wait()
 
 		return nil
 	},
 }
 
+var settlerSimulateCommand = &cobra.Command{
+	Use:   "simulate",
+	Short: "Calculate the Operation Rewards of an epoch without submitting them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		viper.Set(flag.KeyDryRun, true)
+
+		return settlerCommand.RunE(cmd, args)
+	},
+}
+
+var adminCommand = &cobra.Command{
+	Use:   "admin",
+	Short: "Administrative commands for the incidents",
+}
+
+var adminCheckpointCommand = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Inspect and edit the checkpoints of the indexers",
+}
+
+var adminCheckpointListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List the checkpoints of each chain and their lag behind the chain head",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runAdmin(cmd, admin.ActionCheckpointList)
+	},
+}
+
+var adminCheckpointSetCommand = &cobra.Command{
+	Use:   "set",
+	Short: "Set the checkpoint of a chain to a block, it fails while an indexer of the chain is running",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runAdmin(cmd, admin.ActionCheckpointSet)
+	},
+}
+
+var adminCheckpointRewindCommand = &cobra.Command{
+	Use:   "rewind",
+	Short: "Rewind the checkpoint of a chain by a number of blocks and delete the rows indexed from them, it fails while an indexer of the chain is running",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runAdmin(cmd, admin.ActionCheckpointRewind)
+	},
+}
+
+func runAdmin(cmd *cobra.Command, action string) error {
+	viper.Set(flag.KeyAction, action)
+
+	server := service.NewServer(
+		admin.Module,
+		fx.Provide(admin.NewServer),
+	)
+
+	if err := server.Start(cmd.Context()); err != nil {
+		return fmt.Errorf("start server: %w", err)
+	}
+
+	// The action is finished once the server is started.
+	return server.Stop(cmd.Context())
+}
+
 func initializeLogger() {
 	if os.Getenv(config.Environment) == config.EnvironmentDevelopment {
 		zap.ReplaceGlobals(zap.Must(zap.NewDevelopment()))
@@ -108,15 +195,42 @@ func init() {
 	command.AddCommand(indexCommand)
 	command.AddCommand(schedulerCommand)
 	command.AddCommand(settlerCommand)
+	command.AddCommand(adminCommand)
+
+	indexCommand.AddCommand(indexBackfillCommand)
+	settlerCommand.AddCommand(settlerSimulateCommand)
+	adminCommand.AddCommand(adminCheckpointCommand)
+	adminCheckpointCommand.AddCommand(adminCheckpointListCommand, adminCheckpointSetCommand, adminCheckpointRewindCommand)
 
 	command.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
 	command.PersistentFlags().Uint64(flag.KeyChainIDL1, flag.ValueChainIDL1, "l1 chain id")
 	command.PersistentFlags().Uint64(flag.KeyChainIDL2, flag.ValueChainIDL2, "l2 chain id")
 
 	indexCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
+	indexBackfillCommand.Flags().String(flag.KeyChain, "", "chain to backfill, l1 or l2")
+	indexBackfillCommand.Flags().Uint64(flag.KeyFrom, 0, "first block to backfill")
+	indexBackfillCommand.Flags().Uint64(flag.KeyTo, 0, "last block to backfill, the latest finalized block if 0")
+	indexBackfillCommand.Flags().Uint64(flag.KeyRangeSize, 100, "number of blocks committed with the checkpoint at a time")
 	schedulerCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
 	schedulerCommand.PersistentFlags().String(flag.KeyServer, "detector", "server name")
 	settlerCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
+	settlerCommand.PersistentFlags().String(flag.KeyOutput, "", "output file path of the rewards breakdown, stdout if empty")
+	settlerCommand.PersistentFlags().String(flag.KeyFormat, settler.FormatJSON, "format of the rewards breakdown, json or csv")
+	settlerCommand.Flags().Bool(flag.KeyDryRun, false, "calculate the Operation Rewards of the next epoch without submitting them")
+	settlerSimulateCommand.Flags().Uint64(flag.KeyEpoch, 0, "epoch to simulate, a settled epoch is rebuilt from its persisted breakdowns, the next epoch if 0")
+	adminCommand.PersistentFlags().String(flag.KeyConfig, "./deploy/config.yaml", "config file path")
+	adminCheckpointCommand.PersistentFlags().Bool(flag.KeyYes, false, "skip the confirmation")
+	adminCheckpointSetCommand.Flags().String(flag.KeyChain, "", "chain of the checkpoint, l1 or l2")
+	adminCheckpointSetCommand.Flags().Uint64(flag.KeyBlock, 0, "block to set the checkpoint to")
+	adminCheckpointRewindCommand.Flags().String(flag.KeyChain, "", "chain of the checkpoint, l1 or l2")
+	adminCheckpointRewindCommand.Flags().Uint64(flag.KeyBlocks, 0, "number of blocks to rewind")
+
+	lo.Must0(indexBackfillCommand.MarkFlagRequired(flag.KeyChain))
+	lo.Must0(indexBackfillCommand.MarkFlagRequired(flag.KeyFrom))
+	lo.Must0(adminCheckpointSetCommand.MarkFlagRequired(flag.KeyChain))
+	lo.Must0(adminCheckpointSetCommand.MarkFlagRequired(flag.KeyBlock))
+	lo.Must0(adminCheckpointRewindCommand.MarkFlagRequired(flag.KeyChain))
+	lo.Must0(adminCheckpointRewindCommand.MarkFlagRequired(flag.KeyBlocks))
 }
 
 func main() {
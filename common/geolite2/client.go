@@ -13,12 +13,68 @@ import (
 	"go.uber.org/zap"
 )
 
-type Client struct {
+// Provider looks up the location of an IP address from an offline database.
+type Provider interface {
+	// Lookup returns the location of the IP address, or nil if the database has no record of it.
+	Lookup(ip net.IP) (*schema.NodeLocation, error)
+}
+
+var _ Provider = (*cityProvider)(nil)
+
+// cityProvider looks up the locations from a database in the format of GeoIP2 City, such as GeoLite2-City.
+type cityProvider struct {
 	reader *geoip2.Reader
 }
 
+func (p *cityProvider) Lookup(ip net.IP) (*schema.NodeLocation, error) {
+	record, err := p.reader.City(ip)
+	if err != nil {
+		return nil, fmt.Errorf("get city: %w", err)
+	}
+
+	if record.Location.Longitude == 0 && record.Location.Latitude == 0 {
+		return nil, nil
+	}
+
+	local := &schema.NodeLocation{
+		Latitude:  record.Location.Latitude,
+		Longitude: record.Location.Longitude,
+	}
+
+	if len(record.Country.Names) > 0 {
+		local.Country = record.Country.Names["en"]
+	}
+
+	if len(record.Subdivisions) > 0 && len(record.Subdivisions[0].Names) > 0 {
+		local.Region = record.Subdivisions[0].Names["en"]
+	}
+
+	if len(record.City.Names) > 0 {
+		local.City = record.City.Names["en"]
+	}
+
+	return local, nil
+}
+
+// NewCityProvider returns a Provider of a database in the format of GeoIP2 City.
+func NewCityProvider(file string) (Provider, error) {
+	reader, err := geoip2.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open database %s: %w", file, err)
+	}
+
+	return &cityProvider{reader: reader}, nil
+}
+
+type Client struct {
+	// providers are consulted in order until one of them has the record.
+	providers []Provider
+	// asnReader enriches the locations with the autonomous systems, it is optional.
+	asnReader *geoip2.Reader
+}
+
 func (c *Client) LookupNodeLocation(_ context.Context, endpoint string) ([]*schema.NodeLocation, error) {
-	if c == nil || c.reader == nil {
+	if c == nil || len(c.providers) == 0 {
 		zap.L().Warn("geoip2 client is nil")
 
 		return nil, nil
@@ -34,11 +90,7 @@ func (c *Client) LookupNodeLocation(_ context.Context, endpoint string) ([]*sche
 			return nil, fmt.Errorf("lookup endpoint: %w", err)
 		}
 
-		for _, ipAddress := range ipAddresses {
-			if ipv4 := ipAddress.To4(); ipv4 != nil {
-				ips = append(ips, ipv4)
-			}
-		}
+		ips = append(ips, ipAddresses...)
 	} else {
 		ips = append(ips, ip)
 	}
@@ -46,36 +98,64 @@ func (c *Client) LookupNodeLocation(_ context.Context, endpoint string) ([]*sche
 	records := make([]*schema.NodeLocation, 0, len(ips))
 
 	for _, ip := range ips {
-		record, err := c.reader.City(ip)
-		if err != nil {
-			return nil, fmt.Errorf("get city: %w", err)
+		if ipv4 := ip.To4(); ipv4 != nil {
+			ip = ipv4
 		}
 
-		if record.Location.Longitude == 0 && record.Location.Latitude == 0 {
-			continue
+		local, err := c.lookup(ip)
+		if err != nil {
+			return nil, err
 		}
 
-		local := &schema.NodeLocation{
-			Latitude:  record.Location.Latitude,
-			Longitude: record.Location.Longitude,
+		if local != nil {
+			records = append(records, local)
 		}
+	}
 
-		if len(record.Country.Names) > 0 {
-			local.Country = record.Country.Names["en"]
-		}
+	return records, nil
+}
+
+// lookup returns the location of the IP address from the first provider having the record, enriched with its autonomous system.
+// A provider failing to look up the IP address is skipped.
+func (c *Client) lookup(ip net.IP) (*schema.NodeLocation, error) {
+	var local *schema.NodeLocation
+
+	for index, provider := range c.providers {
+		record, err := provider.Lookup(ip)
+		if err != nil {
+			zap.L().Warn("lookup ip from provider failed", zap.Stringer("ip", ip), zap.Int("provider", index), zap.Error(err))
 
-		if len(record.Subdivisions) > 0 && len(record.Subdivisions[0].Names) > 0 {
-			local.Region = record.Subdivisions[0].Names["en"]
+			continue
 		}
 
-		if len(record.City.Names) > 0 {
-			local.City = record.City.Names["en"]
+		if record != nil {
+			local = record
+
+			break
 		}
+	}
 
-		records = append(records, local)
+	if local == nil || c.asnReader == nil {
+		return local, nil
 	}
 
-	return records, nil
+	record, err := c.asnReader.ASN(ip)
+	if err != nil {
+		zap.L().Warn("lookup asn failed", zap.Stringer("ip", ip), zap.Error(err))
+
+		return local, nil
+	}
+
+	local.ASN = record.AutonomousSystemNumber
+	local.NetworkProvider = record.AutonomousSystemOrganization
+	local.ASNLookedUp = true
+
+	return local, nil
+}
+
+// ASNLoaded returns whether the locations are enriched with the autonomous systems.
+func (c *Client) ASNLoaded() bool {
+	return c != nil && c.asnReader != nil
 }
 
 func NewClient(conf *config.GeoIP) *Client {
@@ -87,7 +167,7 @@ func NewClient(conf *config.GeoIP) *Client {
 		LockFile:          filepath.Join(dir, ".geoipupdate.lock"),
 		AccountID:         conf.Account,
 		LicenseKey:        conf.LicenseKey,
-		EditionIDs:        []string{"GeoLite2-City"},
+		EditionIDs:        []string{"GeoLite2-City", "GeoLite2-ASN"},
 		Output:            true,
 		Verbose:           true,
 		Parallelism:       1,
@@ -100,13 +180,32 @@ func NewClient(conf *config.GeoIP) *Client {
 		zap.L().Warn("run geoipupdate failed", zap.Error(err))
 	}
 
-	reader, err := geoip2.Open(conf.File)
-	if err != nil {
-		zap.L().Warn("open geoip2 database failed", zap.Error(err))
+	var instance Client
+
+	for _, file := range append([]string{conf.File}, conf.FallbackFiles...) {
+		provider, err := NewCityProvider(file)
+		if err != nil {
+			zap.L().Warn("open geoip2 database failed", zap.Error(err))
+
+			continue
+		}
+
+		instance.providers = append(instance.providers, provider)
+	}
+
+	if len(instance.providers) == 0 {
 		return nil
 	}
 
-	return &Client{
-		reader: reader,
+	// The GeoLite2-ASN database is downloaded into the directory of the File by default.
+	asnFile := conf.ASNFile
+	if asnFile == "" {
+		asnFile = filepath.Join(dir, "GeoLite2-ASN.mmdb")
 	}
+
+	if instance.asnReader, err = geoip2.Open(asnFile); err != nil {
+		zap.L().Warn("open geoip2 asn database failed", zap.Error(err))
+	}
+
+	return &instance
 }
@@ -0,0 +1,108 @@
+package geolite2
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapProvider map[string]*schema.NodeLocation
+
+// Lookup returns an error if the location of the IP address is nil.
+func (p mapProvider) Lookup(ip net.IP) (*schema.NodeLocation, error) {
+	if location, exists := p[ip.String()]; exists {
+		if location == nil {
+			return nil, errors.New("invalid ip")
+		}
+
+		location := *location
+
+		return &location, nil
+	}
+
+	return nil, nil
+}
+
+func TestLookupNodeLocation(t *testing.T) {
+	t.Parallel()
+
+	client := Client{
+		providers: []Provider{
+			mapProvider{
+				"1.2.3.4": {Country: "Australia", Latitude: -33.494, Longitude: 143.2104},
+				"0.0.0.0": nil,
+				"9.9.9.9": nil,
+			},
+			mapProvider{
+				"1.2.3.4":     {Country: "Japan", Latitude: 35.6897, Longitude: 139.6895},
+				"2001:db8::1": {Country: "Germany", Latitude: 51.2993, Longitude: 9.491},
+				"9.9.9.9":     {Country: "France", Latitude: 48.8566, Longitude: 2.3522},
+				"0.0.0.0":     nil,
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		endpoint string
+		expected []*schema.NodeLocation
+	}{
+		{
+			name:     "ipv4",
+			endpoint: "1.2.3.4",
+			expected: []*schema.NodeLocation{{Country: "Australia", Latitude: -33.494, Longitude: 143.2104}},
+		},
+		{
+			name:     "ipv6 from the fallback provider",
+			endpoint: "2001:db8::1",
+			expected: []*schema.NodeLocation{{Country: "Germany", Latitude: 51.2993, Longitude: 9.491}},
+		},
+		{
+			name:     "ipv4 mapped ipv6",
+			endpoint: "::ffff:1.2.3.4",
+			expected: []*schema.NodeLocation{{Country: "Australia", Latitude: -33.494, Longitude: 143.2104}},
+		},
+		{
+			name:     "no record",
+			endpoint: "5.6.7.8",
+			expected: []*schema.NodeLocation{},
+		},
+		{
+			name:     "provider error falls back to the next provider",
+			endpoint: "9.9.9.9",
+			expected: []*schema.NodeLocation{{Country: "France", Latitude: 48.8566, Longitude: 2.3522}},
+		},
+		{
+			name:     "all providers error",
+			endpoint: "0.0.0.0",
+			expected: []*schema.NodeLocation{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			locations, err := client.LookupNodeLocation(context.Background(), testcase.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expected, locations)
+		})
+	}
+}
+
+func TestLookupNodeLocationWithoutProvider(t *testing.T) {
+	t.Parallel()
+
+	var client *Client
+
+	locations, err := client.LookupNodeLocation(context.Background(), "1.2.3.4")
+	require.NoError(t, err)
+	assert.Nil(t, locations)
+}
@@ -0,0 +1,214 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// Journal persists the transactions sent by the SimpleTxManager, so they can be recovered after a restart.
+type Journal interface {
+	SaveTxJournal(ctx context.Context, journal *schema.TxJournal) error
+	FindTxJournals(ctx context.Context, query schema.TxJournalsQuery) ([]*schema.TxJournal, error)
+}
+
+// newJournal returns the journal of a transaction crafted from the candidate, it is nil if the journal is disabled.
+func (m *SimpleTxManager) newJournal(account *Account, candidate TxCandidate) *schema.TxJournal {
+	if m.journal == nil {
+		return nil
+	}
+
+	return &schema.TxJournal{
+		Service:  m.service,
+		ChainID:  m.chainID.Uint64(),
+		From:     account.address,
+		To:       candidate.To,
+		Data:     candidate.TxData,
+		Value:    candidate.Value,
+		GasLimit: candidate.GasLimit,
+		Metadata: candidate.Metadata,
+		Status:   schema.TxJournalStatusPending,
+	}
+}
+
+// journalTx records a submission of the transaction before it is published.
+func (m *SimpleTxManager) journalTx(ctx context.Context, journal *schema.TxJournal, tx *types.Transaction) {
+	if journal == nil {
+		return
+	}
+
+	rawTransaction, err := tx.MarshalBinary()
+	if err != nil {
+		zap.L().Error("marshal transaction", zap.Error(err), zap.String("hash", tx.Hash().String()))
+
+		return
+	}
+
+	journal.Nonce = tx.Nonce()
+	journal.GasLimit = tx.Gas()
+	journal.GasTipCap = tx.GasTipCap()
+	journal.GasFeeCap = tx.GasFeeCap()
+	journal.RawTransaction = rawTransaction
+
+	if !lo.Contains(journal.TransactionHashes, tx.Hash()) {
+		journal.TransactionHashes = append(journal.TransactionHashes, tx.Hash())
+	}
+
+	if err := m.journal.SaveTxJournal(context.WithoutCancel(ctx), journal); err != nil {
+		zap.L().Error("save tx journal", zap.Error(err), zap.String("hash", tx.Hash().String()))
+	}
+}
+
+// finishJournal records the result of sending the transaction.
+// The transaction is left pending if the sending is canceled, since a submission may still be mined.
+func (m *SimpleTxManager) finishJournal(ctx context.Context, journal *schema.TxJournal, receipt *types.Receipt, err error) {
+	if journal == nil {
+		return
+	}
+
+	switch {
+	case err == nil:
+		journal.Status = schema.TxJournalStatusConfirmed
+		journal.Receipt = &schema.TxJournalReceipt{
+			TransactionHash: receipt.TxHash,
+			BlockNumber:     receipt.BlockNumber.Uint64(),
+			Status:          receipt.Status,
+			GasUsed:         receipt.GasUsed,
+		}
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return
+	default:
+		journal.Status = schema.TxJournalStatusFailed
+	}
+
+	if err := m.journal.SaveTxJournal(context.WithoutCancel(ctx), journal); err != nil {
+		zap.L().Error("save tx journal", zap.Error(err), zap.Uint64("id", journal.ID))
+	}
+}
+
+// Recover reconciles the transactions of the service left pending in the journal against the chain.
+// A transaction with a submission mined is confirmed, and a transaction whose nonce is used by another transaction is dropped.
+// The others are resumed from their latest submission, and replaced with bumped fees if they are not mined,
+// rather than being sent again with a new nonce.
+// Recover returns once all of them are settled, onConfirmed is called with each transaction confirmed if it is not nil.
+func (m *SimpleTxManager) Recover(ctx context.Context, onConfirmed RecoverFunc) error {
+	if m.journal == nil {
+		return nil
+	}
+
+	journals, err := m.journal.FindTxJournals(ctx, schema.TxJournalsQuery{
+		ChainID: lo.ToPtr(m.chainID.Uint64()),
+		Service: lo.ToPtr(m.service),
+		Status:  lo.ToPtr(schema.TxJournalStatusPending),
+	})
+	if err != nil {
+		return fmt.Errorf("find pending tx journals: %w", err)
+	}
+
+	for _, journal := range journals {
+		receipt, err := m.recoverTx(ctx, journal)
+		if err != nil {
+			return fmt.Errorf("recover tx journal %d: %w", journal.ID, err)
+		}
+
+		if receipt == nil || onConfirmed == nil {
+			continue
+		}
+
+		if err := onConfirmed(ctx, journal, receipt); err != nil {
+			return fmt.Errorf("confirm tx journal %d: %w", journal.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// recoverTx returns the receipt of the transaction if it is confirmed.
+func (m *SimpleTxManager) recoverTx(ctx context.Context, journal *schema.TxJournal) (*types.Receipt, error) {
+	for _, hash := range journal.TransactionHashes {
+		receipt, err := m.ethereumClient.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("get receipt of %s: %w", hash, err)
+		}
+
+		zap.L().Info("pending transaction mined", zap.Uint64("id", journal.ID), zap.String("hash", hash.String()))
+
+		m.finishJournal(ctx, journal, receipt, nil)
+
+		return receipt, nil
+	}
+
+	nonce, err := m.ethereumClient.NonceAt(ctx, journal.From, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get nonce: %w", err)
+	}
+
+	if nonce > journal.Nonce {
+		zap.L().Warn("pending transaction dropped", zap.Uint64("id", journal.ID), zap.Uint64("nonce", journal.Nonce))
+
+		journal.Status = schema.TxJournalStatusDropped
+
+		return nil, m.journal.SaveTxJournal(ctx, journal)
+	}
+
+	account, exists := m.signerPool.accountByAddress(journal.From)
+	if !exists {
+		zap.L().Warn("account of pending transaction not found", zap.Uint64("id", journal.ID), zap.String("from", journal.From.String()))
+
+		journal.Status = schema.TxJournalStatusFailed
+
+		return nil, m.journal.SaveTxJournal(ctx, journal)
+	}
+
+	var tx types.Transaction
+
+	if err := tx.UnmarshalBinary(journal.RawTransaction); err != nil {
+		return nil, fmt.Errorf("unmarshal transaction: %w", err)
+	}
+
+	// The nonces up to the pending transaction are in use, the next transaction of the account takes the following one.
+	account.nonceLock.Lock()
+	if account.nonce == nil || *account.nonce < journal.Nonce {
+		account.nonce = lo.ToPtr(journal.Nonce)
+	}
+	account.nonceLock.Unlock()
+
+	zap.L().Info("resume pending transaction", zap.Uint64("id", journal.ID), zap.String("hash", tx.Hash().String()), zap.Uint64("nonce", journal.Nonce))
+
+	sendCtx := ctx
+
+	if m.cfg.TxSendTimeout != 0 {
+		var cancel context.CancelFunc
+		sendCtx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+
+		defer cancel()
+	}
+
+	receipt, err := m.sendTx(sendCtx, account, &tx, journal)
+	m.finishJournal(sendCtx, journal, receipt, err)
+
+	if err != nil {
+		account.resetNonce()
+
+		// The transaction is left pending to be recovered again by the next run.
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		zap.L().Error("resume pending transaction", zap.Error(err), zap.Uint64("id", journal.ID))
+
+		return nil, nil
+	}
+
+	return receipt, nil
+}
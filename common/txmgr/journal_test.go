@@ -0,0 +1,94 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryJournal struct {
+	journals map[uint64]schema.TxJournal
+}
+
+func (j *memoryJournal) SaveTxJournal(_ context.Context, journal *schema.TxJournal) error {
+	if journal.ID == 0 {
+		journal.ID = uint64(len(j.journals) + 1)
+	}
+
+	j.journals[journal.ID] = *journal
+
+	return nil
+}
+
+func (j *memoryJournal) FindTxJournals(_ context.Context, _ schema.TxJournalsQuery) ([]*schema.TxJournal, error) {
+	return nil, nil
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	journal := &memoryJournal{journals: make(map[uint64]schema.TxJournal)}
+	manager := &SimpleTxManager{chainID: big.NewInt(1), journal: journal, service: ServiceSettler}
+
+	to := common.HexToAddress("0x2")
+	account := &Account{address: common.HexToAddress("0x1")}
+	candidate := TxCandidate{To: &to, GasLimit: 21000, Value: big.NewInt(0)}
+
+	newTx := func(gasTipCap int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     7,
+			GasTipCap: big.NewInt(gasTipCap),
+			GasFeeCap: big.NewInt(gasTipCap * 2),
+			Gas:       candidate.GasLimit,
+			To:        candidate.To,
+			Value:     candidate.Value,
+		})
+	}
+
+	txJournal := manager.newJournal(account, candidate)
+	require.NotNil(t, txJournal)
+
+	// Each submission is journaled once.
+	tx := newTx(1)
+	manager.journalTx(context.Background(), txJournal, tx)
+	manager.journalTx(context.Background(), txJournal, tx)
+
+	bumpedTx := newTx(2)
+	manager.journalTx(context.Background(), txJournal, bumpedTx)
+
+	saved := journal.journals[txJournal.ID]
+	assert.Equal(t, ServiceSettler, saved.Service)
+	assert.Equal(t, uint64(7), saved.Nonce)
+	assert.Equal(t, []common.Hash{tx.Hash(), bumpedTx.Hash()}, saved.TransactionHashes)
+	assert.Equal(t, big.NewInt(2), saved.GasTipCap)
+
+	var rawTx types.Transaction
+
+	require.NoError(t, rawTx.UnmarshalBinary(saved.RawTransaction))
+	assert.Equal(t, bumpedTx.Hash(), rawTx.Hash())
+
+	// The transaction is left pending if the sending is canceled.
+	manager.finishJournal(context.Background(), txJournal, nil, context.DeadlineExceeded)
+	assert.Equal(t, schema.TxJournalStatusPending, journal.journals[txJournal.ID].Status)
+
+	manager.finishJournal(context.Background(), txJournal, &types.Receipt{TxHash: bumpedTx.Hash(), BlockNumber: big.NewInt(10), Status: types.ReceiptStatusSuccessful}, nil)
+	saved = journal.journals[txJournal.ID]
+	assert.Equal(t, schema.TxJournalStatusConfirmed, saved.Status)
+	assert.Equal(t, &schema.TxJournalReceipt{TransactionHash: bumpedTx.Hash(), BlockNumber: 10, Status: types.ReceiptStatusSuccessful}, saved.Receipt)
+
+	abortedJournal := manager.newJournal(account, candidate)
+	manager.journalTx(context.Background(), abortedJournal, tx)
+	manager.finishJournal(context.Background(), abortedJournal, nil, errors.New("aborted transaction sending"))
+	assert.Equal(t, schema.TxJournalStatusFailed, journal.journals[abortedJournal.ID].Status)
+
+	// The transactions are not journaled if the journal is disabled.
+	assert.Nil(t, (&SimpleTxManager{chainID: big.NewInt(1)}).newJournal(account, candidate))
+}
@@ -0,0 +1,168 @@
+package txmgr
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
+)
+
+// The services that send transactions to the VSL.
+const (
+	ServiceSettler  = "settler"
+	ServiceEnforcer = "enforcer"
+	ServiceTaxer    = "taxer"
+)
+
+// Signer is an account loaded into the SignerPool.
+type Signer struct {
+	Address  common.Address
+	SignerFn gicrypto.SignerFn
+	// Services are the services assigned to the account.
+	Services []string
+}
+
+// Account is an account of the SignerPool, its nonce is tracked locally.
+type Account struct {
+	address common.Address
+
+	signer     gicrypto.SignerFn
+	signerLock sync.RWMutex
+
+	nonce     *uint64
+	nonceLock sync.Mutex
+}
+
+// Address returns the address of the account.
+func (a *Account) Address() common.Address {
+	return a.address
+}
+
+func (a *Account) signerFn() gicrypto.SignerFn {
+	a.signerLock.RLock()
+	defer a.signerLock.RUnlock()
+
+	return a.signer
+}
+
+func (a *Account) setSignerFn(signer gicrypto.SignerFn) {
+	a.signerLock.Lock()
+	defer a.signerLock.Unlock()
+
+	a.signer = signer
+}
+
+func (a *Account) resetNonce() {
+	a.nonceLock.Lock()
+	defer a.nonceLock.Unlock()
+
+	a.nonce = nil
+}
+
+// SignerPool holds the accounts used to send transactions and assigns them to services.
+type SignerPool struct {
+	lock sync.RWMutex
+
+	accounts map[common.Address]*Account
+	// services maps a service to the address of its account.
+	services map[string]common.Address
+	// fallback is the address of the account used by the services not assigned.
+	fallback common.Address
+}
+
+// Load replaces the accounts of the pool with the signers, which rotates the keys without a restart.
+// An account which is still loaded keeps its nonce, and transactions being sent by a removed account are not affected.
+// The first signer is the account of the services not assigned.
+func (p *SignerPool) Load(signers []Signer) error {
+	if len(signers) == 0 {
+		return fmt.Errorf("no signer")
+	}
+
+	services := make(map[string]common.Address)
+
+	for _, signer := range signers {
+		if signer.SignerFn == nil {
+			return fmt.Errorf("no signer function for %s", signer.Address)
+		}
+
+		for _, service := range signer.Services {
+			if address, exists := services[service]; exists && address != signer.Address {
+				return fmt.Errorf("service %s is assigned to both %s and %s", service, address, signer.Address)
+			}
+
+			services[service] = signer.Address
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	accounts := make(map[common.Address]*Account, len(signers))
+
+	for _, signer := range signers {
+		account, exists := p.accounts[signer.Address]
+		if !exists {
+			account = &Account{address: signer.Address}
+		}
+
+		account.setSignerFn(signer.SignerFn)
+		accounts[signer.Address] = account
+	}
+
+	p.accounts = accounts
+	p.services = services
+	p.fallback = signers[0].Address
+
+	return nil
+}
+
+// Account returns the account assigned to the service.
+func (p *SignerPool) Account(service string) (*Account, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	address, exists := p.services[service]
+	if !exists {
+		address = p.fallback
+	}
+
+	account, exists := p.accounts[address]
+	if !exists {
+		return nil, fmt.Errorf("no account for service %s", service)
+	}
+
+	return account, nil
+}
+
+func (p *SignerPool) accountByAddress(address common.Address) (*Account, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	account, exists := p.accounts[address]
+
+	return account, exists
+}
+
+// Addresses returns the addresses of the accounts in the pool.
+func (p *SignerPool) Addresses() []common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	addresses := make([]common.Address, 0, len(p.accounts))
+	for address := range p.accounts {
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+func NewSignerPool(signers []Signer) (*SignerPool, error) {
+	var pool SignerPool
+
+	if err := pool.Load(signers); err != nil {
+		return nil, err
+	}
+
+	return &pool, nil
+}
@@ -0,0 +1,70 @@
+package txmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerPool(t *testing.T) {
+	t.Parallel()
+
+	signerFn := func(_ context.Context, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	}
+
+	var (
+		defaultAddress = common.HexToAddress("0x1")
+		settlerAddress = common.HexToAddress("0x2")
+		rotatedAddress = common.HexToAddress("0x3")
+	)
+
+	pool, err := NewSignerPool([]Signer{
+		{Address: defaultAddress, SignerFn: signerFn},
+		{Address: settlerAddress, SignerFn: signerFn, Services: []string{ServiceSettler}},
+	})
+	require.NoError(t, err)
+
+	settlerAccount, err := pool.Account(ServiceSettler)
+	require.NoError(t, err)
+	assert.Equal(t, settlerAddress, settlerAccount.Address())
+
+	// The services not assigned use the first signer.
+	taxerAccount, err := pool.Account(ServiceTaxer)
+	require.NoError(t, err)
+	assert.Equal(t, defaultAddress, taxerAccount.Address())
+
+	nonce := uint64(10)
+	settlerAccount.nonce = &nonce
+
+	// The accounts still loaded keep their nonce after the keys are rotated.
+	require.NoError(t, pool.Load([]Signer{
+		{Address: rotatedAddress, SignerFn: signerFn},
+		{Address: settlerAddress, SignerFn: signerFn, Services: []string{ServiceSettler}},
+	}))
+
+	account, err := pool.Account(ServiceSettler)
+	require.NoError(t, err)
+	assert.Same(t, settlerAccount, account)
+	assert.Equal(t, uint64(10), *account.nonce)
+
+	account, err = pool.Account(ServiceTaxer)
+	require.NoError(t, err)
+	assert.Equal(t, rotatedAddress, account.Address())
+	assert.Len(t, pool.Addresses(), 2)
+
+	// An invalid reload keeps the loaded accounts.
+	assert.Error(t, pool.Load(nil))
+	assert.Error(t, pool.Load([]Signer{
+		{Address: defaultAddress, SignerFn: signerFn, Services: []string{ServiceEnforcer}},
+		{Address: settlerAddress, SignerFn: signerFn, Services: []string{ServiceEnforcer}},
+	}))
+
+	account, err = pool.Account(ServiceTaxer)
+	require.NoError(t, err)
+	assert.Equal(t, rotatedAddress, account.Address())
+}
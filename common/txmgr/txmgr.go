@@ -2,6 +2,7 @@ package txmgr
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -17,7 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
+	"github.com/rss3-network/global-indexer/schema"
 	"go.uber.org/zap"
 )
 
@@ -32,18 +33,22 @@ var oneHundred = big.NewInt(100)
 
 type TxManager interface {
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
+	Recover(ctx context.Context, onConfirmed RecoverFunc) error
 }
 
+// RecoverFunc is called with each transaction confirmed by Recover, so the service can finish the work of the transaction.
+type RecoverFunc func(ctx context.Context, journal *schema.TxJournal, receipt *types.Receipt) error
+
 type SimpleTxManager struct {
 	cfg Config
 
 	chainID        *big.Int
 	ethereumClient *ethclient.Client
-	from           common.Address
-	nonce          *uint64
-	nonceLock      sync.RWMutex
 
-	signer gicrypto.SignerFn
+	signerPool *SignerPool
+	journal    Journal
+	// service is the service the transactions are sent for, which decides the account signing them.
+	service string
 }
 
 type TxCandidate struct {
@@ -55,26 +60,36 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Metadata is journaled with the tx, for the service to resume its work once the tx is recovered.
+	Metadata json.RawMessage
+}
+
+// WithService returns a SimpleTxManager sending transactions with the account assigned to the service.
+func (m *SimpleTxManager) WithService(service string) *SimpleTxManager {
+	manager := *m
+	manager.service = service
+
+	return &manager
 }
 
 // Send sends a candidate to the VSL.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
-	receipt, err := m.send(ctx, candidate)
+	// The account is resolved on each send, so the rotated keys take effect on the next transaction.
+	account, err := m.signerPool.Account(m.service)
 	if err != nil {
-		m.resetNonce()
+		return nil, fmt.Errorf("failed to get the account: %w", err)
 	}
 
-	return receipt, err
-}
+	receipt, err := m.send(ctx, account, candidate)
+	if err != nil {
+		account.resetNonce()
+	}
 
-func (m *SimpleTxManager) resetNonce() {
-	m.nonceLock.Lock()
-	defer m.nonceLock.Unlock()
-	m.nonce = nil
+	return receipt, err
 }
 
 // send performs the actual transaction creation and sending.
-func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+func (m *SimpleTxManager) send(ctx context.Context, account *Account, candidate TxCandidate) (*types.Receipt, error) {
 	if m.cfg.TxSendTimeout != 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
@@ -89,7 +104,7 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	)
 
 	if tx, err = retry.DoWithData(func() (*types.Transaction, error) {
-		tx, err = m.craftTx(ctx, candidate)
+		tx, err = m.craftTx(ctx, account, candidate)
 		if err != nil {
 			zap.L().Warn("Failed to create a transaction, will retry", zap.Error(err))
 
@@ -101,10 +116,15 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
 
-	return m.sendTx(ctx, tx)
+	journal := m.newJournal(account, candidate)
+
+	receipt, err := m.sendTx(ctx, account, tx, journal)
+	m.finishJournal(ctx, journal, receipt, err)
+
+	return receipt, err
 }
 
-func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
+func (m *SimpleTxManager) craftTx(ctx context.Context, account *Account, candidate TxCandidate) (*types.Transaction, error) {
 	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
@@ -127,7 +147,7 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 	} else {
 		// Calculate the intrinsic gas for the transaction
 		gas, err := m.ethereumClient.EstimateGas(ctx, ethereum.CallMsg{
-			From:      m.from,
+			From:      account.address,
 			To:        candidate.To,
 			GasFeeCap: gasFeeCap,
 			GasTipCap: gasTipCap,
@@ -141,10 +161,10 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		rawTx.Gas = gas
 	}
 
-	return m.signWithNextNonce(ctx, rawTx)
+	return m.signWithNextNonce(ctx, account, rawTx)
 }
 
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+func (m *SimpleTxManager) sendTx(ctx context.Context, account *Account, tx *types.Transaction, journal *schema.TxJournal) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
@@ -157,7 +177,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
 		wg.Add(1)
 
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
+		tx, published := m.publishTx(ctx, account, tx, journal, sendState, bumpFees)
 
 		if published {
 			go func() {
@@ -235,47 +255,47 @@ func calcGasFeeCap(baseFee, gasTipCap *big.Int) *big.Int {
 	)
 }
 
-func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.DynamicFeeTx) (*types.Transaction, error) {
-	m.nonceLock.Lock()
-	defer m.nonceLock.Unlock()
+func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, account *Account, rawTx *types.DynamicFeeTx) (*types.Transaction, error) {
+	account.nonceLock.Lock()
+	defer account.nonceLock.Unlock()
 
-	if m.nonce == nil {
+	if account.nonce == nil {
 		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
 		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 		defer cancel()
 
-		nonce, err := m.ethereumClient.NonceAt(childCtx, m.from, nil)
+		nonce, err := m.ethereumClient.NonceAt(childCtx, account.address, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get nonce: %w", err)
 		}
 
-		m.nonce = &nonce
+		account.nonce = &nonce
 	} else {
-		*m.nonce++
+		*account.nonce++
 	}
 
-	rawTx.Nonce = *m.nonce
+	rawTx.Nonce = *account.nonce
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 
 	defer cancel()
 
-	tx, err := m.signer(ctx, m.from, types.NewTx(rawTx))
+	tx, err := account.signerFn()(ctx, account.address, types.NewTx(rawTx))
 
 	if err != nil {
 		// decrement the nonce, so we can retry signing with the same nonce next time
 		// signWithNextNonce is called
-		*m.nonce--
+		*account.nonce--
 	}
 
 	return tx, err
 }
 
-func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool) (*types.Transaction, bool) {
+func (m *SimpleTxManager) publishTx(ctx context.Context, account *Account, tx *types.Transaction, journal *schema.TxJournal, sendState *SendState, bumpFeesImmediately bool) (*types.Transaction, bool) {
 	var resetCurrentNonce bool
 
 	for {
 		if resetCurrentNonce {
-			newTx, err := m.resetCurrentNonce(ctx, tx)
+			newTx, err := m.resetCurrentNonce(ctx, account, tx)
 			if err != nil {
 				return tx, false
 			}
@@ -284,7 +304,7 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 		}
 
 		if bumpFeesImmediately {
-			newTx, err := m.increaseGasPrice(ctx, tx)
+			newTx, err := m.increaseGasPrice(ctx, account, tx)
 			if err != nil {
 				return tx, false
 			}
@@ -301,6 +321,9 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			return tx, false
 		}
 
+		// Journal the submission before publishing it, so it can be recovered if the process stops.
+		m.journalTx(ctx, journal, tx)
+
 		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 		err := m.ethereumClient.SendTransaction(cCtx, tx)
 
@@ -470,7 +493,7 @@ func calcThresholdValue(x *big.Int) *big.Int {
 	return threshold
 }
 
-func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, account *Account, tx *types.Transaction) (*types.Transaction, error) {
 	zap.L().Info("bumping gas price for tx", zap.String("hash", tx.Hash().String()), zap.Uint64("tip", tx.GasTipCap().Uint64()), zap.Uint64("fee", tx.GasFeeCap().Uint64()), zap.Uint64("gaslimit", tx.Gas()))
 
 	tip, basefee, err := m.suggestGasPriceCaps(ctx)
@@ -512,7 +535,7 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 
-	newTx, err := m.signer(ctx, m.from, types.NewTx(rawTx))
+	newTx, err := account.signerFn()(ctx, account.address, types.NewTx(rawTx))
 
 	if err != nil {
 		zap.L().Warn("failed to sign new transaction", zap.Error(err))
@@ -522,9 +545,9 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return newTx, nil
 }
 
-func (m *SimpleTxManager) resetCurrentNonce(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	m.nonceLock.Lock()
-	defer m.nonceLock.Unlock()
+func (m *SimpleTxManager) resetCurrentNonce(ctx context.Context, account *Account, tx *types.Transaction) (*types.Transaction, error) {
+	account.nonceLock.Lock()
+	defer account.nonceLock.Unlock()
 
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    tx.ChainId(),
@@ -537,25 +560,25 @@ func (m *SimpleTxManager) resetCurrentNonce(ctx context.Context, tx *types.Trans
 		AccessList: tx.AccessList(),
 	}
 
-	nonce, err := m.ethereumClient.NonceAt(ctx, m.from, nil)
+	nonce, err := m.ethereumClient.NonceAt(ctx, account.address, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	*m.nonce = nonce
-	rawTx.Nonce = *m.nonce
+	account.nonce = &nonce
+	rawTx.Nonce = *account.nonce
 
-	zap.L().Info("reset nonce", zap.Uint64("nonce", *m.nonce))
+	zap.L().Info("reset nonce", zap.String("account", account.address.String()), zap.Uint64("nonce", *account.nonce))
 
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 
-	newTx, err := m.signer(ctx, m.from, types.NewTx(rawTx))
+	newTx, err := account.signerFn()(ctx, account.address, types.NewTx(rawTx))
 
 	if err != nil {
 		zap.L().Warn("failed to sign new transaction", zap.Error(err))
 
-		*m.nonce--
+		*account.nonce--
 
 		return tx, nil
 	}
@@ -578,7 +601,8 @@ func EncodeInput(contractABI, methodName string, args ...interface{}) ([]byte, e
 	return encodedArgs, nil
 }
 
-func NewSimpleTxManager(conf Config, chainID *big.Int, nonce *uint64, ethereumClient *ethclient.Client, from common.Address, singer gicrypto.SignerFn) (*SimpleTxManager, error) {
+// NewSimpleTxManager creates a SimpleTxManager, the transactions are not journaled if the journal is nil.
+func NewSimpleTxManager(conf Config, chainID *big.Int, ethereumClient *ethclient.Client, signerPool *SignerPool, journal Journal) (*SimpleTxManager, error) {
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
@@ -588,9 +612,8 @@ func NewSimpleTxManager(conf Config, chainID *big.Int, nonce *uint64, ethereumCl
 
 		chainID:        chainID,
 		ethereumClient: ethereumClient,
-		from:           from,
-		nonce:          nonce,
 
-		signer: singer,
+		signerPool: signerPool,
+		journal:    journal,
 	}, nil
 }
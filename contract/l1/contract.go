@@ -1,8 +1,16 @@
 package l1
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 //go:generate go run --mod=mod github.com/ethereum/go-ethereum/cmd/abigen@v1.13.5 --abi ./abi/L1StandardBridge.abi --pkg l1 --type L1StandardBridge --out contract_l1_standard_bridge.go
@@ -41,6 +49,64 @@ var ContractMap = map[uint64]*struct {
 	},
 }
 
+// WithdrawalPeriod is the timing of the withdrawals from the VSL, set by the L2OutputOracle on L1.
+type WithdrawalPeriod struct {
+	// ProposalInterval is the submission interval of the output roots, a withdrawal can be proven once it is covered by an output root.
+	ProposalInterval time.Duration
+	// FinalizationPeriod is the challenge period of a proven withdrawal before it can be finalized.
+	FinalizationPeriod time.Duration
+}
+
+// NewWithdrawalPeriod reads the withdrawal period from the L2OutputOracle of the OptimismPortal of the chain.
+func NewWithdrawalPeriod(ctx context.Context, chainID uint64, ethereumClient *ethclient.Client) (*WithdrawalPeriod, error) {
+	contractAddresses, exists := ContractMap[chainID]
+	if !exists {
+		return nil, fmt.Errorf("contract address not found for chain id: %d", chainID)
+	}
+
+	callOptions := bind.CallOpts{
+		Context: ctx,
+	}
+
+	optimismPortal, err := bindings.NewOptimismPortalCaller(contractAddresses.AddressOptimismPortalProxy, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("new optimism portal: %w", err)
+	}
+
+	addressL2OutputOracle, err := optimismPortal.L2ORACLE(&callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("get l2 output oracle address: %w", err)
+	}
+
+	l2OutputOracle, err := bindings.NewL2OutputOracleCaller(addressL2OutputOracle, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("new l2 output oracle: %w", err)
+	}
+
+	finalizationPeriodSeconds, err := l2OutputOracle.FINALIZATIONPERIODSECONDS(&callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("get finalization period seconds: %w", err)
+	}
+
+	// The submission interval is in L2 blocks.
+	submissionInterval, err := l2OutputOracle.SUBMISSIONINTERVAL(&callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("get submission interval: %w", err)
+	}
+
+	l2BlockTime, err := l2OutputOracle.L2BLOCKTIME(&callOptions)
+	if err != nil {
+		return nil, fmt.Errorf("get l2 block time: %w", err)
+	}
+
+	withdrawalPeriod := WithdrawalPeriod{
+		ProposalInterval:   time.Duration(new(big.Int).Mul(submissionInterval, l2BlockTime).Int64()) * time.Second,
+		FinalizationPeriod: time.Duration(finalizationPeriodSeconds.Int64()) * time.Second,
+	}
+
+	return &withdrawalPeriod, nil
+}
+
 var (
 	EventHashL1CrossDomainMessengerSentMessage    = crypto.Keccak256Hash([]byte("SentMessage(address,address,bytes,uint256,uint256)"))
 	EventHashL1CrossDomainMessengerRelayedMessage = crypto.Keccak256Hash([]byte("RelayedMessage(bytes32)"))
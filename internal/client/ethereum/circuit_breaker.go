@@ -0,0 +1,78 @@
+package ethereum
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitBreakerState int
+
+const (
+	// circuitBreakerClosed when the endpoint serves requests.
+	circuitBreakerClosed circuitBreakerState = iota
+	// circuitBreakerHalfOpen when the cooldown has elapsed and the endpoint is being retried.
+	circuitBreakerHalfOpen
+	// circuitBreakerOpen when the endpoint failed too many times and is skipped until the cooldown elapses.
+	circuitBreakerOpen
+)
+
+// circuitBreaker tracks the consecutive failures of an endpoint.
+type circuitBreaker struct {
+	lock sync.Mutex
+
+	state    circuitBreakerState
+	failures int
+	openedAt time.Time
+
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+// allow reports whether a request can be sent to the endpoint.
+func (b *circuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == circuitBreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = circuitBreakerHalfOpen
+	}
+
+	return b.state != circuitBreakerOpen
+}
+
+func (b *circuitBreaker) success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.state = circuitBreakerClosed
+	b.failures = 0
+}
+
+func (b *circuitBreaker) failure() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures++
+
+	// A failed retry opens the circuit breaker again immediately.
+	if b.state == circuitBreakerHalfOpen || b.failures >= b.threshold {
+		b.state = circuitBreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *circuitBreaker) currentState() circuitBreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.state
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
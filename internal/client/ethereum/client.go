@@ -3,9 +3,12 @@ package ethereum
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/sourcegraph/conc/pool"
 )
 
@@ -34,28 +37,24 @@ func (m *MultiChainClient) Get(chainID uint64) (*ethclient.Client, error) {
 	return ethereumClient, nil
 }
 
-func Dial(ctx context.Context, endpoints []string) (*MultiChainClient, error) {
+// Dial dials the endpoints of each chain, the requests of a chain fail over between its endpoints in order.
+func Dial(ctx context.Context, chains [][]string, options Options) (*MultiChainClient, error) {
 	client := MultiChainClient{
 		chainMap: make(map[uint64]*ethclient.Client),
 	}
 
 	contextPool := pool.New().WithContext(ctx).WithFirstError().WithCancelOnError()
 
-	for _, endpoint := range endpoints {
-		endpoint := endpoint
+	for _, endpoints := range chains {
+		endpoints := endpoints
 
-		contextPool.Go(func(ctx context.Context) error {
-			ethereumClient, err := ethclient.DialContext(ctx, endpoint)
+		contextPool.Go(func(dialContext context.Context) error {
+			ethereumClient, chainID, err := dialChain(ctx, dialContext, endpoints, options)
 			if err != nil {
-				return fmt.Errorf("dial to endpoint: %w", err)
+				return err
 			}
 
-			chainID, err := ethereumClient.ChainID(ctx)
-			if err != nil {
-				return fmt.Errorf("get chain id: %w", err)
-			}
-
-			client.Put(chainID.Uint64(), ethereumClient)
+			client.Put(chainID, ethereumClient)
 
 			return nil
 		})
@@ -67,3 +66,48 @@ func Dial(ctx context.Context, endpoints []string) (*MultiChainClient, error) {
 
 	return &client, nil
 }
+
+// dialChain dials the endpoints of a chain, the health check of the endpoints runs until ctx is done.
+func dialChain(ctx, dialContext context.Context, endpoints []string, options Options) (*ethclient.Client, uint64, error) {
+	if len(endpoints) == 0 {
+		return nil, 0, fmt.Errorf("no endpoint")
+	}
+
+	// A single non-http endpoint, such as a websocket one, is dialed directly.
+	if len(endpoints) == 1 && !strings.HasPrefix(endpoints[0], "http") {
+		ethereumClient, err := ethclient.DialContext(dialContext, endpoints[0])
+		if err != nil {
+			return nil, 0, fmt.Errorf("dial to endpoint: %w", err)
+		}
+
+		chainID, err := ethereumClient.ChainID(dialContext)
+		if err != nil {
+			return nil, 0, fmt.Errorf("get chain id: %w", err)
+		}
+
+		return ethereumClient, chainID.Uint64(), nil
+	}
+
+	transport, err := newFailoverTransport(endpoints, options)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create failover transport: %w", err)
+	}
+
+	rpcClient, err := rpc.DialOptions(dialContext, endpoints[0], rpc.WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("dial to endpoints: %w", err)
+	}
+
+	ethereumClient := ethclient.NewClient(rpcClient)
+
+	chainID, err := ethereumClient.ChainID(dialContext)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get chain id: %w", err)
+	}
+
+	transport.chainID = chainID.String()
+
+	go transport.healthCheck(ctx)
+
+	return ethereumClient, chainID.Uint64(), nil
+}
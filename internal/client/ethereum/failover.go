@@ -0,0 +1,480 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+// MethodBlockNumber is the JSON-RPC method of the latest block number, its quorum is the highest block reached by enough endpoints.
+const MethodBlockNumber = "eth_blockNumber"
+
+var blockNumberRequest = []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+
+// Options configures the failover between the endpoints of a chain.
+type Options struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit breaker of an endpoint.
+	FailureThreshold int
+	// Cooldown is the duration an open circuit breaker skips an endpoint before it is retried.
+	Cooldown time.Duration
+	// HealthCheckInterval is the interval at which the latest block number of each endpoint is checked, 0 disables the health check.
+	HealthCheckInterval time.Duration
+	// Quorum is the number of endpoints that must agree on a critical read, the quorum mode is disabled if it is less than 2.
+	Quorum int
+	// QuorumMethods are the JSON-RPC methods of the critical reads.
+	QuorumMethods []string
+}
+
+type endpoint struct {
+	url *url.URL
+	// label identifies the endpoint in metrics and logs without exposing the API key in its path or query.
+	label   string
+	breaker *circuitBreaker
+}
+
+// failoverTransport sends each JSON-RPC request to the first healthy endpoint in order,
+// and to all healthy endpoints if the request is a critical read in the quorum mode.
+type failoverTransport struct {
+	endpoints     []*endpoint
+	transport     http.RoundTripper
+	options       Options
+	quorumMethods map[string]struct{}
+	// chainID labels the metrics once the chain ID is known.
+	chainID string
+}
+
+type jsonrpcMessage struct {
+	Method string          `json:"method,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+type quorumResponse struct {
+	endpoint *endpoint
+	response *http.Response
+	body     []byte
+	message  jsonrpcMessage
+}
+
+func (t *failoverTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if request.Body != nil {
+		var err error
+
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+
+		_ = request.Body.Close()
+	}
+
+	if method, ok := t.quorumMethod(body); ok {
+		return t.roundTripQuorum(request, body, method)
+	}
+
+	return t.roundTripFailover(request, body)
+}
+
+// quorumMethod returns the method of the request if it is a critical read in the quorum mode, batch requests are never.
+func (t *failoverTransport) quorumMethod(body []byte) (string, bool) {
+	if t.options.Quorum < 2 {
+		return "", false
+	}
+
+	var message jsonrpcMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return "", false
+	}
+
+	_, ok := t.quorumMethods[message.Method]
+
+	return message.Method, ok
+}
+
+func (t *failoverTransport) roundTripFailover(request *http.Request, body []byte) (*http.Response, error) {
+	var lastErr error
+
+	for _, endpoint := range t.availableEndpoints() {
+		response, err := t.send(request, endpoint, body)
+		if err != nil {
+			if request.Context().Err() != nil {
+				return nil, err
+			}
+
+			zap.L().Warn("rpc endpoint failed, failing over", zap.String("chainID", t.chainID), zap.String("endpoint", endpoint.label), zap.Error(err))
+
+			lastErr = err
+
+			continue
+		}
+
+		t.serve(endpoint)
+
+		return response, nil
+	}
+
+	return nil, fmt.Errorf("all endpoints failed: %w", lastErr)
+}
+
+func (t *failoverTransport) roundTripQuorum(request *http.Request, body []byte, method string) (*http.Response, error) {
+	endpoints := t.availableEndpoints()
+	responses := make([]*quorumResponse, len(endpoints))
+
+	sendPool := pool.New()
+
+	for index, endpoint := range endpoints {
+		index, endpoint := index, endpoint
+
+		sendPool.Go(func() {
+			response, err := t.send(request, endpoint, body)
+			if err != nil {
+				zap.L().Warn("rpc endpoint failed in quorum", zap.String("chainID", t.chainID), zap.String("endpoint", endpoint.label), zap.Error(err))
+
+				return
+			}
+
+			defer func() {
+				_ = response.Body.Close()
+			}()
+
+			responseBody, err := io.ReadAll(response.Body)
+			if err != nil {
+				return
+			}
+
+			result := quorumResponse{
+				endpoint: endpoint,
+				response: response,
+				body:     responseBody,
+			}
+
+			if err := json.Unmarshal(responseBody, &result.message); err != nil {
+				return
+			}
+
+			responses[index] = &result
+		})
+	}
+
+	sendPool.Wait()
+
+	result, err := selectQuorum(method, responses, t.options.Quorum)
+	if err != nil {
+		if t.chainID != "" {
+			quorumFailureCounter.WithLabelValues(t.chainID, method).Inc()
+		}
+
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	t.serve(result.endpoint)
+
+	return &http.Response{
+		Status:        result.response.Status,
+		StatusCode:    result.response.StatusCode,
+		Proto:         result.response.Proto,
+		ProtoMajor:    result.response.ProtoMajor,
+		ProtoMinor:    result.response.ProtoMinor,
+		Header:        result.response.Header,
+		Body:          io.NopCloser(bytes.NewReader(result.body)),
+		ContentLength: int64(len(result.body)),
+		Request:       request,
+	}, nil
+}
+
+// selectQuorum selects the response agreed by at least quorum endpoints.
+// The latest block numbers differ across endpoints, so the highest block number reached by at least quorum endpoints is selected.
+func selectQuorum(method string, responses []*quorumResponse, quorum int) (*quorumResponse, error) {
+	var received []*quorumResponse
+
+	for _, response := range responses {
+		if response != nil {
+			received = append(received, response)
+		}
+	}
+
+	if len(received) < quorum {
+		return nil, fmt.Errorf("%d responses received, quorum of %d not reached", len(received), quorum)
+	}
+
+	if method == MethodBlockNumber {
+		type blockNumberResponse struct {
+			blockNumber uint64
+			response    *quorumResponse
+		}
+
+		var blockNumbers []blockNumberResponse
+
+		for _, response := range received {
+			var blockNumber hexutil.Uint64
+			if response.message.Error != nil || json.Unmarshal(response.message.Result, &blockNumber) != nil {
+				continue
+			}
+
+			blockNumbers = append(blockNumbers, blockNumberResponse{uint64(blockNumber), response})
+		}
+
+		if len(blockNumbers) < quorum {
+			return nil, fmt.Errorf("%d block numbers received, quorum of %d not reached", len(blockNumbers), quorum)
+		}
+
+		sort.SliceStable(blockNumbers, func(i, j int) bool {
+			return blockNumbers[i].blockNumber > blockNumbers[j].blockNumber
+		})
+
+		return blockNumbers[quorum-1].response, nil
+	}
+
+	groups := make(map[string][]*quorumResponse)
+
+	for _, response := range received {
+		key := string(compactJSON(response.message.Result)) + "|" + string(compactJSON(response.message.Error))
+		groups[key] = append(groups[key], response)
+	}
+
+	var agreed []*quorumResponse
+
+	for _, group := range groups {
+		if len(group) > len(agreed) {
+			agreed = group
+		}
+	}
+
+	if len(agreed) < quorum {
+		return nil, fmt.Errorf("%d of %d responses agreed, quorum of %d not reached", len(agreed), len(received), quorum)
+	}
+
+	return agreed[0], nil
+}
+
+func compactJSON(message json.RawMessage) []byte {
+	var buffer bytes.Buffer
+
+	if err := json.Compact(&buffer, message); err != nil {
+		return message
+	}
+
+	return buffer.Bytes()
+}
+
+// availableEndpoints returns the endpoints whose circuit breaker is not open in order,
+// or all endpoints as the last resort if every circuit breaker is open.
+func (t *failoverTransport) availableEndpoints() []*endpoint {
+	endpoints := make([]*endpoint, 0, len(t.endpoints))
+
+	for _, endpoint := range t.endpoints {
+		if endpoint.breaker.allow() {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	if len(endpoints) == 0 {
+		return t.endpoints
+	}
+
+	return endpoints
+}
+
+// send sends the request to the endpoint, a transport error or a server error is a failure of the endpoint.
+func (t *failoverTransport) send(request *http.Request, endpoint *endpoint, body []byte) (*http.Response, error) {
+	endpointURL := *endpoint.url
+
+	outgoing := request.Clone(request.Context())
+	outgoing.URL = &endpointURL
+	outgoing.Host = endpointURL.Host
+	outgoing.Body = io.NopCloser(bytes.NewReader(body))
+	outgoing.ContentLength = int64(len(body))
+	outgoing.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	start := time.Now()
+	response, err := t.transport.RoundTrip(outgoing)
+
+	t.observe(endpoint, time.Since(start))
+
+	if err == nil && (response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests) {
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+
+		err = fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	if err != nil {
+		// A canceled request is not a failure of the endpoint.
+		if request.Context().Err() == nil {
+			endpoint.breaker.failure()
+		}
+
+		t.record(endpoint, false)
+
+		return nil, err
+	}
+
+	endpoint.breaker.success()
+	t.record(endpoint, true)
+
+	return response, nil
+}
+
+func (t *failoverTransport) observe(endpoint *endpoint, duration time.Duration) {
+	if t.chainID == "" {
+		return
+	}
+
+	requestDuration.WithLabelValues(t.chainID, endpoint.label).Observe(duration.Seconds())
+}
+
+func (t *failoverTransport) record(endpoint *endpoint, succeeded bool) {
+	if t.chainID == "" {
+		return
+	}
+
+	result := "success"
+	if !succeeded {
+		result = "failure"
+	}
+
+	requestCounter.WithLabelValues(t.chainID, endpoint.label, result).Inc()
+	circuitBreakerGauge.WithLabelValues(t.chainID, endpoint.label).Set(float64(endpoint.breaker.currentState()))
+}
+
+// serve marks the endpoint as the one serving the chain.
+func (t *failoverTransport) serve(serving *endpoint) {
+	if t.chainID == "" {
+		return
+	}
+
+	for _, endpoint := range t.endpoints {
+		value := 0.0
+		if endpoint == serving {
+			value = 1
+		}
+
+		servingGauge.WithLabelValues(t.chainID, endpoint.label).Set(value)
+	}
+}
+
+// healthCheck checks the latest block number of each endpoint periodically,
+// which also closes the circuit breaker of an endpoint once it recovers.
+func (t *failoverTransport) healthCheck(ctx context.Context) {
+	if t.options.HealthCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.options.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, endpoint := range t.endpoints {
+				if err := t.checkEndpoint(ctx, endpoint); err != nil {
+					zap.L().Warn("rpc endpoint health check failed", zap.String("chainID", t.chainID), zap.String("endpoint", endpoint.label), zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+func (t *failoverTransport) checkEndpoint(ctx context.Context, endpoint *endpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, t.options.HealthCheckInterval)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.url.String(), nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := t.send(request, endpoint, blockNumberRequest)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	var message jsonrpcMessage
+	if err := json.NewDecoder(response.Body).Decode(&message); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	if message.Error != nil {
+		return errors.New(string(message.Error))
+	}
+
+	var blockNumber hexutil.Uint64
+	if err := json.Unmarshal(message.Result, &blockNumber); err != nil {
+		return fmt.Errorf("decode block number: %w", err)
+	}
+
+	if t.chainID != "" {
+		blockNumberGauge.WithLabelValues(t.chainID, endpoint.label).Set(float64(blockNumber))
+	}
+
+	return nil
+}
+
+func newFailoverTransport(endpointURLs []string, options Options) (*failoverTransport, error) {
+	transport := failoverTransport{
+		transport:     http.DefaultTransport,
+		options:       options,
+		quorumMethods: make(map[string]struct{}, len(options.QuorumMethods)),
+	}
+
+	for _, method := range options.QuorumMethods {
+		transport.quorumMethods[method] = struct{}{}
+	}
+
+	labels := make(map[string]int)
+
+	for _, endpointURL := range endpointURLs {
+		parsedURL, err := url.Parse(endpointURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse endpoint: %w", err)
+		}
+
+		if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+			return nil, fmt.Errorf("failover is only supported by http endpoints: %s", parsedURL.Scheme)
+		}
+
+		// Endpoints of the same host are told apart by their position.
+		label := parsedURL.Host
+		if labels[parsedURL.Host]++; labels[parsedURL.Host] > 1 {
+			label += "#" + strconv.Itoa(labels[parsedURL.Host])
+		}
+
+		transport.endpoints = append(transport.endpoints, &endpoint{
+			url:     parsedURL,
+			label:   label,
+			breaker: newCircuitBreaker(options.FailureThreshold, options.Cooldown),
+		})
+	}
+
+	// The quorum mode is disabled for a chain without enough endpoints.
+	if len(transport.endpoints) < options.Quorum {
+		zap.L().Warn("not enough rpc endpoints for the quorum, quorum mode disabled", zap.Int("endpoints", len(transport.endpoints)), zap.Int("quorum", options.Quorum))
+
+		transport.options.Quorum = 0
+	}
+
+	return &transport, nil
+}
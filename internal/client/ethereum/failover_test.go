@@ -0,0 +1,184 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRPCServer returns a server responding the results by method, or a server error if it is down.
+func newRPCServer(t *testing.T, results map[string]string, down *atomic.Bool) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+
+		if down != nil && down.Load() {
+			writer.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+
+		if err := json.NewDecoder(request.Body).Decode(&message); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(writer, `{"jsonrpc":"2.0","id":%s,"result":%q}`, message.ID, results[message.Method])
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestFailover(t *testing.T) {
+	t.Parallel()
+
+	var down atomic.Bool
+
+	down.Store(true)
+
+	primary, primaryRequests := newRPCServer(t, map[string]string{"eth_chainId": "0x1", "eth_blockNumber": "0xa"}, &down)
+	secondary, _ := newRPCServer(t, map[string]string{"eth_chainId": "0x1", "eth_blockNumber": "0xb"}, nil)
+
+	ethereumClient, chainID, err := dialChain(context.Background(), context.Background(), []string{primary.URL, secondary.URL}, Options{
+		FailureThreshold: 2,
+		Cooldown:         time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), chainID)
+
+	// The requests fail over to the secondary endpoint.
+	blockNumber, err := ethereumClient.BlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0xb), blockNumber)
+
+	// The primary endpoint is skipped once its circuit breaker opens.
+	requests := primaryRequests.Load()
+
+	blockNumber, err = ethereumClient.BlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0xb), blockNumber)
+	assert.Equal(t, requests, primaryRequests.Load())
+}
+
+func TestQuorum(t *testing.T) {
+	t.Parallel()
+
+	options := Options{
+		FailureThreshold: 1,
+		Cooldown:         time.Hour,
+		Quorum:           2,
+		QuorumMethods:    []string{MethodBlockNumber, "eth_call"},
+	}
+
+	newEndpoints := func(t *testing.T, results ...map[string]string) []string {
+		endpoints := make([]string, 0, len(results))
+
+		for _, result := range results {
+			result["eth_chainId"] = "0x1"
+
+			server, _ := newRPCServer(t, result, nil)
+			endpoints = append(endpoints, server.URL)
+		}
+
+		return endpoints
+	}
+
+	t.Run("block number", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := newEndpoints(t,
+			map[string]string{"eth_blockNumber": "0xa"},
+			map[string]string{"eth_blockNumber": "0xc"},
+			map[string]string{"eth_blockNumber": "0xb"},
+		)
+
+		ethereumClient, _, err := dialChain(context.Background(), context.Background(), endpoints, options)
+		require.NoError(t, err)
+
+		// The highest block reached by at least 2 endpoints.
+		blockNumber, err := ethereumClient.BlockNumber(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0xb), blockNumber)
+	})
+
+	t.Run("call", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := newEndpoints(t,
+			map[string]string{"eth_call": "0x01"},
+			map[string]string{"eth_call": "0x02"},
+			map[string]string{"eth_call": "0x01"},
+		)
+
+		ethereumClient, _, err := dialChain(context.Background(), context.Background(), endpoints, options)
+		require.NoError(t, err)
+
+		result, err := ethereumClient.CallContract(context.Background(), ethereum.CallMsg{To: &common.Address{}}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, result)
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		t.Parallel()
+
+		endpoints := newEndpoints(t,
+			map[string]string{"eth_call": "0x01"},
+			map[string]string{"eth_call": "0x02"},
+		)
+
+		ethereumClient, _, err := dialChain(context.Background(), context.Background(), endpoints, options)
+		require.NoError(t, err)
+
+		_, err = ethereumClient.CallContract(context.Background(), ethereum.CallMsg{To: &common.Address{}}, nil)
+		assert.ErrorContains(t, err, "quorum")
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.failure()
+	assert.True(t, breaker.allow())
+
+	breaker.failure()
+	assert.False(t, breaker.allow())
+
+	// The endpoint is retried after the cooldown, and a failed retry opens the circuit breaker again.
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	assert.Equal(t, circuitBreakerHalfOpen, breaker.currentState())
+
+	breaker.failure()
+	assert.False(t, breaker.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+
+	breaker.success()
+	assert.Equal(t, circuitBreakerClosed, breaker.currentState())
+}
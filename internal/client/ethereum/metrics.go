@@ -0,0 +1,52 @@
+package ethereum
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requestCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ethereum_rpc_requests_total",
+			Help: "Total number of requests sent to each RPC endpoint",
+		},
+		[]string{"chain_id", "endpoint", "result"},
+	)
+	requestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ethereum_rpc_request_duration_seconds",
+			Help:    "Duration of the requests sent to each RPC endpoint",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"chain_id", "endpoint"},
+	)
+	servingGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ethereum_rpc_serving",
+			Help: "Whether the RPC endpoint served the latest request of the chain",
+		},
+		[]string{"chain_id", "endpoint"},
+	)
+	circuitBreakerGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ethereum_rpc_circuit_breaker_state",
+			Help: "State of the circuit breaker of each RPC endpoint, 0 is closed, 1 is half-open and 2 is open",
+		},
+		[]string{"chain_id", "endpoint"},
+	)
+	blockNumberGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ethereum_rpc_block_number",
+			Help: "Latest block number of each RPC endpoint observed by the health check",
+		},
+		[]string{"chain_id", "endpoint"},
+	)
+	quorumFailureCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ethereum_rpc_quorum_failures_total",
+			Help: "Total number of critical reads on which the RPC endpoints did not reach a quorum",
+		},
+		[]string{"chain_id", "method"},
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"time"
 	"unsafe"
 
 	"github.com/creasty/defaults"
@@ -26,6 +27,7 @@ type File struct {
 	Redis         *Redis         `yaml:"redis"`
 	RSS3Chain     *RSS3Chain     `yaml:"rss3_chain"`
 	Settler       *Settler       `yaml:"settler"`
+	Signers       []*Signer      `yaml:"signers"`
 	Distributor   *Distributor   `yaml:"distributor"`
 	Rewards       *Rewards       `yaml:"rewards"`
 	ActiveScores  *ActiveScores  `yaml:"active_scores"`
@@ -45,10 +47,57 @@ type Redis struct {
 }
 
 type RSS3Chain struct {
-	EndpointL1     string `yaml:"endpoint_l1" validate:"required"`
-	EndpointL2     string `yaml:"endpoint_l2" validate:"required"`
-	BlockThreadsL1 uint64 `yaml:"block_threads_l1" default:"1"`
-	BlockThreadsL2 uint64 `yaml:"block_threads_l2" default:"1"`
+	// EndpointL1 and EndpointL2 are the endpoints of each chain, the requests fail over between them in order.
+	EndpointL1     Endpoints `yaml:"endpoint_l1" validate:"required,min=1,dive,required"`
+	EndpointL2     Endpoints `yaml:"endpoint_l2" validate:"required,min=1,dive,required"`
+	BlockThreadsL1 uint64    `yaml:"block_threads_l1" default:"1"`
+	BlockThreadsL2 uint64    `yaml:"block_threads_l2" default:"1"`
+	// SourceL1 and SourceL2 are the sources of the indexer of each chain,
+	// `block` fetches every block with all its receipts, and `logs` fetches only the ones containing the logs of the contracts.
+	SourceL1 string `yaml:"source_l1" validate:"oneof=block logs" default:"block"`
+	SourceL2 string `yaml:"source_l2" validate:"oneof=block logs" default:"block"`
+	// LogsRangeL1 and LogsRangeL2 are the max number of blocks of an eth_getLogs request of the `logs` source.
+	LogsRangeL1 uint64 `yaml:"logs_range_l1" validate:"min=1" default:"1000"`
+	LogsRangeL2 uint64 `yaml:"logs_range_l2" validate:"min=1" default:"1000"`
+	// Failover configures the failover between the endpoints of each chain.
+	Failover *RSS3ChainFailover `yaml:"failover" default:"{}"`
+}
+
+// Endpoints is a list of endpoints, which can also be a single endpoint in the config file.
+type Endpoints []string
+
+func (e *Endpoints) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		if value.Tag == "!!null" {
+			*e = nil
+		} else {
+			*e = Endpoints{value.Value}
+		}
+
+		return nil
+	}
+
+	var endpoints []string
+	if err := value.Decode(&endpoints); err != nil {
+		return err
+	}
+
+	*e = endpoints
+
+	return nil
+}
+
+type RSS3ChainFailover struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit breaker of an endpoint.
+	FailureThreshold int `yaml:"failure_threshold" validate:"min=1" default:"3"`
+	// Cooldown is the duration an open circuit breaker skips an endpoint before it is retried.
+	Cooldown time.Duration `yaml:"cooldown" default:"30s"`
+	// HealthCheckInterval is the interval at which the latest block number of each endpoint is checked.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" default:"15s"`
+	// Quorum is the number of endpoints that must agree on a critical read, the quorum mode is disabled if it is less than 2.
+	Quorum int `yaml:"quorum" validate:"min=0"`
+	// QuorumMethods are the JSON-RPC methods of the critical reads, such as the contract calls of GetNode and the latest block numbers.
+	QuorumMethods []string `yaml:"quorum_methods" default:"[\"eth_blockNumber\",\"eth_call\"]"`
 }
 
 type Settler struct {
@@ -65,6 +114,15 @@ type Settler struct {
 	GracePeriodEpochs    int `yaml:"grace_period_epochs" default:"28"`
 }
 
+// Signer is an account sending transactions to the VSL, which is signed by the private key or the remote signer.
+type Signer struct {
+	PrivateKey     string `yaml:"private_key"`
+	WalletAddress  string `yaml:"wallet_address"`
+	SignerEndpoint string `yaml:"signer_endpoint"`
+	// Services are the services assigned to the account, such as settler, enforcer and taxer.
+	Services []string `yaml:"services"`
+}
+
 type Distributor struct {
 	// The number of demotions that triggers a slashing.
 	MaxDemotionCount int `yaml:"max_demotion_count" default:"4"`
@@ -73,6 +131,43 @@ type Distributor struct {
 	// The number of verification activities selected during the second verification.
 	VerificationCount int `yaml:"verification_count" default:"3"`
 	ToleranceSeconds  int `yaml:"tolerance_seconds" default:"1200"`
+	// BatchShardSize is the number of accounts per shard when a batch request is split across Nodes, 0 disables sharding.
+	BatchShardSize int `yaml:"batch_shard_size" validate:"min=0" default:"5"`
+	// StreamSubscriptionLimit is the maximum number of concurrent activity streams of a client.
+	StreamSubscriptionLimit int `yaml:"stream_subscription_limit" validate:"min=1" default:"3"`
+	// Cache caches Node responses for identical requests, disabled when omitted.
+	Cache *DistributorCache `yaml:"cache"`
+	// Routing selects how requests are sent to the qualified Nodes, broadcasting to all of them when omitted.
+	Routing *DistributorRouting `yaml:"routing"`
+}
+
+type DistributorCache struct {
+	Enable bool `yaml:"enable"`
+	// TTL is the duration a cached response is served without contacting the Nodes.
+	TTL time.Duration `yaml:"ttl" default:"30s"`
+	// StaleTTL is the duration after TTL during which a stale response is served while it is refreshed in the background.
+	StaleTTL time.Duration `yaml:"stale_ttl" default:"1m"`
+	// Routes overrides TTL per request type (activity, activities, batch_activities, network_activities, platform_activities),
+	// a zero duration disables caching for the route.
+	Routes map[string]time.Duration `yaml:"routes"`
+}
+
+type DistributorRouting struct {
+	// Strategy is the routing strategy of routes without an override.
+	Strategy *RoutingStrategy `yaml:"strategy"`
+	// Routes overrides Strategy per request type (activity, activities, batch_activities, network_activities, platform_activities, rsshub).
+	Routes map[string]*RoutingStrategy `yaml:"routes" validate:"dive"`
+}
+
+type RoutingStrategy struct {
+	// Name is one of broadcast, hedged or weighted.
+	Name string `yaml:"name" validate:"oneof=broadcast hedged weighted" default:"broadcast"`
+	// Fanout is the number of Nodes requested immediately by the hedged and weighted strategies.
+	Fanout int `yaml:"fanout" default:"1"`
+	// HedgePercentile is the latency percentile of previous responses after which the hedged strategy requests the remaining Nodes.
+	HedgePercentile float64 `yaml:"hedge_percentile" validate:"gt=0,lte=100" default:"95"`
+	// HedgeDelay is used instead of the latency percentile until enough responses have been observed.
+	HedgeDelay time.Duration `yaml:"hedge_delay" default:"500ms"`
 }
 
 type Rewards struct {
@@ -114,6 +209,11 @@ type GeoIP struct {
 	Account    int    `yaml:"account"`
 	LicenseKey string `yaml:"license_key"`
 	File       string `yaml:"file" validate:"required" default:"./common/geolite2/mmdb/GeoLite2-City.mmdb"`
+	// ASNFile is the GeoLite2-ASN database to look up the autonomous systems,
+	// it defaults to the database downloaded with the File into the same directory.
+	ASNFile string `yaml:"asn_file"`
+	// FallbackFiles are the databases in the format of GeoIP2 City consulted in order when the File has no record.
+	FallbackFiles []string `yaml:"fallback_files"`
 }
 
 type RPC struct {
@@ -137,9 +237,25 @@ type Telemetry struct {
 	Insecure bool   `yaml:"insecure"`
 }
 
+// TokenPriceAPI configures the prices of the tokens in USD, the Endpoint is a GeckoTerminal provider.
+// The median of the prices of all providers is used if there are several.
 type TokenPriceAPI struct {
-	Endpoint  string `yaml:"endpoint" validate:"required"`
+	Endpoint  string `yaml:"endpoint" validate:"required_without=Providers"`
 	AuthToken string `yaml:"auth_token"`
+	// Networks maps the chain IDs to the GeckoTerminal networks, overriding the mainnets of Ethereum and the VSL.
+	Networks  map[uint64]string `yaml:"networks"`
+	Providers []*PriceProvider  `yaml:"providers" validate:"dive"`
+	// MinQuotes is the minimum number of providers pricing a token, a token priced by fewer providers is treated as unpriced.
+	MinQuotes int `yaml:"min_quotes" default:"1" validate:"min=1"`
+}
+
+type PriceProvider struct {
+	Type      string            `yaml:"type" validate:"required,oneof=geckoterminal static"`
+	Endpoint  string            `yaml:"endpoint" validate:"required_if=Type geckoterminal"`
+	AuthToken string            `yaml:"auth_token"`
+	Networks  map[uint64]string `yaml:"networks"`
+	// Prices are the fixed prices in USD of the static provider by token address, for tests and air-gapped environments.
+	Prices map[string]string `yaml:"prices" validate:"required_if=Type static"`
 }
 
 func Setup(configFilePath string) (*File, error) {
@@ -11,6 +11,22 @@ const (
 
 	KeyChainIDL1 = "chain-id.l1"
 	KeyChainIDL2 = "chain-id.l2"
+
+	KeyDryRun = "dry-run"
+	KeyEpoch  = "epoch"
+	KeyOutput = "output"
+	KeyFormat = "format"
+
+	KeyBackfill  = "backfill"
+	KeyChain     = "chain"
+	KeyFrom      = "from"
+	KeyTo        = "to"
+	KeyRangeSize = "range-size"
+
+	KeyAction = "action"
+	KeyBlock  = "block"
+	KeyBlocks = "blocks"
+	KeyYes    = "yes"
 )
 
 const (
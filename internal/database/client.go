@@ -22,11 +22,19 @@ type Client interface {
 	Session
 	Transaction
 
+	FindBlock(ctx context.Context, chainID, blockNumber uint64) (*schema.Block, error)
+	FindLatestBlock(ctx context.Context, chainID uint64) (*schema.Block, error)
+	SaveBlock(ctx context.Context, block *schema.Block) error
+	DeleteBlocksBeforeBlockNumber(ctx context.Context, chainID, blockNumber uint64) error
 	RollbackBlock(ctx context.Context, chainID, blockNumber uint64) error
+	RewindBlocks(ctx context.Context, chainID, blockNumber uint64) error
 
 	FindCheckpoint(ctx context.Context, chainID uint64) (*schema.Checkpoint, error)
 	SaveCheckpoint(ctx context.Context, checkpoint *schema.Checkpoint) error
 
+	SaveTxJournal(ctx context.Context, journal *schema.TxJournal) error
+	FindTxJournals(ctx context.Context, query schema.TxJournalsQuery) ([]*schema.TxJournal, error)
+
 	FindNode(ctx context.Context, nodeAddress common.Address) (*schema.Node, error)
 	FindNodes(ctx context.Context, query schema.FindNodesQuery) ([]*schema.Node, error)
 	FindNodeAvatar(ctx context.Context, nodeAddress common.Address) (*l2.ChipsTokenMetadata, error)
@@ -50,12 +58,23 @@ type Client interface {
 	SaveNodeWorkers(ctx context.Context, workers []*schema.Worker) error
 	UpdateNodeWorkerActive(ctx context.Context) error
 	SaveNodeInvalidResponses(ctx context.Context, nodeInvalidResponses []*schema.NodeInvalidResponse) error
+	FindNodeInvalidResponse(ctx context.Context, id uint64) (*schema.NodeInvalidResponse, error)
+	FindNodeInvalidResponses(ctx context.Context, query schema.NodeInvalidResponsesQuery) ([]*schema.NodeInvalidResponse, error)
+	SaveNodeInvalidResponseAppeal(ctx context.Context, id uint64, reason string) error
+	UpdateNodeInvalidResponseAppealStatus(ctx context.Context, id uint64, status schema.NodeInvalidResponseAppealStatus) error
+	SaveNodeWebhook(ctx context.Context, webhook *schema.NodeWebhook) error
+	FindNodeWebhooks(ctx context.Context, query schema.NodeWebhookQuery) ([]*schema.NodeWebhook, error)
+	DeleteNodeWebhook(ctx context.Context, id uint64) error
+	SaveNodeWebhookDeliveries(ctx context.Context, deliveries []*schema.NodeWebhookDelivery) error
+	UpdateNodeWebhookDelivery(ctx context.Context, delivery *schema.NodeWebhookDelivery) error
+	FindNodeWebhookDeliveries(ctx context.Context, query schema.NodeWebhookDeliveryQuery) ([]*schema.NodeWebhookDelivery, error)
 
 	FindNodeCountSnapshots(ctx context.Context) ([]*schema.NodeSnapshot, error)
 	SaveNodeCountSnapshot(ctx context.Context, nodeSnapshot *schema.NodeSnapshot) error
 	FindStakerCountSnapshots(ctx context.Context) ([]*schema.StakerCountSnapshot, error)
 	SaveStakerCountSnapshot(ctx context.Context, stakeSnapshot *schema.StakerCountSnapshot) error
 	FindStakerProfitSnapshots(ctx context.Context, query schema.StakerProfitSnapshotsQuery) ([]*schema.StakerProfitSnapshot, error)
+	IterateStakerProfitSnapshots(ctx context.Context, query schema.ExportQuery, iterator func(stakerProfitSnapshot *schema.StakerProfitSnapshot) error) error
 	SaveStakerProfitSnapshots(ctx context.Context, stakerProfitSnapshots []*schema.StakerProfitSnapshot) error
 	FindOperatorProfitSnapshots(ctx context.Context, query schema.OperatorProfitSnapshotsQuery) ([]*schema.OperatorProfitSnapshot, error)
 	SaveOperatorProfitSnapshots(ctx context.Context, operatorProfitSnapshots []*schema.OperatorProfitSnapshot) error
@@ -63,9 +82,14 @@ type Client interface {
 	FindEpochAPYSnapshots(ctx context.Context, query schema.EpochAPYSnapshotQuery) ([]*schema.EpochAPYSnapshot, error)
 	SaveEpochAPYSnapshot(ctx context.Context, epochAPYSnapshots *schema.EpochAPYSnapshot) error
 	FindEpochAPYSnapshotsAverage(ctx context.Context) (decimal.Decimal, error)
+	FindDecentralizationSnapshots(ctx context.Context, query schema.DecentralizationSnapshotQuery) ([]*schema.DecentralizationSnapshot, error)
+	SaveDecentralizationSnapshot(ctx context.Context, snapshot *schema.DecentralizationSnapshot) error
+	FindTVLSnapshots(ctx context.Context, query schema.TVLSnapshotQuery) ([]*schema.TVLSnapshot, error)
+	SaveTVLSnapshot(ctx context.Context, snapshot *schema.TVLSnapshot) error
 
 	FindBridgeTransaction(ctx context.Context, query schema.BridgeTransactionQuery) (*schema.BridgeTransaction, error)
 	FindBridgeTransactions(ctx context.Context, query schema.BridgeTransactionsQuery) ([]*schema.BridgeTransaction, error)
+	IterateBridgeTransactions(ctx context.Context, query schema.ExportQuery, iterator func(bridgeTransaction *schema.BridgeTransaction) error) error
 	FindBridgeEvents(ctx context.Context, query schema.BridgeEventsQuery) ([]*schema.BridgeEvent, error)
 	UpdateBridgeTransactionsFinalizedByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error
 	UpdateBridgeEventsFinalizedByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error
@@ -76,6 +100,7 @@ type Client interface {
 
 	FindStakeTransaction(ctx context.Context, query schema.StakeTransactionQuery) (*schema.StakeTransaction, error)
 	FindStakeTransactions(ctx context.Context, query schema.StakeTransactionsQuery) ([]*schema.StakeTransaction, error)
+	IterateStakeTransactions(ctx context.Context, query schema.ExportQuery, iterator func(stakeTransaction *schema.StakeTransaction) error) error
 	FindStakeEvents(ctx context.Context, query schema.StakeEventsQuery) ([]*schema.StakeEvent, error)
 	FindStakeChip(ctx context.Context, query schema.StakeChipQuery) (*schema.StakeChip, error)
 	FindStakeChips(ctx context.Context, query schema.StakeChipsQuery) ([]*schema.StakeChip, error)
@@ -98,6 +123,7 @@ type Client interface {
 	FindEpochTransactions(ctx context.Context, id uint64, itemsLimit int, cursor *string) ([]*schema.Epoch, error)
 	FindEpochTransaction(ctx context.Context, transactionHash common.Hash, itemsLimit int, cursor *string) (*schema.Epoch, error)
 	FindEpochNodeRewards(ctx context.Context, nodeAddress common.Address, limit int, cursor *string) ([]*schema.Epoch, error)
+	IterateEpochNodeRewards(ctx context.Context, query schema.ExportQuery, iterator func(epoch *schema.Epoch) error) error
 	UpdateEpochsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error
 	DeleteEpochsByBlockNumber(ctx context.Context, blockNumber uint64) error
 
@@ -105,6 +131,10 @@ type Client interface {
 	FindLatestEpochTrigger(ctx context.Context) (*schema.EpochTrigger, error)
 	FindEpochTriggers(ctx context.Context, epochID uint64) ([]*schema.EpochTrigger, error)
 
+	SaveNodeScoreBreakdowns(ctx context.Context, breakdowns []*schema.NodeScoreBreakdown) error
+	FindNodeScoreBreakdown(ctx context.Context, epochID uint64, nodeAddress common.Address) (*schema.NodeScoreBreakdown, error)
+	FindNodeScoreBreakdowns(ctx context.Context, epochID uint64) ([]*schema.NodeScoreBreakdown, error)
+
 	FindAverageTaxSubmissions(ctx context.Context, query schema.AverageTaxRateSubmissionQuery) ([]*schema.AverageTaxRateSubmission, error)
 	SaveAverageTaxSubmission(ctx context.Context, averageTaxSubmission *schema.AverageTaxRateSubmission) error
 }
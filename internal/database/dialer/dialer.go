@@ -6,6 +6,7 @@ import (
 
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/mysql"
 	"github.com/rss3-network/global-indexer/internal/database/dialer/postgres"
 )
 
@@ -13,6 +14,8 @@ func Dial(ctx context.Context, config *config.Database) (database.Client, error)
 	switch config.Driver {
 	case database.DriverPostgres:
 		return postgres.Dial(ctx, config.URI)
+	case database.DriverMySQL:
+		return mysql.Dial(ctx, config.URI)
 	default:
 		return nil, fmt.Errorf("unsupported driver: %s", config.Driver)
 	}
@@ -0,0 +1,314 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/mysql/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (c *client) FindBridgeTransaction(ctx context.Context, query schema.BridgeTransactionQuery) (*schema.BridgeTransaction, error) {
+	var row *table.BridgeTransaction
+
+	databaseClient := c.database.WithContext(ctx)
+
+	if query.ID != nil {
+		databaseClient = databaseClient.Where(`id = ?`, query.ID.String())
+	}
+
+	if query.Sender != nil {
+		databaseClient = databaseClient.Where(`sender = ?`, query.Sender.String())
+	}
+
+	if query.Receiver != nil {
+		databaseClient = databaseClient.Where(`receiver = ?`, query.Receiver.String())
+	}
+
+	if query.Address != nil {
+		databaseClient = databaseClient.Where(`sender = ? or receiver = ?`, query.Address.String(), query.Address.String())
+	}
+
+	if query.Type != nil {
+		databaseClient = databaseClient.Where(`type = ?`, *query.Type)
+	}
+
+	if err := databaseClient.Order(`block_timestamp DESC, block_number DESC, transaction_index DESC`).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	result, err := row.Export()
+	if err != nil {
+		return nil, fmt.Errorf("export row: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *client) FindBridgeTransactions(ctx context.Context, query schema.BridgeTransactionsQuery) ([]*schema.BridgeTransaction, error) {
+	var rows []table.BridgeTransaction
+
+	databaseClient := c.database.WithContext(ctx)
+
+	const limit = 100
+
+	if query.Cursor != nil {
+		var cursor table.BridgeTransaction
+		if err := databaseClient.Where(`id = ?`, query.Cursor.String()).First(&cursor).Error; err != nil {
+			return nil, fmt.Errorf("query cursor: %w", err)
+		}
+
+		// TODO Need a better cursor implementation.
+		databaseClient = databaseClient.Where(
+			`
+(block_timestamp < ?) OR
+(block_timestamp = ? AND chain_id = ? AND block_number < ?) OR
+(block_timestamp = ? AND chain_id = ? AND block_number = ? AND transaction_index < ?)
+`,
+			cursor.BlockTimestamp,
+			cursor.BlockTimestamp, cursor.ChainID, cursor.BlockNumber,
+			cursor.BlockTimestamp, cursor.ChainID, cursor.BlockNumber, cursor.TransactionIndex,
+		)
+	}
+
+	if query.ID != nil {
+		databaseClient = databaseClient.Where(`id = ?`, query.ID.String())
+	}
+
+	if query.Sender != nil {
+		databaseClient = databaseClient.Where(`sender = ?`, query.Sender.String())
+	}
+
+	if query.Receiver != nil {
+		databaseClient = databaseClient.Where(`receiver = ?`, query.Receiver.String())
+	}
+
+	if query.Address != nil {
+		databaseClient = databaseClient.Where(`sender = ? or receiver = ?`, query.Address.String(), query.Address.String())
+	}
+
+	if query.Type != nil {
+		databaseClient = databaseClient.Where(`type = ?`, *query.Type)
+	}
+
+	if query.WithdrawalStatus != nil {
+		databaseClient = whereBridgeWithdrawalStatus(databaseClient, query)
+	}
+
+	if err := databaseClient.Order(`block_timestamp DESC, block_number DESC, transaction_index DESC`).Limit(limit).Find(&rows).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	results := make([]*schema.BridgeTransaction, 0, len(rows))
+
+	for _, row := range rows {
+		result, err := row.Export()
+		if err != nil {
+			return nil, fmt.Errorf("export row: %w", err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (c *client) IterateBridgeTransactions(ctx context.Context, query schema.ExportQuery, iterator func(bridgeTransaction *schema.BridgeTransaction) error) error {
+	databaseClient := c.database.WithContext(ctx).Model(&table.BridgeTransaction{})
+
+	if query.Address != nil {
+		databaseClient = databaseClient.Where(`(sender = ? OR receiver = ?)`, query.Address.String(), query.Address.String())
+	}
+
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`block_timestamp >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`block_timestamp <= ?`, query.Until)
+	}
+
+	rows, err := databaseClient.Order(`block_timestamp, chain_id, block_number, transaction_index`).Rows()
+	if err != nil {
+		return fmt.Errorf("iterate bridge transactions: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row table.BridgeTransaction
+
+		if err := c.database.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("scan bridge transaction: %w", err)
+		}
+
+		bridgeTransaction, err := row.Export()
+		if err != nil {
+			return fmt.Errorf("export bridge transaction: %w", err)
+		}
+
+		if err := iterator(bridgeTransaction); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (c *client) FindBridgeEvents(ctx context.Context, query schema.BridgeEventsQuery) ([]*schema.BridgeEvent, error) {
+	var rows []*table.BridgeEvent
+
+	databaseClient := c.database.WithContext(ctx)
+
+	if len(query.IDs) > 0 {
+		databaseClient = databaseClient.Where(`id IN ?`, lo.Map(query.IDs, func(id common.Hash, _ int) string {
+			return id.String()
+		}))
+	}
+
+	if err := databaseClient.Order(`block_timestamp DESC, block_number DESC, transaction_index DESC`).Find(&rows).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, fmt.Errorf("find bridge event: %w", err)
+	}
+
+	results := make([]*schema.BridgeEvent, 0, len(rows))
+
+	for _, row := range rows {
+		result, err := row.Export()
+		if err != nil {
+			return nil, fmt.Errorf("export row: %w", err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (c *client) SaveBridgeTransaction(ctx context.Context, bridgeTransaction *schema.BridgeTransaction) error {
+	var value table.BridgeTransaction
+	if err := value.Import(*bridgeTransaction); err != nil {
+		return fmt.Errorf("import bridge transaction: %w", err)
+	}
+
+	clauses := []clause.Expression{
+		clause.OnConflict{
+			UpdateAll: true,
+			Columns: []clause.Column{
+				{
+					Name: "id",
+				},
+				{
+					Name: "type",
+				},
+			},
+		},
+	}
+
+	return c.database.WithContext(ctx).Clauses(clauses...).Create(&value).Error
+}
+
+func (c *client) SaveBridgeEvent(ctx context.Context, bridgeEvent *schema.BridgeEvent) error {
+	var value table.BridgeEvent
+	if err := value.Import(*bridgeEvent); err != nil {
+		return fmt.Errorf("import bridge event: %w", err)
+	}
+
+	clauses := []clause.Expression{
+		clause.OnConflict{
+			UpdateAll: true,
+			Columns: []clause.Column{
+				{
+					Name: "transaction_hash",
+				},
+				{
+					Name: "block_hash",
+				},
+			},
+		},
+	}
+
+	return c.database.WithContext(ctx).Clauses(clauses...).Create(&value).Error
+}
+
+func (c *client) DeleteBridgeTransactionsByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error {
+	return c.database.
+		WithContext(ctx).
+		Delete(new(table.BridgeTransaction), `chain_id = ? AND block_number = ? AND NOT finalized`, chainID, blockNumber).
+		Error
+}
+
+func (c *client) DeleteBridgeEventsByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error {
+	return c.database.
+		WithContext(ctx).
+		Delete(new(table.BridgeEvent), `chain_id = ? AND block_number = ? AND NOT finalized`, chainID, blockNumber).
+		Error
+}
+
+func (c *client) UpdateBridgeTransactionsFinalizedByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error {
+	return c.database.
+		WithContext(ctx).
+		Table((*table.BridgeTransaction).TableName(nil)).
+		Where(`chain_id = ? AND block_number < ? AND NOT finalized`, chainID, blockNumber).
+		Update("finalized", true).
+		Error
+}
+
+func (c *client) UpdateBridgeEventsFinalizedByBlockNumber(ctx context.Context, chainID, blockNumber uint64) error {
+	return c.database.
+		WithContext(ctx).
+		Table((*table.BridgeEvent).TableName(nil)).
+		Where(`chain_id = ? AND block_number < ? AND NOT finalized`, chainID, blockNumber).
+		Update("finalized", true).
+		Error
+}
+
+// whereBridgeWithdrawalStatus filters the withdrawals by the status computed from their events.
+func whereBridgeWithdrawalStatus(databaseClient *gorm.DB, query schema.BridgeTransactionsQuery) *gorm.DB {
+	const (
+		eventExists            = `EXISTS (SELECT 1 FROM bridge_events WHERE bridge_events.id = bridge_transactions.id AND bridge_events.type = ?)`
+		eventExistsUnfinalized = `EXISTS (SELECT 1 FROM bridge_events WHERE bridge_events.id = bridge_transactions.id AND bridge_events.type = ? AND NOT bridge_events.finalized)`
+		eventExistsBefore      = `EXISTS (SELECT 1 FROM bridge_events WHERE bridge_events.id = bridge_transactions.id AND bridge_events.type = ? AND bridge_events.finalized AND bridge_events.block_timestamp <= ?)`
+		eventExistsAfter       = `EXISTS (SELECT 1 FROM bridge_events WHERE bridge_events.id = bridge_transactions.id AND bridge_events.type = ? AND bridge_events.finalized AND bridge_events.block_timestamp > ?)`
+	)
+
+	databaseClient = databaseClient.Where(`type = ?`, schema.BridgeTransactionTypeWithdraw)
+
+	if *query.WithdrawalStatus == schema.BridgeWithdrawalStatusFinalized {
+		return databaseClient.Where(eventExists, schema.BridgeEventTypeWithdrawalFinalized)
+	}
+
+	databaseClient = databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalFinalized)
+
+	switch *query.WithdrawalStatus {
+	case schema.BridgeWithdrawalStatusInitiated:
+		return databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalProved).Where(`block_timestamp > ?`, query.ProvableBefore)
+	case schema.BridgeWithdrawalStatusReadyToProve:
+		return databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalProved).Where(`block_timestamp <= ?`, query.ProvableBefore)
+	case schema.BridgeWithdrawalStatusProven:
+		return databaseClient.Where(eventExistsUnfinalized, schema.BridgeEventTypeWithdrawalProved)
+	case schema.BridgeWithdrawalStatusChallengePeriod:
+		return databaseClient.Where(eventExistsAfter, schema.BridgeEventTypeWithdrawalProved, query.FinalizableBefore)
+	case schema.BridgeWithdrawalStatusReadyToFinalize:
+		return databaseClient.Where(eventExistsBefore, schema.BridgeEventTypeWithdrawalProved, query.FinalizableBefore)
+	default:
+		return databaseClient
+	}
+}
@@ -0,0 +1,400 @@
+-- +goose Up
+
+-- bridge
+create table if not exists bridge_events
+(
+    id                 varchar(66)           not null,
+    type               varchar(64)           not null,
+    transaction_hash   varchar(66)           not null,
+    transaction_index  bigint                not null,
+    transaction_status bigint                not null,
+    chain_id           bigint                not null,
+    block_hash         varchar(66)           not null,
+    block_number       bigint                not null,
+    block_timestamp    datetime(6)           not null,
+    finalized          boolean default false not null,
+    constraint pk_bridge_events primary key (transaction_hash, block_hash),
+    index idx_bridge_events_id (id),
+    index idx_bridge_events_chain_id_block_number (chain_id, block_number)
+);
+
+create table if not exists bridge_transactions
+(
+    id                varchar(66)           not null,
+    type              varchar(64)           not null,
+    sender            varchar(42)           not null,
+    receiver          varchar(42)           not null,
+    token_address_l1  varchar(42),
+    token_address_l2  varchar(42),
+    token_value       decimal(65, 0)        not null,
+    data              text,
+    chain_id          bigint                not null,
+    block_number      bigint,
+    transaction_index bigint,
+    block_timestamp   datetime(6),
+    finalized         boolean default false not null,
+    constraint pk_bridge_transactions primary key (id, type),
+    index idx_bridge_transactions_sender (sender),
+    index idx_bridge_transactions_sender_receiver (sender, receiver),
+    index idx_bridge_transactions_receiver (receiver),
+    index idx_bridge_transactions_chain_id_block_number (chain_id, block_number),
+    index idx_bridge_transactions_order (block_timestamp desc, block_number desc, transaction_index desc)
+);
+
+-- epoch
+create table if not exists epoch_apy_snapshots
+(
+    epoch_id   bigint                                    not null,
+    date       datetime(6)                               not null,
+    apy        decimal(65, 18)                           not null,
+    created_at datetime(6) default current_timestamp(6) not null,
+    updated_at datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_epoch_apy_snapshots primary key (epoch_id),
+    index idx_epoch_apy_snapshots_date (date)
+);
+
+create table if not exists epoch
+(
+    id                      bigint                                    not null,
+    start_timestamp         datetime(6)                               not null,
+    end_timestamp           datetime(6)                               not null,
+    block_hash              varchar(66)                               not null,
+    block_number            bigint                                    not null,
+    block_timestamp         datetime(6)                               not null,
+    transaction_hash        varchar(66)                               not null,
+    transaction_index       bigint                                    not null,
+    total_operation_rewards decimal(65, 0),
+    total_staking_rewards   decimal(65, 0),
+    total_rewarded_nodes    bigint,
+    created_at              datetime(6) default current_timestamp(6) not null,
+    updated_at              datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    total_request_counts    decimal(65, 0)                 default 0,
+    finalized               boolean                        default false not null,
+    constraint pk_epoch primary key (transaction_hash),
+    index idx_epoch_start_timestamp_end_timestamp (start_timestamp desc, end_timestamp desc),
+    index idx_epoch_id_block_number_transaction_index (id desc, block_number desc, transaction_index desc)
+);
+
+create table if not exists epoch_trigger
+(
+    transaction_hash varchar(66)                               not null,
+    epoch_id         bigint                                    not null,
+    data             json                                      not null,
+    created_at       datetime(6) default current_timestamp(6) not null,
+    updated_at       datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_epoch_trigger primary key (transaction_hash),
+    index idx_epoch_trigger_epoch_id (epoch_id),
+    index idx_epoch_trigger_created_at (created_at)
+);
+
+-- node
+create table if not exists node_count_snapshots
+(
+    date  date             not null,
+    count bigint default 0 not null,
+    constraint pk_node_count_snapshots primary key (date)
+);
+
+create table if not exists node_events
+(
+    transaction_hash  varchar(66)                               not null,
+    transaction_index bigint                                    not null,
+    node_id           bigint                                    not null,
+    address_from      binary(20)                                not null,
+    address_to        binary(20)                                not null,
+    type              varchar(64)                               not null,
+    log_index         bigint                                    not null,
+    chain_id          bigint                                    not null,
+    block_hash        varchar(66)                               not null,
+    block_number      bigint                                    not null,
+    block_timestamp   datetime(6)                               not null,
+    metadata          json                                      not null,
+    created_at        datetime(6) default current_timestamp(6) not null,
+    updated_at        datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    finalized         boolean     default false                 not null,
+    constraint pk_node_events primary key (transaction_hash, transaction_index, log_index),
+    index idx_node_events_node_id (node_id),
+    index idx_node_events_address_from_address_to (address_from, address_to),
+    index idx_node_events_address_from_type (address_from, type),
+    index idx_node_events_block_number_transaction_index_log_index (block_number desc, transaction_index desc, log_index desc),
+    index idx_node_events_block_number (block_number)
+);
+
+create table if not exists node_operator_profit_snapshots
+(
+    id             bigint auto_increment                     not null,
+    date           datetime(6)                               not null,
+    epoch_id       bigint                                    not null,
+    operator       binary(20)                                not null,
+    operation_pool decimal(65, 0)                            not null,
+    created_at     datetime(6) default current_timestamp(6) not null,
+    updated_at     datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_operator_profit_snapshots primary key (operator, epoch_id),
+    index idx_node_operator_profit_snapshots_id (id desc),
+    index idx_node_operator_profit_snapshots_operation_pool (operation_pool desc),
+    index idx_node_operator_profit_snapshots_epoch_id (epoch_id desc),
+    index idx_node_operator_profit_snapshots_date (date)
+);
+
+create table if not exists node_apy_snapshots
+(
+    id           bigint auto_increment                     not null,
+    date         datetime(6)                               not null,
+    epoch_id     bigint                                    not null,
+    node_address binary(20)                                not null,
+    apy          decimal(65, 18)                           not null,
+    created_at   datetime(6) default current_timestamp(6) not null,
+    updated_at   datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_apy_snapshots primary key (node_address, epoch_id),
+    index idx_node_apy_snapshots_id (id),
+    index idx_node_apy_snapshots_date (date),
+    index idx_node_apy_snapshots_epoch_id_id (epoch_id desc, id desc)
+);
+
+create table if not exists node_info
+(
+    id                       bigint                                    not null,
+    address                  binary(20)                                not null,
+    endpoint                 varchar(512)                              not null,
+    is_public_good           boolean                                   not null,
+    stream                   json,
+    config                   json,
+    status                   varchar(32) default 'offline'             not null,
+    location                 json        default (json_array())        not null,
+    last_heartbeat_timestamp datetime(6),
+    created_at               datetime(6) default current_timestamp(6) not null,
+    updated_at               datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    avatar                   json,
+    hide_tax_rate            boolean     default false,
+    apy                      decimal(65, 18) default 0,
+    type                     varchar(32),
+    access_token             text,
+    version                  varchar(64),
+    constraint pk_node_info primary key (address),
+    constraint idx_id unique (id),
+    constraint idx_endpoint_unique unique (endpoint),
+    index idx_node_info_is_public (is_public_good asc, created_at desc),
+    index idx_node_info_last_heartbeat_timestamp (last_heartbeat_timestamp),
+    index idx_node_info_status (status),
+    index idx_node_info_address_created_at (address asc, created_at desc),
+    index idx_node_info_version (version),
+    index idx_node_info_type (type)
+);
+
+create table if not exists node_stat
+(
+    address                     binary(20)                                not null,
+    endpoint                    varchar(512)                              not null,
+    points                      double                                    not null,
+    is_public_good              boolean                                   not null,
+    is_full_node                boolean                                   not null,
+    is_rss_node                 boolean                                   not null,
+    staking                     double                                    not null,
+    epoch                       bigint                                    not null,
+    total_request_count         bigint                                    not null,
+    epoch_request_count         bigint                                    not null,
+    epoch_invalid_request_count bigint                                    not null,
+    decentralized_network_count bigint                                    not null,
+    federated_network_count     bigint                                    not null,
+    indexer_count               bigint                                    not null,
+    reset_at                    datetime(6)                               not null,
+    created_at                  datetime(6) default current_timestamp(6) not null,
+    updated_at                  datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    access_token                text,
+    constraint pk_node_stat primary key (address),
+    index idx_node_stat_created_at (created_at),
+    index idx_node_stat_epoch_invalid_request_count (epoch_invalid_request_count),
+    index idx_node_stat_points (points desc),
+    index idx_node_stat_is_full_node_points (is_full_node, points desc),
+    index idx_node_stat_is_rss_node_points (is_rss_node, points desc)
+);
+
+create table if not exists node_invalid_response
+(
+    id                bigint auto_increment                     not null,
+    epoch_id          bigint                                    not null,
+    type              varchar(32)                               not null,
+    request           text                                      not null,
+    verifier_nodes    json,
+    verifier_response json,
+    node              binary(20)                                not null,
+    response          json                                      not null,
+    appeal_status     varchar(32),
+    appeal_reason     text,
+    appealed_at       datetime(6),
+    resolved_at       datetime(6),
+    created_at        datetime(6) default current_timestamp(6) not null,
+    updated_at        datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_invalid_response primary key (id),
+    index idx_node_invalid_response_node_created_at (node asc, created_at desc),
+    index idx_node_invalid_response_node_id (node asc, id desc),
+    index idx_node_invalid_response_request_created_at (request(255) asc, created_at desc),
+    index idx_node_invalid_response_type_created_at (type asc, created_at desc),
+    index idx_node_invalid_response_epoch_id (epoch_id desc),
+    index idx_node_invalid_response_appeal_status (appeal_status)
+);
+
+create table if not exists node_reward_record
+(
+    epoch_id          bigint                                    not null,
+    `index`           bigint                                    not null,
+    node_address      varchar(42)                               not null,
+    transaction_hash  varchar(66)                               not null,
+    operation_rewards decimal(65, 0)                            not null,
+    staking_rewards   decimal(65, 0)                            not null,
+    tax_collected     decimal(65, 0)                            not null,
+    created_at        datetime(6) default current_timestamp(6) not null,
+    updated_at        datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    request_count     decimal(65, 0)              default 0,
+    constraint pk_epoch_item primary key (transaction_hash, `index`),
+    index idx_node_reward_record_node_address (node_address),
+    index idx_node_reward_record_epoch_id (epoch_id)
+);
+
+create table if not exists node_worker
+(
+    address   binary(20)            not null,
+    network   varchar(64)           not null,
+    name      varchar(64)           not null,
+    epoch_id  bigint  default 0     not null,
+    is_active boolean default false not null,
+    constraint pk_node_worker primary key (epoch_id, address, network, name),
+    index idx_node_worker_is_active (is_active)
+);
+
+-- stake
+create table if not exists stake_transactions
+(
+    id                varchar(66)           not null,
+    type              varchar(32)           not null,
+    user              varchar(42)           not null,
+    node              varchar(42)           not null,
+    value             decimal(65, 0)        not null,
+    chips             json                  not null,
+    block_number      bigint                not null,
+    transaction_index bigint                not null,
+    block_timestamp   datetime(6)           not null,
+    finalized         boolean default false not null,
+    constraint pk_stake_transactions primary key (id, type),
+    index idx_stake_transactions_block_number (block_number),
+    index idx_stake_transactions_order (block_timestamp desc, block_number desc, transaction_index desc),
+    index idx_stake_transactions_node (node),
+    index idx_stake_transactions_user_node (user, node),
+    index idx_stake_transactions_user (user)
+);
+
+create table if not exists stake_events
+(
+    id                 varchar(66)           not null,
+    type               varchar(32)           not null,
+    transaction_hash   varchar(66)           not null,
+    transaction_index  bigint                not null,
+    transaction_status bigint                not null,
+    block_hash         varchar(66)           not null,
+    block_number       bigint                not null,
+    block_timestamp    datetime(6)           not null,
+    finalized          boolean default false not null,
+    log_index          bigint  default 0     not null,
+    metadata           json,
+    constraint pk_stake_events primary key (transaction_hash, log_index, id),
+    index idx_stake_events_id (id),
+    index idx_stake_events_order (block_timestamp desc, block_number desc, transaction_index desc),
+    index idx_stake_events_block_number (block_number)
+);
+
+create table if not exists stake_chips
+(
+    id              decimal(65, 0)        not null,
+    owner           varchar(42)           not null,
+    node            varchar(42)           not null,
+    block_number    decimal(65, 0)        not null,
+    block_timestamp datetime(6)           not null,
+    metadata        json,
+    value           decimal(65, 0),
+    finalized       boolean default false not null,
+    constraint pk_stake_chips primary key (id),
+    index idx_stake_chips_owner_node_value_finalized (owner, node, value, finalized),
+    index idx_stake_chips_block_number (block_number),
+    index idx_stake_chips_node (node),
+    index idx_stake_chips_owner (owner)
+);
+
+create table if not exists stake_count_snapshots
+(
+    date  date             not null,
+    count bigint default 0 not null,
+    constraint pk_stake_count_snapshots primary key (date)
+);
+
+create table if not exists stake_profit_snapshots
+(
+    id                 bigint auto_increment                     not null,
+    date               datetime(6)                               not null,
+    epoch_id           bigint                                    not null,
+    owner_address      binary(20)                                not null,
+    total_chip_amounts decimal(65, 0)                            not null,
+    total_chip_values  decimal(65, 0)                            not null,
+    created_at         datetime(6) default current_timestamp(6) not null,
+    updated_at         datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_stake_profit_snapshots primary key (owner_address, epoch_id),
+    index idx_stake_profit_snapshots_id (id),
+    index idx_stake_profit_snapshots_date (date),
+    index idx_stake_profit_snapshots_total_chip_amounts (total_chip_amounts desc),
+    index idx_stake_profit_snapshots_epoch_id_id (epoch_id desc, id desc),
+    index idx_stake_profit_snapshots_total_chip_values (total_chip_values desc)
+);
+
+create or replace view stake_stakings (staker, node, count, value) as
+SELECT owner AS staker, node, count(*) AS count, sum(value) AS value
+FROM stake_chips
+WHERE finalized IS true
+GROUP BY owner, node;
+
+-- public
+create table if not exists checkpoints
+(
+    chain_id     bigint                                    not null,
+    block_number bigint                                    not null,
+    block_hash   varchar(66)                               not null,
+    created_at   datetime(6) default current_timestamp(6) not null,
+    updated_at   datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_checkpoints primary key (chain_id)
+);
+
+create table if not exists average_tax_rate_submissions
+(
+    id               bigint auto_increment                     not null,
+    epoch_id         bigint                                    not null,
+    transaction_hash varchar(66)                               not null,
+    average_tax_rate decimal(65, 18)                           not null,
+    created_at       datetime(6) default current_timestamp(6) not null,
+    updated_at       datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_average_tax_rate_submissions primary key (epoch_id),
+    index idx_average_tax_rate_submissions_id (id desc),
+    index idx_average_tax_rate_submissions_transaction_hash (transaction_hash),
+    index idx_average_tax_rate_submissions_epoch_id (epoch_id desc)
+);
+
+-- +goose Down
+drop view if exists stake_stakings;
+drop table if exists bridge_events;
+drop table if exists bridge_transactions;
+drop table if exists epoch_apy_snapshots;
+drop table if exists epoch;
+drop table if exists epoch_trigger;
+drop table if exists node_count_snapshots;
+drop table if exists node_events;
+drop table if exists node_operator_profit_snapshots;
+drop table if exists node_apy_snapshots;
+drop table if exists node_info;
+drop table if exists node_stat;
+drop table if exists node_invalid_response;
+drop table if exists node_reward_record;
+drop table if exists node_worker;
+drop table if exists stake_transactions;
+drop table if exists stake_events;
+drop table if exists stake_chips;
+drop table if exists stake_count_snapshots;
+drop table if exists stake_profit_snapshots;
+drop table if exists checkpoints;
+drop table if exists average_tax_rate_submissions;
@@ -0,0 +1,32 @@
+-- +goose Up
+create table if not exists node_score_breakdown
+(
+    epoch_id              bigint                                    not null,
+    node_address          binary(20)                                not null,
+    valid_request_count   bigint                                    not null,
+    invalid_request_count bigint                                    not null,
+    network_count         bigint                                    not null,
+    indexer_count         bigint                                    not null,
+    activity_count        bigint                                    not null,
+    uptime                bigint                                    not null,
+    is_latest_version     boolean                                   not null,
+    valid_score           double                                    not null,
+    invalid_score         double                                    not null,
+    network_score         double                                    not null,
+    indexer_score         double                                    not null,
+    activity_score        double                                    not null,
+    uptime_score          double                                    not null,
+    version_score         double                                    not null,
+    distribution_score    double                                    not null,
+    data_score            double                                    not null,
+    stability_score       double                                    not null,
+    score                 double                                    not null,
+    operation_rewards     decimal(65, 0)                            not null,
+    created_at            datetime(6) default current_timestamp(6) not null,
+    updated_at            datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_score_breakdown primary key (epoch_id, node_address),
+    index idx_node_score_breakdown_node_address (node_address, epoch_id desc)
+);
+
+-- +goose Down
+drop table if exists node_score_breakdown;
@@ -0,0 +1,29 @@
+-- +goose Up
+create table if not exists tx_journal
+(
+    id                       bigint auto_increment                     not null,
+    service                  varchar(64)                               not null,
+    chain_id                 bigint                                    not null,
+    from_address             binary(20)                                not null,
+    nonce                    bigint unsigned                           not null,
+    to_address               binary(20),
+    data                     longblob,
+    value                    decimal(65, 0)                            not null,
+    gas_limit                bigint unsigned                           not null,
+    gas_tip_cap              decimal(65, 0)                            not null,
+    gas_fee_cap              decimal(65, 0)                            not null,
+    transaction_hashes       json                                      not null,
+    raw_transaction          longblob                                  not null,
+    status                   varchar(16)                               not null,
+    receipt_transaction_hash binary(32),
+    receipt_block_number     bigint unsigned,
+    receipt_status           bigint unsigned,
+    receipt_gas_used         bigint unsigned,
+    created_at               datetime(6) default current_timestamp(6) not null,
+    updated_at               datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_tx_journal primary key (id),
+    index idx_tx_journal_status (chain_id, service, status)
+);
+
+-- +goose Down
+drop table if exists tx_journal;
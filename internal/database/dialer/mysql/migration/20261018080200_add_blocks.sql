@@ -0,0 +1,14 @@
+-- +goose Up
+create table if not exists blocks
+(
+    chain_id     bigint                                    not null,
+    block_number bigint                                    not null,
+    block_hash   varchar(66)                               not null,
+    parent_hash  varchar(66)                               not null,
+    created_at   datetime(6) default current_timestamp(6) not null,
+    updated_at   datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_blocks primary key (chain_id, block_number)
+);
+
+-- +goose Down
+drop table if exists blocks;
@@ -0,0 +1,21 @@
+-- +goose Up
+create table if not exists epoch_decentralization_snapshots
+(
+    epoch_id             bigint                                    not null,
+    date                 datetime(6)                               not null,
+    nodes                bigint                                    not null,
+    staking_pool_tokens  decimal(65, 0)                            not null,
+    nakamoto_coefficient bigint                                    not null,
+    gini                 decimal(65, 18)                           not null,
+    countries            json        default (json_array())        not null,
+    network_providers    json        default (json_array())        not null,
+    workers              json        default (json_array())        not null,
+    full_nodes           bigint      default 0                     not null,
+    created_at           datetime(6) default current_timestamp(6) not null,
+    updated_at           datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_epoch_decentralization_snapshots primary key (epoch_id),
+    index idx_epoch_decentralization_snapshots_date (date)
+);
+
+-- +goose Down
+drop table if exists epoch_decentralization_snapshots;
@@ -0,0 +1,37 @@
+-- +goose Up
+create table if not exists node_webhooks
+(
+    id           bigint auto_increment                     not null,
+    node_address binary(20)                                not null,
+    url          varchar(512)                              not null,
+    secret       varchar(128)                              not null,
+    events       json        default (json_array())        not null,
+    created_at   datetime(6) default current_timestamp(6) not null,
+    updated_at   datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_webhooks primary key (id),
+    constraint idx_node_webhooks_node_address_url unique (node_address, url)
+);
+
+create table if not exists node_webhook_deliveries
+(
+    id              bigint auto_increment                     not null,
+    webhook_id      bigint                                    not null,
+    node_address    binary(20)                                not null,
+    event_type      varchar(64)                               not null,
+    data            json                                      not null,
+    status          varchar(16)                               not null,
+    attempts        bigint unsigned default 0                 not null,
+    next_attempt_at datetime(6)                               not null,
+    response_status bigint,
+    error           text,
+    delivered_at    datetime(6),
+    created_at      datetime(6) default current_timestamp(6) not null,
+    updated_at      datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_node_webhook_deliveries primary key (id),
+    index idx_node_webhook_deliveries_status_next_attempt_at (status, next_attempt_at),
+    index idx_node_webhook_deliveries_webhook_id_id (webhook_id asc, id desc)
+);
+
+-- +goose Down
+drop table if exists node_webhook_deliveries;
+drop table if exists node_webhooks;
@@ -0,0 +1,15 @@
+-- +goose Up
+create table if not exists epoch_tvl_snapshots
+(
+    epoch_id   bigint                                    not null,
+    date       datetime(6)                               not null,
+    tvl        decimal(65, 18)                           not null,
+    tokens     json        default (json_array())        not null,
+    created_at datetime(6) default current_timestamp(6) not null,
+    updated_at datetime(6) default current_timestamp(6) not null on update current_timestamp(6),
+    constraint pk_epoch_tvl_snapshots primary key (epoch_id),
+    index idx_epoch_tvl_snapshots_date (date)
+);
+
+-- +goose Down
+drop table if exists epoch_tvl_snapshots;
@@ -0,0 +1,5 @@
+-- +goose Up
+alter table tx_journal add column metadata json after raw_transaction;
+
+-- +goose Down
+alter table tx_journal drop column metadata;
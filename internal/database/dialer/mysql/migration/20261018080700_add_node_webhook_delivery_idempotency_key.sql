@@ -0,0 +1,9 @@
+-- +goose Up
+alter table node_webhook_deliveries
+    add column idempotency_key varchar(255) after event_type,
+    add unique index idx_node_webhook_deliveries_webhook_id_idempotency_key (webhook_id, idempotency_key);
+
+-- +goose Down
+alter table node_webhook_deliveries
+    drop index idx_node_webhook_deliveries_webhook_id_idempotency_key,
+    drop column idempotency_key;
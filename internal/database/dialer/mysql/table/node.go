@@ -0,0 +1,151 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type Node struct {
+	Address                common.Address    `gorm:"column:address;primaryKey"`
+	NodeID                 uint64            `gorm:"column:id"`
+	Endpoint               string            `gorm:"column:endpoint"`
+	HideTaxRate            bool              `gorm:"column:hide_tax_rate"`
+	IsPublicGood           bool              `gorm:"column:is_public_good"`
+	Stream                 json.RawMessage   `gorm:"column:stream"`
+	Config                 json.RawMessage   `gorm:"column:config;type:json"`
+	Status                 schema.NodeStatus `gorm:"column:status"`
+	LastHeartbeatTimestamp time.Time         `gorm:"column:last_heartbeat_timestamp"`
+	Location               json.RawMessage   `gorm:"column:location;type:json"`
+	Avatar                 json.RawMessage   `gorm:"column:avatar;type:json"`
+	APY                    decimal.Decimal   `gorm:"column:apy"`
+	Version                string            `gorm:"column:version"`
+	Type                   string            `gorm:"column:type"`
+	AccessToken            string            `gorm:"column:access_token"`
+	CreatedAt              time.Time         `gorm:"column:created_at"`
+	UpdatedAt              time.Time         `gorm:"column:updated_at"`
+}
+
+// NodeLocation is a location of the Node in the location column, it keeps whether the autonomous system has been looked up.
+type NodeLocation struct {
+	schema.NodeLocation
+
+	ASNLookedUp bool `json:"asn_looked_up,omitempty"`
+}
+
+func (*Node) TableName() string {
+	return "node_info"
+}
+
+func (n *Node) Import(node *schema.Node) (err error) {
+	n.Address = node.Address
+	n.NodeID = node.ID.Uint64()
+	n.Endpoint = node.Endpoint
+	n.HideTaxRate = node.HideTaxRate
+	n.IsPublicGood = node.IsPublicGood
+	n.Status = node.Status
+	n.LastHeartbeatTimestamp = time.Unix(node.LastHeartbeatTimestamp, 0)
+	n.Stream = node.Stream
+	n.Config = node.Config
+	n.APY = node.APY
+	n.Version = node.Version
+	n.Type = node.Type
+	n.AccessToken = node.AccessToken
+
+	locations := make([]*NodeLocation, 0, len(node.Location))
+
+	for _, location := range lo.Compact(node.Location) {
+		locations = append(locations, &NodeLocation{NodeLocation: *location, ASNLookedUp: location.ASNLookedUp})
+	}
+
+	n.Location, err = json.Marshal(locations)
+	if err != nil {
+		return fmt.Errorf("marshal node local: %w", err)
+	}
+
+	n.Avatar, err = json.Marshal(node.Avatar)
+	if err != nil {
+		return fmt.Errorf("marshal node avatar: %w", err)
+	}
+
+	return nil
+}
+
+func (n *Node) Export() (*schema.Node, error) {
+	var tLocations []*NodeLocation
+
+	if err := json.Unmarshal(n.Location, &tLocations); len(n.Location) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal node locations: %w", err)
+	}
+
+	locations := make([]*schema.NodeLocation, 0, len(tLocations))
+
+	for _, location := range lo.Compact(tLocations) {
+		location.NodeLocation.ASNLookedUp = location.ASNLookedUp
+		locations = append(locations, &location.NodeLocation)
+	}
+
+	var avatar *l2.ChipsTokenMetadata
+	if err := json.Unmarshal(n.Avatar, &avatar); len(n.Avatar) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal node avatar: %w", err)
+	}
+
+	return &schema.Node{
+		Address:                n.Address,
+		ID:                     big.NewInt(int64(n.NodeID)),
+		Endpoint:               n.Endpoint,
+		HideTaxRate:            n.HideTaxRate,
+		IsPublicGood:           n.IsPublicGood,
+		Status:                 n.Status,
+		LastHeartbeatTimestamp: n.LastHeartbeatTimestamp.Unix(),
+		Stream:                 n.Stream,
+		Config:                 n.Config,
+		Location:               locations,
+		Avatar:                 avatar,
+		APY:                    n.APY,
+		Version:                n.Version,
+		Type:                   n.Type,
+		AccessToken:            n.AccessToken,
+		CreatedAt:              n.CreatedAt.Unix(),
+	}, nil
+}
+
+type Nodes []Node
+
+func (n *Nodes) Import(nodes []*schema.Node) (err error) {
+	*n = make([]Node, 0, len(nodes))
+
+	for _, node := range nodes {
+		var tNode Node
+
+		if err = tNode.Import(node); err != nil {
+			return err
+		}
+
+		*n = append(*n, tNode)
+	}
+
+	return nil
+}
+
+func (n Nodes) Export() ([]*schema.Node, error) {
+	nodes := make([]*schema.Node, 0)
+
+	for _, node := range n {
+		exportedNode, err := node.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, exportedNode)
+	}
+
+	return nodes, nil
+}
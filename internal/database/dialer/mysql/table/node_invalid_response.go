@@ -0,0 +1,105 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
+type NodeInvalidResponse struct {
+	ID               uint64                         `gorm:"id;primaryKey"`
+	EpochID          uint64                         `gorm:"column:epoch_id"`
+	Type             schema.NodeInvalidResponseType `gorm:"column:type"`
+	Request          string                         `gorm:"column:request"`
+	VerifierNodes    json.RawMessage                `gorm:"column:verifier_nodes;type:json"`
+	VerifierResponse json.RawMessage                `gorm:"column:verifier_response;type:json"`
+	Node             common.Address                 `gorm:"column:node"`
+	Response         json.RawMessage                `gorm:"column:response;type:json"`
+	AppealStatus     *string                        `gorm:"column:appeal_status"`
+	AppealReason     *string                        `gorm:"column:appeal_reason"`
+	AppealedAt       *time.Time                     `gorm:"column:appealed_at"`
+	ResolvedAt       *time.Time                     `gorm:"column:resolved_at"`
+	CreatedAt        time.Time                      `gorm:"column:created_at"`
+	UpdatedAt        time.Time                      `gorm:"column:updated_at"`
+}
+
+func (*NodeInvalidResponse) TableName() string {
+	return "node_invalid_response"
+}
+
+func (n *NodeInvalidResponse) Import(nodeInvalidResponse *schema.NodeInvalidResponse) {
+	n.EpochID = nodeInvalidResponse.EpochID
+	n.Type = nodeInvalidResponse.Type
+	n.Request = nodeInvalidResponse.Request
+
+	n.VerifierNodes, _ = json.Marshal(nodeInvalidResponse.VerifierNodes)
+
+	n.VerifierResponse = nodeInvalidResponse.VerifierResponse
+	n.Node = nodeInvalidResponse.Node
+	n.Response = nodeInvalidResponse.Response
+}
+
+func (n *NodeInvalidResponse) Export() *schema.NodeInvalidResponse {
+	var verifierNodes []common.Address
+
+	if len(n.VerifierNodes) > 0 {
+		_ = json.Unmarshal(n.VerifierNodes, &verifierNodes)
+	}
+
+	var appeal *schema.NodeInvalidResponseAppeal
+
+	if n.AppealStatus != nil {
+		appeal = &schema.NodeInvalidResponseAppeal{
+			Status: schema.NodeInvalidResponseAppealStatus(*n.AppealStatus),
+			Reason: lo.FromPtr(n.AppealReason),
+		}
+
+		if n.AppealedAt != nil {
+			appeal.AppealedAt = n.AppealedAt.Unix()
+		}
+
+		if n.ResolvedAt != nil {
+			appeal.ResolvedAt = n.ResolvedAt.Unix()
+		}
+	}
+
+	return &schema.NodeInvalidResponse{
+		ID:               n.ID,
+		EpochID:          n.EpochID,
+		Type:             n.Type,
+		Request:          n.Request,
+		VerifierNodes:    verifierNodes,
+		VerifierResponse: n.VerifierResponse,
+		Node:             n.Node,
+		Response:         n.Response,
+		CreatedAt:        n.CreatedAt.Unix(),
+		Appeal:           appeal,
+	}
+}
+
+type NodeInvalidResponses []NodeInvalidResponse
+
+func (ns *NodeInvalidResponses) Import(nodeInvalidResponses []*schema.NodeInvalidResponse) {
+	*ns = make([]NodeInvalidResponse, 0, len(nodeInvalidResponses))
+
+	for _, nodeInvalidResponse := range nodeInvalidResponses {
+		var tNodeInvalidResponse NodeInvalidResponse
+
+		tNodeInvalidResponse.Import(nodeInvalidResponse)
+
+		*ns = append(*ns, tNodeInvalidResponse)
+	}
+}
+
+func (ns *NodeInvalidResponses) Export() []*schema.NodeInvalidResponse {
+	nodeInvalidResponses := make([]*schema.NodeInvalidResponse, 0, len(*ns))
+
+	for _, nodeInvalidResponse := range *ns {
+		nodeInvalidResponses = append(nodeInvalidResponses, nodeInvalidResponse.Export())
+	}
+
+	return nodeInvalidResponses
+}
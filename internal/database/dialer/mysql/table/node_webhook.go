@@ -0,0 +1,151 @@
+package table
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeWebhook struct {
+	ID          uint64          `gorm:"column:id;primaryKey"`
+	NodeAddress common.Address  `gorm:"column:node_address"`
+	URL         string          `gorm:"column:url"`
+	Secret      string          `gorm:"column:secret"`
+	Events      json.RawMessage `gorm:"column:events;type:json"`
+	CreatedAt   time.Time       `gorm:"column:created_at"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at"`
+}
+
+func (*NodeWebhook) TableName() string {
+	return "node_webhooks"
+}
+
+func (w *NodeWebhook) Import(webhook *schema.NodeWebhook) (err error) {
+	w.ID = webhook.ID
+	w.NodeAddress = webhook.NodeAddress
+	w.URL = webhook.URL
+	w.Secret = webhook.Secret
+
+	events := webhook.Events
+	if events == nil {
+		events = make([]schema.NodeWebhookEventType, 0)
+	}
+
+	w.Events, err = json.Marshal(events)
+
+	return err
+}
+
+func (w *NodeWebhook) Export() (*schema.NodeWebhook, error) {
+	webhook := schema.NodeWebhook{
+		ID:          w.ID,
+		NodeAddress: w.NodeAddress,
+		URL:         w.URL,
+		Secret:      w.Secret,
+		Events:      make([]schema.NodeWebhookEventType, 0),
+		CreatedAt:   w.CreatedAt.Unix(),
+		UpdatedAt:   w.UpdatedAt.Unix(),
+	}
+
+	if err := json.Unmarshal(w.Events, &webhook.Events); len(w.Events) > 0 && err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+type NodeWebhooks []NodeWebhook
+
+func (ws *NodeWebhooks) Export() ([]*schema.NodeWebhook, error) {
+	webhooks := make([]*schema.NodeWebhook, 0, len(*ws))
+
+	for _, webhook := range *ws {
+		exported, err := webhook.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, exported)
+	}
+
+	return webhooks, nil
+}
+
+type NodeWebhookDelivery struct {
+	ID             uint64          `gorm:"column:id;primaryKey"`
+	WebhookID      uint64          `gorm:"column:webhook_id"`
+	NodeAddress    common.Address  `gorm:"column:node_address"`
+	EventType      string          `gorm:"column:event_type"`
+	IdempotencyKey *string         `gorm:"column:idempotency_key"`
+	Data           json.RawMessage `gorm:"column:data;type:json"`
+	Status         string          `gorm:"column:status"`
+	Attempts       uint            `gorm:"column:attempts"`
+	NextAttemptAt  time.Time       `gorm:"column:next_attempt_at"`
+	ResponseStatus *int            `gorm:"column:response_status"`
+	Error          *string         `gorm:"column:error"`
+	DeliveredAt    *time.Time      `gorm:"column:delivered_at"`
+	CreatedAt      time.Time       `gorm:"column:created_at"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at"`
+}
+
+func (*NodeWebhookDelivery) TableName() string {
+	return "node_webhook_deliveries"
+}
+
+func (d *NodeWebhookDelivery) Import(delivery *schema.NodeWebhookDelivery) {
+	d.ID = delivery.ID
+	d.WebhookID = delivery.WebhookID
+	d.NodeAddress = delivery.NodeAddress
+	d.EventType = string(delivery.EventType)
+	d.IdempotencyKey = delivery.IdempotencyKey
+	d.Data = delivery.Data
+	d.Status = string(delivery.Status)
+	d.Attempts = delivery.Attempts
+	d.NextAttemptAt = delivery.NextAttemptAt
+	d.ResponseStatus = delivery.ResponseStatus
+	d.Error = delivery.Error
+	d.DeliveredAt = delivery.DeliveredAt
+}
+
+func (d *NodeWebhookDelivery) Export() *schema.NodeWebhookDelivery {
+	return &schema.NodeWebhookDelivery{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		NodeAddress:    d.NodeAddress,
+		EventType:      schema.NodeWebhookEventType(d.EventType),
+		IdempotencyKey: d.IdempotencyKey,
+		Data:           d.Data,
+		Status:         schema.NodeWebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt.Unix(),
+		UpdatedAt:      d.UpdatedAt.Unix(),
+	}
+}
+
+type NodeWebhookDeliveries []NodeWebhookDelivery
+
+func (ds *NodeWebhookDeliveries) Import(deliveries []*schema.NodeWebhookDelivery) {
+	for _, delivery := range deliveries {
+		var imported NodeWebhookDelivery
+
+		imported.Import(delivery)
+
+		*ds = append(*ds, imported)
+	}
+}
+
+func (ds *NodeWebhookDeliveries) Export() []*schema.NodeWebhookDelivery {
+	deliveries := make([]*schema.NodeWebhookDelivery, 0, len(*ds))
+
+	for _, delivery := range *ds {
+		deliveries = append(deliveries, delivery.Export())
+	}
+
+	return deliveries
+}
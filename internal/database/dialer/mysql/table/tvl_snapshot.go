@@ -0,0 +1,69 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type TVLSnapshot struct {
+	EpochID   uint64          `gorm:"column:epoch_id"`
+	Date      time.Time       `gorm:"column:date"`
+	TVL       decimal.Decimal `gorm:"column:tvl"`
+	Tokens    json.RawMessage `gorm:"column:tokens;type:json"`
+	CreatedAt time.Time       `gorm:"column:created_at"`
+	UpdatedAt time.Time       `gorm:"column:updated_at"`
+}
+
+func (t *TVLSnapshot) TableName() string {
+	return "epoch_tvl_snapshots"
+}
+
+func (t *TVLSnapshot) Import(snapshot *schema.TVLSnapshot) (err error) {
+	t.EpochID = snapshot.EpochID
+	t.Date = snapshot.Date
+	t.TVL = snapshot.TVL
+
+	if t.Tokens, err = json.Marshal(snapshot.Tokens); err != nil {
+		return fmt.Errorf("marshal tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (t *TVLSnapshot) Export() (*schema.TVLSnapshot, error) {
+	snapshot := schema.TVLSnapshot{
+		EpochID:   t.EpochID,
+		Date:      t.Date,
+		TVL:       t.TVL,
+		Tokens:    make([]*schema.TVLToken, 0),
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+
+	if err := json.Unmarshal(t.Tokens, &snapshot.Tokens); len(t.Tokens) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal tokens: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+type TVLSnapshots []TVLSnapshot
+
+func (t *TVLSnapshots) Export() ([]*schema.TVLSnapshot, error) {
+	snapshots := make([]*schema.TVLSnapshot, 0, len(*t))
+
+	for _, snapshot := range *t {
+		exported, err := snapshot.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, exported)
+	}
+
+	return snapshots, nil
+}
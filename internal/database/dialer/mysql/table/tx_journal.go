@@ -0,0 +1,124 @@
+package table
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type TxJournal struct {
+	ID                     uint64          `gorm:"column:id;primaryKey"`
+	Service                string          `gorm:"column:service"`
+	ChainID                uint64          `gorm:"column:chain_id"`
+	FromAddress            common.Address  `gorm:"column:from_address"`
+	Nonce                  uint64          `gorm:"column:nonce"`
+	ToAddress              *common.Address `gorm:"column:to_address"`
+	Data                   []byte          `gorm:"column:data"`
+	Value                  decimal.Decimal `gorm:"column:value"`
+	GasLimit               uint64          `gorm:"column:gas_limit"`
+	GasTipCap              decimal.Decimal `gorm:"column:gas_tip_cap"`
+	GasFeeCap              decimal.Decimal `gorm:"column:gas_fee_cap"`
+	TransactionHashes      json.RawMessage `gorm:"column:transaction_hashes;type:json"`
+	RawTransaction         []byte          `gorm:"column:raw_transaction"`
+	Metadata               json.RawMessage `gorm:"column:metadata"`
+	Status                 string          `gorm:"column:status"`
+	ReceiptTransactionHash *common.Hash    `gorm:"column:receipt_transaction_hash"`
+	ReceiptBlockNumber     *uint64         `gorm:"column:receipt_block_number"`
+	ReceiptStatus          *uint64         `gorm:"column:receipt_status"`
+	ReceiptGasUsed         *uint64         `gorm:"column:receipt_gas_used"`
+	CreatedAt              time.Time       `gorm:"column:created_at"`
+	UpdatedAt              time.Time       `gorm:"column:updated_at"`
+}
+
+func (*TxJournal) TableName() string {
+	return "tx_journal"
+}
+
+func (t *TxJournal) Import(journal *schema.TxJournal) {
+	t.ID = journal.ID
+	t.Service = journal.Service
+	t.ChainID = journal.ChainID
+	t.FromAddress = journal.From
+	t.Nonce = journal.Nonce
+	t.ToAddress = journal.To
+	t.Data = journal.Data
+	t.Value = decimalFromBigInt(journal.Value)
+	t.GasLimit = journal.GasLimit
+	t.GasTipCap = decimalFromBigInt(journal.GasTipCap)
+	t.GasFeeCap = decimalFromBigInt(journal.GasFeeCap)
+
+	t.TransactionHashes, _ = json.Marshal(journal.TransactionHashes)
+
+	t.RawTransaction = journal.RawTransaction
+	t.Metadata = journal.Metadata
+	t.Status = string(journal.Status)
+
+	if journal.Receipt != nil {
+		t.ReceiptTransactionHash = &journal.Receipt.TransactionHash
+		t.ReceiptBlockNumber = &journal.Receipt.BlockNumber
+		t.ReceiptStatus = &journal.Receipt.Status
+		t.ReceiptGasUsed = &journal.Receipt.GasUsed
+	}
+}
+
+func (t *TxJournal) Export() *schema.TxJournal {
+	journal := schema.TxJournal{
+		ID:                t.ID,
+		Service:           t.Service,
+		ChainID:           t.ChainID,
+		From:              t.FromAddress,
+		Nonce:             t.Nonce,
+		To:                t.ToAddress,
+		Data:              t.Data,
+		Value:             t.Value.BigInt(),
+		GasLimit:          t.GasLimit,
+		GasTipCap:         t.GasTipCap.BigInt(),
+		GasFeeCap:         t.GasFeeCap.BigInt(),
+		TransactionHashes: make([]common.Hash, 0),
+		RawTransaction:    t.RawTransaction,
+		Metadata:          t.Metadata,
+		Status:            schema.TxJournalStatus(t.Status),
+		CreatedAt:         t.CreatedAt.Unix(),
+		UpdatedAt:         t.UpdatedAt.Unix(),
+	}
+
+	if len(t.TransactionHashes) > 0 {
+		_ = json.Unmarshal(t.TransactionHashes, &journal.TransactionHashes)
+	}
+
+	if t.ReceiptTransactionHash != nil {
+		journal.Receipt = &schema.TxJournalReceipt{
+			TransactionHash: *t.ReceiptTransactionHash,
+			BlockNumber:     lo.FromPtr(t.ReceiptBlockNumber),
+			Status:          lo.FromPtr(t.ReceiptStatus),
+			GasUsed:         lo.FromPtr(t.ReceiptGasUsed),
+		}
+	}
+
+	return &journal
+}
+
+type TxJournals []TxJournal
+
+func (ts *TxJournals) Export() []*schema.TxJournal {
+	journals := make([]*schema.TxJournal, 0, len(*ts))
+
+	for _, journal := range *ts {
+		journals = append(journals, journal.Export())
+	}
+
+	return journals
+}
+
+func decimalFromBigInt(value *big.Int) decimal.Decimal {
+	if value == nil {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromBigInt(value, 0)
+}
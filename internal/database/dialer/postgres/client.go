@@ -76,11 +76,6 @@ func (c *client) Commit() error {
 	return c.database.Commit().Error
 }
 
-func (c *client) RollbackBlock(_ context.Context, _, _ uint64) error {
-	// TODO implement the function.
-	return nil
-}
-
 // Dial dials a database.
 func Dial(_ context.Context, dataSourceName string) (database.Client, error) {
 	logger := zapgorm2.New(zap.L())
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/postgres/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (c *client) FindBlock(ctx context.Context, chainID, blockNumber uint64) (*schema.Block, error) {
+	var block table.Block
+
+	if err := c.database.
+		WithContext(ctx).
+		Where(`"chain_id" = ? AND "block_number" = ?`, chainID, blockNumber).
+		First(&block).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	return block.Export()
+}
+
+func (c *client) FindLatestBlock(ctx context.Context, chainID uint64) (*schema.Block, error) {
+	var block table.Block
+
+	if err := c.database.
+		WithContext(ctx).
+		Where(`"chain_id" = ?`, chainID).
+		Order(`"block_number" DESC`).
+		First(&block).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	return block.Export()
+}
+
+func (c *client) SaveBlock(ctx context.Context, block *schema.Block) error {
+	var value table.Block
+	if err := value.Import(*block); err != nil {
+		return fmt.Errorf("import block: %w", err)
+	}
+
+	clauses := []clause.Expression{
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chain_id"}, {Name: "block_number"}},
+			UpdateAll: true,
+		},
+	}
+
+	return c.database.WithContext(ctx).Clauses(clauses...).Create(&value).Error
+}
+
+func (c *client) DeleteBlocksBeforeBlockNumber(ctx context.Context, chainID, blockNumber uint64) error {
+	return c.database.
+		WithContext(ctx).
+		Delete(new(table.Block), `"chain_id" = ? AND "block_number" < ?`, chainID, blockNumber).
+		Error
+}
+
+// RollbackBlock deletes the unfinalized rows indexed from a block reorganized out of the chain.
+// The stake and epoch rows are only indexed from the RSS3 chain, so they are not deleted for the other chains.
+func (c *client) RollbackBlock(ctx context.Context, chainID, blockNumber uint64) error {
+	if err := c.DeleteBridgeTransactionsByBlockNumber(ctx, chainID, blockNumber); err != nil {
+		return fmt.Errorf("delete bridge transactions: %w", err)
+	}
+
+	if err := c.DeleteBridgeEventsByBlockNumber(ctx, chainID, blockNumber); err != nil {
+		return fmt.Errorf("delete bridge events: %w", err)
+	}
+
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.NodeEvent), `"chain_id" = ? AND "block_number" = ? AND NOT "finalized"`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete node events: %w", err)
+	}
+
+	if _, exists := l2.ContractMap[chainID]; exists {
+		if err := c.DeleteStakeTransactionsByBlockNumber(ctx, blockNumber); err != nil {
+			return fmt.Errorf("delete stake transactions: %w", err)
+		}
+
+		if err := c.DeleteStakeEventsByBlockNumber(ctx, blockNumber); err != nil {
+			return fmt.Errorf("delete stake events: %w", err)
+		}
+
+		if err := c.DeleteStakeChipsByBlockNumber(ctx, blockNumber); err != nil {
+			return fmt.Errorf("delete stake chips: %w", err)
+		}
+
+		if err := c.DeleteEpochsByBlockNumber(ctx, blockNumber); err != nil {
+			return fmt.Errorf("delete epochs: %w", err)
+		}
+	}
+
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.Block), `"chain_id" = ? AND "block_number" = ?`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete block: %w", err)
+	}
+
+	return nil
+}
+
+// RewindBlocks deletes the rows indexed from the blocks after the block, including the finalized ones,
+// so an operator can index these blocks again after rewinding the checkpoint.
+func (c *client) RewindBlocks(ctx context.Context, chainID, blockNumber uint64) error {
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.BridgeTransaction), `"chain_id" = ? AND "block_number" > ?`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete bridge transactions: %w", err)
+	}
+
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.BridgeEvent), `"chain_id" = ? AND "block_number" > ?`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete bridge events: %w", err)
+	}
+
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.NodeEvent), `"chain_id" = ? AND "block_number" > ?`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete node events: %w", err)
+	}
+
+	if _, exists := l2.ContractMap[chainID]; exists {
+		if err := c.database.
+			WithContext(ctx).
+			Delete(new(table.StakeTransaction), `"block_number" > ?`, blockNumber).
+			Error; err != nil {
+			return fmt.Errorf("delete stake transactions: %w", err)
+		}
+
+		if err := c.database.
+			WithContext(ctx).
+			Delete(new(table.StakeEvent), `"block_number" > ?`, blockNumber).
+			Error; err != nil {
+			return fmt.Errorf("delete stake events: %w", err)
+		}
+
+		if err := c.database.
+			WithContext(ctx).
+			Delete(new(table.StakeChip), `"block_number" > ?`, blockNumber).
+			Error; err != nil {
+			return fmt.Errorf("delete stake chips: %w", err)
+		}
+
+		epochs := c.database.
+			Model(new(table.Epoch)).
+			Select(`"transaction_hash"`).
+			Where(`"block_number" > ?`, blockNumber)
+
+		if err := c.database.
+			WithContext(ctx).
+			Delete(new(table.NodeRewardRecord), `"transaction_hash" IN (?)`, epochs).
+			Error; err != nil {
+			return fmt.Errorf("delete epoch items: %w", err)
+		}
+
+		if err := c.database.
+			WithContext(ctx).
+			Delete(new(table.Epoch), `"block_number" > ?`, blockNumber).
+			Error; err != nil {
+			return fmt.Errorf("delete epochs: %w", err)
+		}
+	}
+
+	if err := c.database.
+		WithContext(ctx).
+		Delete(new(table.Block), `"chain_id" = ? AND "block_number" > ?`, chainID, blockNumber).
+		Error; err != nil {
+		return fmt.Errorf("delete blocks: %w", err)
+	}
+
+	return nil
+}
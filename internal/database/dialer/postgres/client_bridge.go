@@ -101,6 +101,10 @@ func (c *client) FindBridgeTransactions(ctx context.Context, query schema.Bridge
 		databaseClient = databaseClient.Where(`"type" = ?`, *query.Type)
 	}
 
+	if query.WithdrawalStatus != nil {
+		databaseClient = whereBridgeWithdrawalStatus(databaseClient, query)
+	}
+
 	if err := databaseClient.Order(`"block_timestamp" DESC, "block_number" DESC, "transaction_index" DESC`).Limit(limit).Find(&rows).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, database.ErrorRowNotFound
@@ -123,6 +127,48 @@ func (c *client) FindBridgeTransactions(ctx context.Context, query schema.Bridge
 	return results, nil
 }
 
+func (c *client) IterateBridgeTransactions(ctx context.Context, query schema.ExportQuery, iterator func(bridgeTransaction *schema.BridgeTransaction) error) error {
+	databaseClient := c.database.WithContext(ctx).Model(&table.BridgeTransaction{})
+
+	if query.Address != nil {
+		databaseClient = databaseClient.Where(`("sender" = ? OR "receiver" = ?)`, query.Address.String(), query.Address.String())
+	}
+
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`"block_timestamp" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`"block_timestamp" <= ?`, query.Until)
+	}
+
+	rows, err := databaseClient.Order(`"block_timestamp", "chain_id", "block_number", "transaction_index"`).Rows()
+	if err != nil {
+		return fmt.Errorf("iterate bridge transactions: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row table.BridgeTransaction
+
+		if err := c.database.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("scan bridge transaction: %w", err)
+		}
+
+		bridgeTransaction, err := row.Export()
+		if err != nil {
+			return fmt.Errorf("export bridge transaction: %w", err)
+		}
+
+		if err := iterator(bridgeTransaction); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (c *client) FindBridgeEvents(ctx context.Context, query schema.BridgeEventsQuery) ([]*schema.BridgeEvent, error) {
 	var rows []*table.BridgeEvent
 
@@ -233,3 +279,36 @@ func (c *client) UpdateBridgeEventsFinalizedByBlockNumber(ctx context.Context, c
 		Update("finalized", true).
 		Error
 }
+
+// whereBridgeWithdrawalStatus filters the withdrawals by the status computed from their events.
+func whereBridgeWithdrawalStatus(databaseClient *gorm.DB, query schema.BridgeTransactionsQuery) *gorm.DB {
+	const (
+		eventExists            = `EXISTS (SELECT 1 FROM "bridge"."events" WHERE "bridge"."events"."id" = "bridge"."transactions"."id" AND "bridge"."events"."type" = ?)`
+		eventExistsUnfinalized = `EXISTS (SELECT 1 FROM "bridge"."events" WHERE "bridge"."events"."id" = "bridge"."transactions"."id" AND "bridge"."events"."type" = ? AND NOT "bridge"."events"."finalized")`
+		eventExistsBefore      = `EXISTS (SELECT 1 FROM "bridge"."events" WHERE "bridge"."events"."id" = "bridge"."transactions"."id" AND "bridge"."events"."type" = ? AND "bridge"."events"."finalized" AND "bridge"."events"."block_timestamp" <= ?)`
+		eventExistsAfter       = `EXISTS (SELECT 1 FROM "bridge"."events" WHERE "bridge"."events"."id" = "bridge"."transactions"."id" AND "bridge"."events"."type" = ? AND "bridge"."events"."finalized" AND "bridge"."events"."block_timestamp" > ?)`
+	)
+
+	databaseClient = databaseClient.Where(`"type" = ?`, schema.BridgeTransactionTypeWithdraw)
+
+	if *query.WithdrawalStatus == schema.BridgeWithdrawalStatusFinalized {
+		return databaseClient.Where(eventExists, schema.BridgeEventTypeWithdrawalFinalized)
+	}
+
+	databaseClient = databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalFinalized)
+
+	switch *query.WithdrawalStatus {
+	case schema.BridgeWithdrawalStatusInitiated:
+		return databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalProved).Where(`"block_timestamp" > ?`, query.ProvableBefore)
+	case schema.BridgeWithdrawalStatusReadyToProve:
+		return databaseClient.Not(eventExists, schema.BridgeEventTypeWithdrawalProved).Where(`"block_timestamp" <= ?`, query.ProvableBefore)
+	case schema.BridgeWithdrawalStatusProven:
+		return databaseClient.Where(eventExistsUnfinalized, schema.BridgeEventTypeWithdrawalProved)
+	case schema.BridgeWithdrawalStatusChallengePeriod:
+		return databaseClient.Where(eventExistsAfter, schema.BridgeEventTypeWithdrawalProved, query.FinalizableBefore)
+	case schema.BridgeWithdrawalStatusReadyToFinalize:
+		return databaseClient.Where(eventExistsBefore, schema.BridgeEventTypeWithdrawalProved, query.FinalizableBefore)
+	default:
+		return databaseClient
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/database"
@@ -274,6 +276,61 @@ func (c *client) FindEpochNodeRewards(ctx context.Context, nodeAddress common.Ad
 	return result, nil
 }
 
+func (c *client) IterateEpochNodeRewards(ctx context.Context, query schema.ExportQuery, iterator func(epoch *schema.Epoch) error) error {
+	// Join the reward records with the epochs for the block timestamps.
+	databaseStatement := c.database.WithContext(ctx).Model(&table.NodeRewardRecord{}).
+		Select(`"node_reward_record".*, "epoch"."block_timestamp"`).
+		Joins(`JOIN "epoch" ON "epoch"."transaction_hash" = "node_reward_record"."transaction_hash"`)
+
+	if query.Node != nil {
+		databaseStatement = databaseStatement.Where(`"node_reward_record"."node_address" = ?`, query.Node.String())
+	}
+
+	if query.Since != nil {
+		databaseStatement = databaseStatement.Where(`"epoch"."block_timestamp" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseStatement = databaseStatement.Where(`"epoch"."block_timestamp" <= ?`, query.Until)
+	}
+
+	rows, err := databaseStatement.Order(`"epoch"."block_timestamp", "node_reward_record"."index"`).Rows()
+	if err != nil {
+		return fmt.Errorf("iterate epoch node rewards: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row struct {
+			table.NodeRewardRecord
+			BlockTimestamp time.Time `gorm:"column:block_timestamp"`
+		}
+
+		if err := c.database.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("scan epoch node reward: %w", err)
+		}
+
+		rewardedNode, err := row.NodeRewardRecord.Export()
+		if err != nil {
+			return fmt.Errorf("export epoch node reward: %w", err)
+		}
+
+		epoch := schema.Epoch{
+			ID:              rewardedNode.EpochID,
+			TransactionHash: rewardedNode.TransactionHash,
+			BlockTimestamp:  row.BlockTimestamp.Unix(),
+			RewardedNodes:   []*schema.RewardedNode{rewardedNode},
+		}
+
+		if err := iterator(&epoch); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (c *client) UpdateEpochsFinalizedByBlockNumber(ctx context.Context, blockNumber uint64) error {
 	return c.database.
 		WithContext(ctx).
@@ -366,6 +423,86 @@ func (c *client) FindEpochTriggers(ctx context.Context, epochID uint64) ([]*sche
 	return data.Export()
 }
 
+func (c *client) SaveNodeScoreBreakdowns(ctx context.Context, breakdowns []*schema.NodeScoreBreakdown) error {
+	if len(breakdowns) == 0 {
+		return nil
+	}
+
+	var data table.NodeScoreBreakdowns
+
+	data.Import(breakdowns)
+
+	// The breakdowns are replaced when the epoch proof is submitted again.
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{
+			{
+				Name: "epoch_id",
+			},
+			{
+				Name: "node_address",
+			},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"valid_request_count",
+			"invalid_request_count",
+			"network_count",
+			"indexer_count",
+			"activity_count",
+			"uptime",
+			"is_latest_version",
+			"valid_score",
+			"invalid_score",
+			"network_score",
+			"indexer_score",
+			"activity_score",
+			"uptime_score",
+			"version_score",
+			"distribution_score",
+			"data_score",
+			"stability_score",
+			"score",
+			"operation_rewards",
+			"updated_at",
+		}),
+	}
+
+	if err := c.database.WithContext(ctx).Clauses(onConflict).CreateInBatches(&data, math.MaxUint8).Error; err != nil {
+		zap.L().Error("insert node score breakdowns", zap.Error(err))
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) FindNodeScoreBreakdown(ctx context.Context, epochID uint64, nodeAddress common.Address) (*schema.NodeScoreBreakdown, error) {
+	var data table.NodeScoreBreakdown
+
+	if err := c.database.WithContext(ctx).Where("epoch_id = ? AND node_address = ?", epochID, nodeAddress).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		zap.L().Error("find node score breakdown", zap.Error(err), zap.Uint64("epochID", epochID), zap.String("nodeAddress", nodeAddress.String()))
+
+		return nil, err
+	}
+
+	return data.Export(), nil
+}
+
+func (c *client) FindNodeScoreBreakdowns(ctx context.Context, epochID uint64) ([]*schema.NodeScoreBreakdown, error) {
+	var data table.NodeScoreBreakdowns
+
+	if err := c.database.WithContext(ctx).Where("epoch_id = ?", epochID).Order("node_address").Find(&data).Error; err != nil {
+		zap.L().Error("find node score breakdowns", zap.Error(err), zap.Uint64("epochID", epochID))
+
+		return nil, err
+	}
+
+	return data.Export(), nil
+}
+
 func (c *client) FindEpochAPYSnapshots(ctx context.Context, query schema.EpochAPYSnapshotQuery) ([]*schema.EpochAPYSnapshot, error) {
 	var data table.EpochAPYSnapshots
 
@@ -422,3 +559,107 @@ func (c *client) FindEpochAPYSnapshotsAverage(ctx context.Context) (decimal.Deci
 
 	return avgAPY, nil
 }
+
+func (c *client) FindDecentralizationSnapshots(ctx context.Context, query schema.DecentralizationSnapshotQuery) ([]*schema.DecentralizationSnapshot, error) {
+	var data table.DecentralizationSnapshots
+
+	databaseStatement := c.database.WithContext(ctx).Model(&table.DecentralizationSnapshot{})
+
+	if query.EpochID != nil {
+		databaseStatement = databaseStatement.Where("epoch_id = ?", *query.EpochID)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("epoch_id < ?", *query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	if err := databaseStatement.Order("epoch_id DESC").Find(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		zap.L().Error("find decentralization snapshots", zap.Error(err), zap.Any("query", query))
+
+		return nil, err
+	}
+
+	return data.Export()
+}
+
+func (c *client) SaveDecentralizationSnapshot(ctx context.Context, snapshot *schema.DecentralizationSnapshot) error {
+	var data table.DecentralizationSnapshot
+	if err := data.Import(snapshot); err != nil {
+		zap.L().Error("import decentralization snapshot", zap.Error(err), zap.Uint64("epochID", snapshot.EpochID))
+
+		return err
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "epoch_id"}},
+		UpdateAll: true,
+	}
+
+	if err := c.database.WithContext(ctx).Clauses(onConflict).Create(&data).Error; err != nil {
+		zap.L().Error("insert decentralization snapshot", zap.Error(err), zap.Uint64("epochID", snapshot.EpochID))
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) FindTVLSnapshots(ctx context.Context, query schema.TVLSnapshotQuery) ([]*schema.TVLSnapshot, error) {
+	var data table.TVLSnapshots
+
+	databaseStatement := c.database.WithContext(ctx).Model(&table.TVLSnapshot{})
+
+	if query.EpochID != nil {
+		databaseStatement = databaseStatement.Where("epoch_id = ?", *query.EpochID)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("epoch_id < ?", *query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	if err := databaseStatement.Order("epoch_id DESC").Find(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		zap.L().Error("find tvl snapshots", zap.Error(err), zap.Any("query", query))
+
+		return nil, err
+	}
+
+	return data.Export()
+}
+
+func (c *client) SaveTVLSnapshot(ctx context.Context, snapshot *schema.TVLSnapshot) error {
+	var data table.TVLSnapshot
+	if err := data.Import(snapshot); err != nil {
+		zap.L().Error("import tvl snapshot", zap.Error(err), zap.Uint64("epochID", snapshot.EpochID))
+
+		return err
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "epoch_id"}},
+		UpdateAll: true,
+	}
+
+	if err := c.database.WithContext(ctx).Clauses(onConflict).Create(&data).Error; err != nil {
+		zap.L().Error("insert tvl snapshot", zap.Error(err), zap.Uint64("epochID", snapshot.EpochID))
+
+		return err
+	}
+
+	return nil
+}
@@ -317,6 +317,99 @@ func (c *client) SaveNodeInvalidResponses(ctx context.Context, nodeInvalidRespon
 	return c.database.WithContext(ctx).CreateInBatches(tNodeInvalidResponses, math.MaxUint8).Error
 }
 
+func (c *client) FindNodeInvalidResponse(ctx context.Context, id uint64) (*schema.NodeInvalidResponse, error) {
+	var nodeInvalidResponse table.NodeInvalidResponse
+
+	if err := c.database.WithContext(ctx).First(&nodeInvalidResponse, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, database.ErrorRowNotFound
+		}
+
+		return nil, err
+	}
+
+	return nodeInvalidResponse.Export(), nil
+}
+
+func (c *client) FindNodeInvalidResponses(ctx context.Context, query schema.NodeInvalidResponsesQuery) ([]*schema.NodeInvalidResponse, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.NodeAddress != nil {
+		databaseStatement = databaseStatement.Where("node = ?", query.NodeAddress)
+	}
+
+	if query.EpochID != nil {
+		databaseStatement = databaseStatement.Where("epoch_id = ?", query.EpochID)
+	}
+
+	if query.Type != nil {
+		databaseStatement = databaseStatement.Where("type = ?", query.Type.String())
+	}
+
+	if query.AppealStatus != nil {
+		databaseStatement = databaseStatement.Where("appeal_status = ?", query.AppealStatus)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", query.Cursor)
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var nodeInvalidResponses table.NodeInvalidResponses
+
+	if err := databaseStatement.Order("id DESC").Find(&nodeInvalidResponses).Error; err != nil {
+		return nil, fmt.Errorf("find node invalid responses: %w", err)
+	}
+
+	return nodeInvalidResponses.Export(), nil
+}
+
+func (c *client) SaveNodeInvalidResponseAppeal(ctx context.Context, id uint64, reason string) error {
+	// An invalid response can only be appealed once.
+	result := c.database.
+		WithContext(ctx).
+		Model((*table.NodeInvalidResponse)(nil)).
+		Where("id = ? AND appeal_status IS NULL", id).
+		Updates(map[string]interface{}{
+			"appeal_status": schema.NodeInvalidResponseAppealStatusPending,
+			"appeal_reason": reason,
+			"appealed_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return database.ErrorRowNotFound
+	}
+
+	return nil
+}
+
+func (c *client) UpdateNodeInvalidResponseAppealStatus(ctx context.Context, id uint64, status schema.NodeInvalidResponseAppealStatus) error {
+	// Only pending appeals can be resolved.
+	result := c.database.
+		WithContext(ctx).
+		Model((*table.NodeInvalidResponse)(nil)).
+		Where("id = ? AND appeal_status = ?", id, schema.NodeInvalidResponseAppealStatusPending).
+		Updates(map[string]interface{}{
+			"appeal_status": status,
+			"resolved_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return database.ErrorRowNotFound
+	}
+
+	return nil
+}
+
 func (c *client) FindNodeCountSnapshots(ctx context.Context) ([]*schema.NodeSnapshot, error) {
 	databaseClient := c.database.WithContext(ctx)
 
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/database/dialer/postgres/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveNodeWebhook inserts the webhook, or replaces the webhook of the Node with the same URL, the ID of the webhook is set.
+func (c *client) SaveNodeWebhook(ctx context.Context, webhook *schema.NodeWebhook) error {
+	var data table.NodeWebhook
+
+	if err := data.Import(webhook); err != nil {
+		zap.L().Error("import node webhook", zap.Error(err), zap.Stringer("node", webhook.NodeAddress))
+
+		return err
+	}
+
+	var existing table.NodeWebhook
+
+	err := c.database.WithContext(ctx).Where("node_address = ? AND url = ?", webhook.NodeAddress, webhook.URL).First(&existing).Error
+
+	switch {
+	case err == nil:
+		data.ID = existing.ID
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		zap.L().Error("find node webhook", zap.Error(err), zap.Stringer("node", webhook.NodeAddress))
+
+		return err
+	}
+
+	if err := c.database.WithContext(ctx).Omit("created_at").Save(&data).Error; err != nil {
+		zap.L().Error("save node webhook", zap.Error(err), zap.Stringer("node", webhook.NodeAddress))
+
+		return err
+	}
+
+	webhook.ID = data.ID
+
+	return nil
+}
+
+func (c *client) FindNodeWebhooks(ctx context.Context, query schema.NodeWebhookQuery) ([]*schema.NodeWebhook, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.ID != nil {
+		databaseStatement = databaseStatement.Where("id = ?", *query.ID)
+	}
+
+	if query.NodeAddress != nil {
+		databaseStatement = databaseStatement.Where("node_address = ?", *query.NodeAddress)
+	}
+
+	var data table.NodeWebhooks
+
+	if err := databaseStatement.Order("id ASC").Find(&data).Error; err != nil {
+		zap.L().Error("find node webhooks", zap.Error(err))
+
+		return nil, err
+	}
+
+	return data.Export()
+}
+
+// DeleteNodeWebhook deletes the webhook and its deliveries.
+func (c *client) DeleteNodeWebhook(ctx context.Context, id uint64) error {
+	return c.database.WithContext(ctx).Transaction(func(databaseStatement *gorm.DB) error {
+		if err := databaseStatement.Where("webhook_id = ?", id).Delete(&table.NodeWebhookDelivery{}).Error; err != nil {
+			zap.L().Error("delete node webhook deliveries", zap.Error(err), zap.Uint64("id", id))
+
+			return err
+		}
+
+		result := databaseStatement.Where("id = ?", id).Delete(&table.NodeWebhook{})
+		if result.Error != nil {
+			zap.L().Error("delete node webhook", zap.Error(result.Error), zap.Uint64("id", id))
+
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return database.ErrorRowNotFound
+		}
+
+		return nil
+	})
+}
+
+func (c *client) SaveNodeWebhookDeliveries(ctx context.Context, deliveries []*schema.NodeWebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var data table.NodeWebhookDeliveries
+
+	data.Import(deliveries)
+
+	// The events already delivered to a webhook with the same idempotency key are skipped.
+	if err := c.database.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&data).Error; err != nil {
+		zap.L().Error("insert node webhook deliveries", zap.Error(err), zap.Int("count", len(deliveries)))
+
+		return err
+	}
+
+	for index := range deliveries {
+		deliveries[index].ID = data[index].ID
+	}
+
+	return nil
+}
+
+// UpdateNodeWebhookDelivery saves the result of an attempt of the delivery.
+func (c *client) UpdateNodeWebhookDelivery(ctx context.Context, delivery *schema.NodeWebhookDelivery) error {
+	var data table.NodeWebhookDelivery
+
+	data.Import(delivery)
+
+	if err := c.database.WithContext(ctx).Omit("created_at").Save(&data).Error; err != nil {
+		zap.L().Error("update node webhook delivery", zap.Error(err), zap.Uint64("id", delivery.ID))
+
+		return err
+	}
+
+	return nil
+}
+
+// FindNodeWebhookDeliveries returns the latest deliveries first, or the earliest ones first if filtered by DueBefore.
+func (c *client) FindNodeWebhookDeliveries(ctx context.Context, query schema.NodeWebhookDeliveryQuery) ([]*schema.NodeWebhookDelivery, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.WebhookID != nil {
+		databaseStatement = databaseStatement.Where("webhook_id = ?", *query.WebhookID)
+	}
+
+	if query.Status != nil {
+		databaseStatement = databaseStatement.Where("status = ?", *query.Status)
+	}
+
+	if query.Cursor != nil {
+		databaseStatement = databaseStatement.Where("id < ?", *query.Cursor)
+	}
+
+	if query.DueBefore != nil {
+		databaseStatement = databaseStatement.Where("next_attempt_at <= ?", *query.DueBefore).Order("next_attempt_at ASC, id ASC")
+	} else {
+		databaseStatement = databaseStatement.Order("id DESC")
+	}
+
+	if query.Limit != nil {
+		databaseStatement = databaseStatement.Limit(*query.Limit)
+	}
+
+	var data table.NodeWebhookDeliveries
+
+	if err := databaseStatement.Find(&data).Error; err != nil {
+		zap.L().Error("find node webhook deliveries", zap.Error(err))
+
+		return nil, err
+	}
+
+	return data.Export(), nil
+}
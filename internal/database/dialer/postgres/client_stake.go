@@ -149,6 +149,52 @@ func (c *client) FindStakeTransactions(ctx context.Context, query schema.StakeTr
 	return results, nil
 }
 
+func (c *client) IterateStakeTransactions(ctx context.Context, query schema.ExportQuery, iterator func(stakeTransaction *schema.StakeTransaction) error) error {
+	databaseClient := c.database.WithContext(ctx).Model(&table.StakeTransaction{})
+
+	if query.Staker != nil {
+		databaseClient = databaseClient.Where(`"user" = ?`, query.Staker.String())
+	}
+
+	if query.Node != nil {
+		databaseClient = databaseClient.Where(`"node" = ?`, query.Node.String())
+	}
+
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`"block_timestamp" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`"block_timestamp" <= ?`, query.Until)
+	}
+
+	rows, err := databaseClient.Order(`"block_timestamp", "block_number", "transaction_index"`).Rows()
+	if err != nil {
+		return fmt.Errorf("iterate stake transactions: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row table.StakeTransaction
+
+		if err := c.database.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("scan stake transaction: %w", err)
+		}
+
+		stakeTransaction, err := row.Export()
+		if err != nil {
+			return fmt.Errorf("export stake transaction: %w", err)
+		}
+
+		if err := iterator(stakeTransaction); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (c *client) FindStakeEvents(ctx context.Context, query schema.StakeEventsQuery) ([]*schema.StakeEvent, error) {
 	databaseClient := c.database.WithContext(ctx)
 
@@ -685,6 +731,48 @@ func (c *client) FindStakerProfitSnapshots(ctx context.Context, query schema.Sta
 	return results, nil
 }
 
+func (c *client) IterateStakerProfitSnapshots(ctx context.Context, query schema.ExportQuery, iterator func(stakerProfitSnapshot *schema.StakerProfitSnapshot) error) error {
+	databaseClient := c.database.WithContext(ctx).Model(&table.StakerProfitSnapshot{})
+
+	if query.Staker != nil {
+		databaseClient = databaseClient.Where(`"owner_address" = ?`, query.Staker)
+	}
+
+	if query.Since != nil {
+		databaseClient = databaseClient.Where(`"date" >= ?`, query.Since)
+	}
+
+	if query.Until != nil {
+		databaseClient = databaseClient.Where(`"date" <= ?`, query.Until)
+	}
+
+	rows, err := databaseClient.Order(`"epoch_id"`).Rows()
+	if err != nil {
+		return fmt.Errorf("iterate staker profit snapshots: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var row table.StakerProfitSnapshot
+
+		if err := c.database.ScanRows(rows, &row); err != nil {
+			return fmt.Errorf("scan staker profit snapshot: %w", err)
+		}
+
+		stakerProfitSnapshot, err := row.Export()
+		if err != nil {
+			return fmt.Errorf("export staker profit snapshot: %w", err)
+		}
+
+		if err := iterator(stakerProfitSnapshot); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (c *client) SaveStakerProfitSnapshots(ctx context.Context, snapshots []*schema.StakerProfitSnapshot) error {
 	var value table.StakerProfitSnapshots
 
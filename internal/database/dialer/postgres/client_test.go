@@ -6,13 +6,19 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/adrianbrad/psqldocker"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/orlangure/gnomock"
+	mysqlmock "github.com/orlangure/gnomock/preset/mysql"
+	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/database/dialer"
 	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,6 +36,59 @@ func TestClient(t *testing.T) {
 			nodeCreated: &schema.Node{
 				ID:      big.NewInt(1),
 				Address: common.HexToAddress("0xc98D64DA73a6616c42117b582e832812e7B8D57F"),
+				Location: []*schema.NodeLocation{
+					{Country: "US", ASNLookedUp: true},
+				},
+				Stream: json.RawMessage(`
+				{
+				   "Driver":"kafka",
+				   "Enable":false,
+				   "Topic":"rss3.node.feeds",
+				   "URI":"localhost:9092"
+				}`),
+				Config: json.RawMessage(`
+				{
+				   "Decentralized":[
+					  {
+						 "Endpoint":"https://rpc.ankr.com/eth",
+						 "IPFSGateways":null,
+						 "Network":"ethereum",
+						 "Parameters":{
+							"block_number_start":null,
+							"block_number_target":null
+						 },
+						 "Worker":"fallback"
+					  }
+				   ],
+				   "Federated":null,
+				   "RSS":[
+					  {
+						 "Endpoint":"https://rsshub.app/",
+						 "IPFSGateways":null,
+						 "Network":"rss",
+						 "Parameters":{
+							"authentication":{
+							   "access_code":null,
+							   "access_key":null,
+							   "password":null,
+							   "username":null
+							}
+						 },
+						 "Worker":"unknown"
+					  }
+				   ]
+				}`),
+			},
+		},
+		{
+			name:   "mysql",
+			driver: database.DriverMySQL,
+			nodeCreated: &schema.Node{
+				ID:      big.NewInt(1),
+				Address: common.HexToAddress("0xc98D64DA73a6616c42117b582e832812e7B8D57F"),
+				Location: []*schema.NodeLocation{
+					{Country: "US", ASNLookedUp: true},
+				},
 				Stream: json.RawMessage(`
 				{
 				   "Driver":"kafka",
@@ -80,20 +139,20 @@ func TestClient(t *testing.T) {
 			t.Parallel()
 
 			var (
-				container      *psqldocker.Container
+				closeContainer func() error
 				dataSourceName string
 				err            error
 			)
 
 			for {
-				container, dataSourceName, err = createContainer(context.Background(), testcase.driver)
+				closeContainer, dataSourceName, err = createContainer(context.Background(), testcase.driver)
 				if err == nil {
 					break
 				}
 			}
 
 			t.Cleanup(func() {
-				require.NoError(t, container.Close())
+				require.NoError(t, closeContainer())
 			})
 
 			// Dial the database.
@@ -116,6 +175,10 @@ func TestClient(t *testing.T) {
 			require.NoError(t, err)
 			require.NotEmpty(t, nodeFound.Address)
 
+			// The locations looked up with the autonomous systems are kept.
+			require.Len(t, nodeFound.Location, 1)
+			require.True(t, nodeFound.Location[0].ASNLookedUp)
+
 			// Find nodes.
 			nodesFound, err := client.FindNodes(context.Background(), schema.FindNodesQuery{
 				NodeAddresses: []common.Address{testcase.nodeCreated.Address},
@@ -131,11 +194,360 @@ func TestClient(t *testing.T) {
 			nodeFound, err = client.FindNode(context.Background(), testcase.nodeCreated.Address)
 			require.NoError(t, err)
 			require.Equal(t, testcase.nodeCreated.Stream, nodeFound.Stream)
+
+			// Save node invalid response.
+			require.NoError(t, client.SaveNodeInvalidResponses(context.Background(), []*schema.NodeInvalidResponse{
+				{
+					EpochID:          1,
+					Type:             schema.NodeInvalidResponseTypeInconsistent,
+					Request:          "https://node.example.com/decentralized/tx/0x1",
+					VerifierNodes:    []common.Address{common.HexToAddress("0x0000000000000000000000000000000000000001")},
+					VerifierResponse: json.RawMessage(`{}`),
+					Node:             testcase.nodeCreated.Address,
+					Response:         json.RawMessage(`{}`),
+				},
+			}))
+
+			// Find node invalid responses.
+			invalidResponsesFound, err := client.FindNodeInvalidResponses(context.Background(), schema.NodeInvalidResponsesQuery{
+				NodeAddress: &testcase.nodeCreated.Address,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(invalidResponsesFound))
+			require.Nil(t, invalidResponsesFound[0].Appeal)
+
+			// Appeal node invalid response.
+			invalidResponseID := invalidResponsesFound[0].ID
+			require.NoError(t, client.SaveNodeInvalidResponseAppeal(context.Background(), invalidResponseID, "reason"))
+			require.ErrorIs(t, client.SaveNodeInvalidResponseAppeal(context.Background(), invalidResponseID, "reason"), database.ErrorRowNotFound)
+
+			// Resolve node invalid response appeal.
+			require.NoError(t, client.UpdateNodeInvalidResponseAppealStatus(context.Background(), invalidResponseID, schema.NodeInvalidResponseAppealStatusUpheld))
+			require.ErrorIs(t, client.UpdateNodeInvalidResponseAppealStatus(context.Background(), invalidResponseID, schema.NodeInvalidResponseAppealStatusRejected), database.ErrorRowNotFound)
+
+			invalidResponseFound, err := client.FindNodeInvalidResponse(context.Background(), invalidResponseID)
+			require.NoError(t, err)
+			require.NotNil(t, invalidResponseFound.Appeal)
+			require.Equal(t, schema.NodeInvalidResponseAppealStatusUpheld, invalidResponseFound.Appeal.Status)
+
+			// Save node score breakdowns.
+			scoreBreakdown := &schema.NodeScoreBreakdown{
+				EpochID:           1,
+				NodeAddress:       testcase.nodeCreated.Address,
+				ValidRequestCount: 100,
+				Score:             0.5,
+				OperationRewards:  decimal.RequireFromString("1000000000000000000"),
+			}
+			require.NoError(t, client.SaveNodeScoreBreakdowns(context.Background(), []*schema.NodeScoreBreakdown{scoreBreakdown}))
+
+			// Replace node score breakdowns.
+			scoreBreakdown.Score = 0.7
+			require.NoError(t, client.SaveNodeScoreBreakdowns(context.Background(), []*schema.NodeScoreBreakdown{scoreBreakdown}))
+
+			// Find node score breakdown.
+			scoreBreakdownFound, err := client.FindNodeScoreBreakdown(context.Background(), 1, testcase.nodeCreated.Address)
+			require.NoError(t, err)
+			require.Equal(t, scoreBreakdown.Score, scoreBreakdownFound.Score)
+			require.True(t, scoreBreakdown.OperationRewards.Equal(scoreBreakdownFound.OperationRewards))
+
+			_, err = client.FindNodeScoreBreakdown(context.Background(), 2, testcase.nodeCreated.Address)
+			require.ErrorIs(t, err, database.ErrorRowNotFound)
+
+			// Save a tx journal.
+			txJournal := &schema.TxJournal{
+				Service:           "settler",
+				ChainID:           1,
+				From:              testcase.nodeCreated.Address,
+				Nonce:             1,
+				To:                &testcase.nodeCreated.Address,
+				Value:             big.NewInt(0),
+				GasLimit:          21000,
+				GasTipCap:         big.NewInt(1),
+				GasFeeCap:         big.NewInt(2),
+				TransactionHashes: []common.Hash{common.HexToHash("0x1")},
+				RawTransaction:    []byte{0x1},
+				Status:            schema.TxJournalStatusPending,
+			}
+			require.NoError(t, client.SaveTxJournal(context.Background(), txJournal))
+			require.NotZero(t, txJournal.ID)
+
+			// Find pending tx journals.
+			txJournals, err := client.FindTxJournals(context.Background(), schema.TxJournalsQuery{Status: lo.ToPtr(schema.TxJournalStatusPending)})
+			require.NoError(t, err)
+			require.Len(t, txJournals, 1)
+			require.Equal(t, txJournal.TransactionHashes, txJournals[0].TransactionHashes)
+
+			// Confirm the tx journal.
+			txJournal.TransactionHashes = append(txJournal.TransactionHashes, common.HexToHash("0x2"))
+			txJournal.Status = schema.TxJournalStatusConfirmed
+			txJournal.Receipt = &schema.TxJournalReceipt{TransactionHash: common.HexToHash("0x2"), BlockNumber: 10, Status: 1}
+			require.NoError(t, client.SaveTxJournal(context.Background(), txJournal))
+
+			txJournals, err = client.FindTxJournals(context.Background(), schema.TxJournalsQuery{Status: lo.ToPtr(schema.TxJournalStatusPending)})
+			require.NoError(t, err)
+			require.Len(t, txJournals, 0)
+
+			txJournals, err = client.FindTxJournals(context.Background(), schema.TxJournalsQuery{Service: lo.ToPtr("settler")})
+			require.NoError(t, err)
+			require.Len(t, txJournals, 1)
+			require.Equal(t, txJournal.Receipt, txJournals[0].Receipt)
+			require.Len(t, txJournals[0].TransactionHashes, 2)
+
+			// Save the recent blocks.
+			for blockNumber := uint64(1); blockNumber <= 3; blockNumber++ {
+				require.NoError(t, client.SaveBlock(context.Background(), &schema.Block{
+					ChainID:     1,
+					BlockNumber: blockNumber,
+					BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber)),
+				}))
+			}
+
+			block, err := client.FindBlock(context.Background(), 1, 2)
+			require.NoError(t, err)
+			require.Equal(t, common.BigToHash(big.NewInt(2)), block.BlockHash)
+
+			// Roll back the block 3 and delete the blocks before the block 2.
+			require.NoError(t, client.RollbackBlock(context.Background(), 1, 3))
+			require.NoError(t, client.DeleteBlocksBeforeBlockNumber(context.Background(), 1, 2))
+
+			for _, blockNumber := range []uint64{1, 3} {
+				_, err = client.FindBlock(context.Background(), 1, blockNumber)
+				require.ErrorIs(t, err, database.ErrorRowNotFound)
+			}
+
+			// Save a decentralization snapshot twice, the second one replaces the first one.
+			decentralizationSnapshot := schema.DecentralizationSnapshot{
+				EpochID:             1,
+				Date:                time.Now(),
+				Nodes:               2,
+				StakingPoolTokens:   decimal.NewFromInt(100),
+				NakamotoCoefficient: 1,
+				Gini:                decimal.RequireFromString("0.5"),
+				Countries:           []*schema.DecentralizationDistribution{{Name: "Germany", Nodes: 2, StakingPoolTokens: decimal.NewFromInt(100)}},
+				NetworkProviders:    []*schema.DecentralizationDistribution{},
+				Workers:             []*schema.DecentralizationWorkerCoverage{{Network: "ethereum", Name: "core", Nodes: 2}},
+			}
+
+			require.NoError(t, client.SaveDecentralizationSnapshot(context.Background(), &decentralizationSnapshot))
+
+			decentralizationSnapshot.FullNodes = 1
+			require.NoError(t, client.SaveDecentralizationSnapshot(context.Background(), &decentralizationSnapshot))
+
+			decentralizationSnapshots, err := client.FindDecentralizationSnapshots(context.Background(), schema.DecentralizationSnapshotQuery{EpochID: lo.ToPtr(uint64(1))})
+			require.NoError(t, err)
+			require.Len(t, decentralizationSnapshots, 1)
+			require.Equal(t, uint64(1), decentralizationSnapshots[0].FullNodes)
+			require.Len(t, decentralizationSnapshots[0].Countries, 1)
+			require.Equal(t, "100", decentralizationSnapshots[0].Countries[0].StakingPoolTokens.String())
+
+			// Register a webhook twice, the second one replaces the first one.
+			nodeWebhook := schema.NodeWebhook{
+				NodeAddress: common.HexToAddress("0xc5999271a6e7a1b1e1e5b3b4b6e6d6c6b6a6e6d6"),
+				URL:         "https://example.com/webhook",
+				Secret:      "secret",
+			}
+
+			require.NoError(t, client.SaveNodeWebhook(context.Background(), &nodeWebhook))
+
+			nodeWebhookID := nodeWebhook.ID
+			nodeWebhook.ID = 0
+			nodeWebhook.Events = []schema.NodeWebhookEventType{schema.NodeWebhookEventTypeSlashed}
+
+			require.NoError(t, client.SaveNodeWebhook(context.Background(), &nodeWebhook))
+			require.Equal(t, nodeWebhookID, nodeWebhook.ID)
+
+			nodeWebhooks, err := client.FindNodeWebhooks(context.Background(), schema.NodeWebhookQuery{NodeAddress: lo.ToPtr(nodeWebhook.NodeAddress)})
+			require.NoError(t, err)
+			require.Len(t, nodeWebhooks, 1)
+			require.Equal(t, nodeWebhook.Events, nodeWebhooks[0].Events)
+
+			// Queue a delivery and record a failed attempt.
+			nodeWebhookDeliveries := []*schema.NodeWebhookDelivery{
+				{
+					WebhookID:     nodeWebhook.ID,
+					NodeAddress:   nodeWebhook.NodeAddress,
+					EventType:     schema.NodeWebhookEventTypeSlashed,
+					Data:          json.RawMessage(`{"block_number":1}`),
+					Status:        schema.NodeWebhookDeliveryStatusPending,
+					NextAttemptAt: time.Now().Add(-time.Second),
+				},
+			}
+
+			require.NoError(t, client.SaveNodeWebhookDeliveries(context.Background(), nodeWebhookDeliveries))
+
+			dueDeliveries, err := client.FindNodeWebhookDeliveries(context.Background(), schema.NodeWebhookDeliveryQuery{
+				Status:    lo.ToPtr(schema.NodeWebhookDeliveryStatusPending),
+				DueBefore: lo.ToPtr(time.Now()),
+			})
+			require.NoError(t, err)
+			require.Len(t, dueDeliveries, 1)
+
+			dueDeliveries[0].Attempts++
+			dueDeliveries[0].ResponseStatus = lo.ToPtr(500)
+			dueDeliveries[0].NextAttemptAt = time.Now().Add(time.Hour)
+
+			require.NoError(t, client.UpdateNodeWebhookDelivery(context.Background(), dueDeliveries[0]))
+
+			dueDeliveries, err = client.FindNodeWebhookDeliveries(context.Background(), schema.NodeWebhookDeliveryQuery{
+				Status:    lo.ToPtr(schema.NodeWebhookDeliveryStatusPending),
+				DueBefore: lo.ToPtr(time.Now()),
+			})
+			require.NoError(t, err)
+			require.Len(t, dueDeliveries, 0)
+
+			// An event is queued once for the same idempotency key.
+			for range 2 {
+				require.NoError(t, client.SaveNodeWebhookDeliveries(context.Background(), []*schema.NodeWebhookDelivery{
+					{
+						WebhookID:      nodeWebhook.ID,
+						NodeAddress:    nodeWebhook.NodeAddress,
+						EventType:      schema.NodeWebhookEventTypeSlashed,
+						IdempotencyKey: lo.ToPtr("node.slashed:0x01:0"),
+						Data:           json.RawMessage(`{"block_number":2}`),
+						Status:         schema.NodeWebhookDeliveryStatusPending,
+						NextAttemptAt:  time.Now(),
+					},
+				}))
+			}
+
+			nodeWebhookDeliveryLog, err := client.FindNodeWebhookDeliveries(context.Background(), schema.NodeWebhookDeliveryQuery{WebhookID: lo.ToPtr(nodeWebhook.ID)})
+			require.NoError(t, err)
+			require.Len(t, nodeWebhookDeliveryLog, 2)
+
+			// Removing the webhook removes its deliveries.
+			require.NoError(t, client.DeleteNodeWebhook(context.Background(), nodeWebhook.ID))
+			require.ErrorIs(t, client.DeleteNodeWebhook(context.Background(), nodeWebhook.ID), database.ErrorRowNotFound)
+
+			nodeWebhookDeliveryLog, err = client.FindNodeWebhookDeliveries(context.Background(), schema.NodeWebhookDeliveryQuery{WebhookID: lo.ToPtr(nodeWebhook.ID)})
+			require.NoError(t, err)
+			require.Len(t, nodeWebhookDeliveryLog, 0)
+
+			// A withdrawal proven a day ago is in the challenge period.
+			withdrawalID := common.HexToHash("0x8b6b1c7e9f0f3c9d3c1e2a4f5b6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d")
+			withdrawalInitiatedAt := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+
+			require.NoError(t, client.SaveBridgeTransaction(context.Background(), &schema.BridgeTransaction{
+				ID:             withdrawalID,
+				Type:           schema.BridgeTransactionTypeWithdraw,
+				Sender:         common.HexToAddress("0x1"),
+				Receiver:       common.HexToAddress("0x1"),
+				TokenValue:     big.NewInt(1),
+				ChainID:        2331,
+				BlockTimestamp: withdrawalInitiatedAt,
+				BlockNumber:    1,
+				Finalized:      true,
+			}))
+
+			require.NoError(t, client.SaveBridgeEvent(context.Background(), &schema.BridgeEvent{
+				ID:              withdrawalID,
+				Type:            schema.BridgeEventTypeWithdrawalProved,
+				TransactionHash: common.HexToHash("0x2"),
+				ChainID:         1,
+				BlockHash:       common.HexToHash("0x3"),
+				BlockNumber:     big.NewInt(1),
+				BlockTimestamp:  withdrawalInitiatedAt.Add(24 * time.Hour),
+				Finalized:       true,
+			}))
+
+			for status, expected := range map[schema.BridgeWithdrawalStatus]int{
+				schema.BridgeWithdrawalStatusReadyToProve:    0,
+				schema.BridgeWithdrawalStatusProven:          0,
+				schema.BridgeWithdrawalStatusChallengePeriod: 1,
+				schema.BridgeWithdrawalStatusReadyToFinalize: 0,
+				schema.BridgeWithdrawalStatusFinalized:       0,
+			} {
+				bridgeTransactions, err := client.FindBridgeTransactions(context.Background(), schema.BridgeTransactionsQuery{
+					WithdrawalStatus:  lo.ToPtr(status),
+					ProvableBefore:    time.Now().Add(-time.Hour),
+					FinalizableBefore: time.Now().Add(-7 * 24 * time.Hour),
+				})
+				require.NoError(t, err)
+				require.Len(t, bridgeTransactions, expected, status)
+			}
+
+			// Iterate the records of an export.
+			var exportedBridgeTransactions []*schema.BridgeTransaction
+
+			require.NoError(t, client.IterateBridgeTransactions(context.Background(), schema.ExportQuery{
+				Address: lo.ToPtr(common.HexToAddress("0x1")),
+				Since:   lo.ToPtr(withdrawalInitiatedAt.Add(-time.Minute)),
+			}, func(bridgeTransaction *schema.BridgeTransaction) error {
+				exportedBridgeTransactions = append(exportedBridgeTransactions, bridgeTransaction)
+
+				return nil
+			}))
+			require.Len(t, exportedBridgeTransactions, 1)
+			require.Equal(t, withdrawalID, exportedBridgeTransactions[0].ID)
+
+			stakeTransaction := schema.StakeTransaction{
+				ID:             common.HexToHash("0x9"),
+				Type:           schema.StakeTransactionTypeStake,
+				User:           common.HexToAddress("0x1"),
+				Node:           common.HexToAddress("0x2"),
+				Value:          big.NewInt(100),
+				ChipIDs:        []*big.Int{big.NewInt(1)},
+				BlockTimestamp: withdrawalInitiatedAt,
+				BlockNumber:    1,
+			}
+			require.NoError(t, client.SaveStakeTransaction(context.Background(), &stakeTransaction))
+
+			var exportedStakeTransactions int
+
+			require.NoError(t, client.IterateStakeTransactions(context.Background(), schema.ExportQuery{
+				Node:  lo.ToPtr(common.HexToAddress("0x2")),
+				Until: lo.ToPtr(withdrawalInitiatedAt),
+			}, func(transaction *schema.StakeTransaction) error {
+				require.Equal(t, stakeTransaction.ID, transaction.ID)
+				exportedStakeTransactions++
+
+				return nil
+			}))
+			require.Equal(t, 1, exportedStakeTransactions)
+
+			require.NoError(t, client.IterateEpochNodeRewards(context.Background(), schema.ExportQuery{
+				Node: lo.ToPtr(common.HexToAddress("0x2")),
+			}, func(*schema.Epoch) error {
+				return fmt.Errorf("unexpected epoch")
+			}))
+
+			// Rewinding the blocks of the RSS3 chain deletes the finalized rows indexed from them.
+			stakeTransaction.Finalized = true
+			require.NoError(t, client.SaveStakeTransaction(context.Background(), &stakeTransaction))
+			require.NoError(t, client.RewindBlocks(context.Background(), l2.ChainIDTestnet, 0))
+
+			require.NoError(t, client.IterateStakeTransactions(context.Background(), schema.ExportQuery{
+				Node: lo.ToPtr(common.HexToAddress("0x2")),
+			}, func(*schema.StakeTransaction) error {
+				return fmt.Errorf("unexpected stake transaction")
+			}))
+
+			require.NoError(t, client.IterateBridgeTransactions(context.Background(), schema.ExportQuery{
+				Address: lo.ToPtr(common.HexToAddress("0x1")),
+			}, func(*schema.BridgeTransaction) error {
+				return fmt.Errorf("unexpected bridge transaction")
+			}))
+
+			// Save and find the tvl snapshots.
+			require.NoError(t, client.SaveTVLSnapshot(context.Background(), &schema.TVLSnapshot{
+				EpochID: 1,
+				Date:    time.Unix(1700000000, 0),
+				TVL:     decimal.NewFromInt(2500),
+				Tokens: []*schema.TVLToken{
+					{ChainID: 1, Address: common.HexToAddress("0x1"), Balance: decimal.New(1, 18), Price: decimal.NewFromInt(2500), Value: decimal.NewFromInt(2500)},
+				},
+			}))
+
+			tvlSnapshots, err := client.FindTVLSnapshots(context.Background(), schema.TVLSnapshotQuery{Limit: lo.ToPtr(1)})
+			require.NoError(t, err)
+			require.Len(t, tvlSnapshots, 1)
+			require.Equal(t, "2500", tvlSnapshots[0].TVL.String())
+			require.Len(t, tvlSnapshots[0].Tokens, 1)
+			require.Equal(t, common.HexToAddress("0x1"), tvlSnapshots[0].Tokens[0].Address)
 		})
 	}
 }
 
-func createContainer(_ context.Context, driver database.Driver) (container *psqldocker.Container, dataSourceName string, err error) {
+func createContainer(_ context.Context, driver database.Driver) (closeContainer func() error, dataSourceName string, err error) {
 	switch driver {
 	case database.DriverPostgres:
 		c, err := psqldocker.NewContainer(
@@ -147,7 +559,17 @@ func createContainer(_ context.Context, driver database.Driver) (container *psql
 			return nil, "", fmt.Errorf("create psql container: %w", err)
 		}
 
-		return c, formatContainerURI(c), nil
+		return c.Close, formatContainerURI(c), nil
+	case database.DriverMySQL:
+		c, err := gnomock.Start(mysqlmock.Preset(
+			mysqlmock.WithUser("user", "password"),
+			mysqlmock.WithDatabase("test"),
+		))
+		if err != nil {
+			return nil, "", fmt.Errorf("create mysql container: %w", err)
+		}
+
+		return func() error { return gnomock.Stop(c) }, formatMySQLContainerURI(c), nil
 	default:
 		return nil, "", fmt.Errorf("unsupported driver: %s", driver)
 	}
@@ -161,3 +583,11 @@ func formatContainerURI(container *psqldocker.Container) string {
 		"test",
 	)
 }
+
+func formatMySQLContainerURI(container *gnomock.Container) string {
+	return fmt.Sprintf(
+		"user:password@tcp(%s)/%s?parseTime=true&loc=UTC",
+		container.DefaultAddress(),
+		"test",
+	)
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/rss3-network/global-indexer/internal/database/dialer/postgres/table"
+	"github.com/rss3-network/global-indexer/schema"
+	"go.uber.org/zap"
+)
+
+// SaveTxJournal inserts the journal if its ID is 0, or updates it otherwise, the ID of an inserted journal is set.
+func (c *client) SaveTxJournal(ctx context.Context, journal *schema.TxJournal) error {
+	var data table.TxJournal
+
+	data.Import(journal)
+
+	if err := c.database.WithContext(ctx).Omit("created_at").Save(&data).Error; err != nil {
+		zap.L().Error("save tx journal", zap.Error(err), zap.Uint64("id", journal.ID))
+
+		return err
+	}
+
+	journal.ID = data.ID
+
+	return nil
+}
+
+func (c *client) FindTxJournals(ctx context.Context, query schema.TxJournalsQuery) ([]*schema.TxJournal, error) {
+	databaseStatement := c.database.WithContext(ctx)
+
+	if query.ChainID != nil {
+		databaseStatement = databaseStatement.Where("chain_id = ?", *query.ChainID)
+	}
+
+	if query.Service != nil {
+		databaseStatement = databaseStatement.Where("service = ?", *query.Service)
+	}
+
+	if query.Status != nil {
+		databaseStatement = databaseStatement.Where("status = ?", *query.Status)
+	}
+
+	var data table.TxJournals
+
+	if err := databaseStatement.Order("id ASC").Find(&data).Error; err != nil {
+		zap.L().Error("find tx journals", zap.Error(err))
+
+		return nil, err
+	}
+
+	return data.Export(), nil
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table "node_invalid_response"
+    add column if not exists appeal_status text,
+    add column if not exists appeal_reason text,
+    add column if not exists appealed_at   timestamp with time zone,
+    add column if not exists resolved_at   timestamp with time zone;
+
+create index if not exists "idx_node_invalid_response_appeal_status" on "node_invalid_response" (appeal_status);
+
+create index if not exists "idx_node_invalid_response_node_id" on "node_invalid_response" (node asc, id desc);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists "idx_node_invalid_response_node_id";
+drop index if exists "idx_node_invalid_response_appeal_status";
+
+alter table "node_invalid_response"
+    drop column if exists appeal_status,
+    drop column if exists appeal_reason,
+    drop column if exists appealed_at,
+    drop column if exists resolved_at;
+-- +goose StatementEnd
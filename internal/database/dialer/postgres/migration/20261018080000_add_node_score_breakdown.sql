@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists "node_score_breakdown"
+(
+    epoch_id              bigint                                 not null,
+    node_address          bytea                                  not null,
+    valid_request_count   bigint                                 not null,
+    invalid_request_count bigint                                 not null,
+    network_count         bigint                                 not null,
+    indexer_count         bigint                                 not null,
+    activity_count        bigint                                 not null,
+    uptime                bigint                                 not null,
+    is_latest_version     boolean                                not null,
+    valid_score           double precision                       not null,
+    invalid_score         double precision                       not null,
+    network_score         double precision                       not null,
+    indexer_score         double precision                       not null,
+    activity_score        double precision                       not null,
+    uptime_score          double precision                       not null,
+    version_score         double precision                       not null,
+    distribution_score    double precision                       not null,
+    data_score            double precision                       not null,
+    stability_score       double precision                       not null,
+    score                 double precision                       not null,
+    operation_rewards     numeric                                not null,
+    created_at            timestamp with time zone default now() not null,
+    updated_at            timestamp with time zone default now() not null,
+    constraint pk_node_score_breakdown primary key (epoch_id, node_address)
+);
+
+create index if not exists "idx_node_score_breakdown_node_address" on "node_score_breakdown" (node_address, epoch_id desc);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "node_score_breakdown";
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+create sequence if not exists "tx_journal_id_seq" minvalue 0;
+
+create table if not exists "tx_journal"
+(
+    id                       bigint                   default nextval('"tx_journal_id_seq"'::REGCLASS) not null,
+    service                  text                                   not null,
+    chain_id                 bigint                                 not null,
+    from_address             bytea                                  not null,
+    nonce                    bigint                                 not null,
+    to_address               bytea,
+    data                     bytea,
+    value                    numeric                                not null,
+    gas_limit                bigint                                 not null,
+    gas_tip_cap              numeric                                not null,
+    gas_fee_cap              numeric                                not null,
+    transaction_hashes       bytea[]                                not null,
+    raw_transaction          bytea                                  not null,
+    status                   text                                   not null,
+    receipt_transaction_hash bytea,
+    receipt_block_number     bigint,
+    receipt_status           bigint,
+    receipt_gas_used         bigint,
+    created_at               timestamp with time zone default now() not null,
+    updated_at               timestamp with time zone default now() not null,
+    constraint pk_tx_journal primary key (id)
+);
+
+create index if not exists "idx_tx_journal_status" on "tx_journal" (chain_id, service, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "tx_journal";
+
+drop sequence if exists "tx_journal_id_seq";
+-- +goose StatementEnd
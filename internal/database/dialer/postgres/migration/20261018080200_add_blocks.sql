@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists "blocks"
+(
+    chain_id     bigint                                 not null,
+    block_number bigint                                 not null,
+    block_hash   text                                   not null,
+    parent_hash  text                                   not null,
+    created_at   timestamp with time zone default now() not null,
+    updated_at   timestamp with time zone default now() not null,
+    constraint pk_blocks primary key (chain_id, block_number)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "blocks";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists "epoch"."decentralization_snapshots"
+(
+    epoch_id             bigint                                 not null,
+    date                 timestamp with time zone               not null,
+    nodes                bigint                                 not null,
+    staking_pool_tokens  numeric                                not null,
+    nakamoto_coefficient bigint                                 not null,
+    gini                 numeric                                not null,
+    countries            jsonb   default '[]'::jsonb            not null,
+    network_providers    jsonb   default '[]'::jsonb            not null,
+    workers              jsonb   default '[]'::jsonb            not null,
+    full_nodes           bigint  default 0                      not null,
+    created_at           timestamp with time zone default now() not null,
+    updated_at           timestamp with time zone default now() not null,
+    constraint pk_epoch_decentralization_snapshots primary key (epoch_id)
+);
+
+create index if not exists "idx_epoch_decentralization_snapshots_date" on "epoch"."decentralization_snapshots" (date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "epoch"."decentralization_snapshots";
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+create sequence if not exists "node_webhooks_id_seq" minvalue 0;
+
+create table if not exists "node_webhooks"
+(
+    id           bigint                   default nextval('"node_webhooks_id_seq"'::REGCLASS) not null,
+    node_address bytea                                  not null,
+    url          text                                   not null,
+    secret       text                                   not null,
+    events       jsonb                    default '[]'::jsonb not null,
+    created_at   timestamp with time zone default now() not null,
+    updated_at   timestamp with time zone default now() not null,
+    constraint pk_node_webhooks primary key (id),
+    constraint idx_node_webhooks_node_address_url unique (node_address, url)
+);
+
+create sequence if not exists "node_webhook_deliveries_id_seq" minvalue 0;
+
+create table if not exists "node_webhook_deliveries"
+(
+    id              bigint                   default nextval('"node_webhook_deliveries_id_seq"'::REGCLASS) not null,
+    webhook_id      bigint                                 not null,
+    node_address    bytea                                  not null,
+    event_type      text                                   not null,
+    data            jsonb                                  not null,
+    status          text                                   not null,
+    attempts        bigint                   default 0     not null,
+    next_attempt_at timestamp with time zone               not null,
+    response_status bigint,
+    error           text,
+    delivered_at    timestamp with time zone,
+    created_at      timestamp with time zone default now() not null,
+    updated_at      timestamp with time zone default now() not null,
+    constraint pk_node_webhook_deliveries primary key (id)
+);
+
+create index if not exists "idx_node_webhook_deliveries_status_next_attempt_at" on "node_webhook_deliveries" (status, next_attempt_at);
+
+create index if not exists "idx_node_webhook_deliveries_webhook_id_id" on "node_webhook_deliveries" (webhook_id asc, id desc);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "node_webhook_deliveries";
+
+drop sequence if exists "node_webhook_deliveries_id_seq";
+
+drop table if exists "node_webhooks";
+
+drop sequence if exists "node_webhooks_id_seq";
+-- +goose StatementEnd
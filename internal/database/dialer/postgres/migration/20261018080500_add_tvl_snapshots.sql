@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists "epoch"."tvl_snapshots"
+(
+    epoch_id   bigint                                 not null,
+    date       timestamp with time zone               not null,
+    tvl        numeric                                not null,
+    tokens     jsonb   default '[]'::jsonb            not null,
+    created_at timestamp with time zone default now() not null,
+    updated_at timestamp with time zone default now() not null,
+    constraint pk_epoch_tvl_snapshots primary key (epoch_id)
+);
+
+create index if not exists "idx_epoch_tvl_snapshots_date" on "epoch"."tvl_snapshots" (date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists "epoch"."tvl_snapshots";
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table "tx_journal"
+    add column if not exists metadata jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table "tx_journal"
+    drop column if exists metadata;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table "node_webhook_deliveries"
+    add column if not exists idempotency_key text;
+
+create unique index if not exists "idx_node_webhook_deliveries_webhook_id_idempotency_key" on "node_webhook_deliveries" (webhook_id, idempotency_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists "idx_node_webhook_deliveries_webhook_id_idempotency_key";
+
+alter table "node_webhook_deliveries"
+    drop column if exists idempotency_key;
+-- +goose StatementEnd
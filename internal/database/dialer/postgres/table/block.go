@@ -0,0 +1,47 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	gorm "gorm.io/gorm/schema"
+)
+
+var (
+	_ gorm.Tabler             = (*Block)(nil)
+	_ schema.BlockTransformer = (*Block)(nil)
+)
+
+type Block struct {
+	ChainID     uint64    `gorm:"column:chain_id"`
+	BlockNumber uint64    `gorm:"column:block_number"`
+	BlockHash   string    `gorm:"column:block_hash"`
+	ParentHash  string    `gorm:"column:parent_hash"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (b *Block) TableName() string {
+	return "blocks"
+}
+
+func (b *Block) Import(block schema.Block) error {
+	b.ChainID = block.ChainID
+	b.BlockNumber = block.BlockNumber
+	b.BlockHash = block.BlockHash.String()
+	b.ParentHash = block.ParentHash.String()
+
+	return nil
+}
+
+func (b *Block) Export() (*schema.Block, error) {
+	block := schema.Block{
+		ChainID:     b.ChainID,
+		BlockNumber: b.BlockNumber,
+		BlockHash:   common.HexToHash(b.BlockHash),
+		ParentHash:  common.HexToHash(b.ParentHash),
+	}
+
+	return &block, nil
+}
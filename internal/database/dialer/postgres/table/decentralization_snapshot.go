@@ -0,0 +1,101 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type DecentralizationSnapshot struct {
+	EpochID             uint64          `gorm:"column:epoch_id"`
+	Date                time.Time       `gorm:"column:date"`
+	Nodes               uint64          `gorm:"column:nodes"`
+	StakingPoolTokens   decimal.Decimal `gorm:"column:staking_pool_tokens"`
+	NakamotoCoefficient uint64          `gorm:"column:nakamoto_coefficient"`
+	Gini                decimal.Decimal `gorm:"column:gini"`
+	Countries           json.RawMessage `gorm:"column:countries;type:jsonb"`
+	NetworkProviders    json.RawMessage `gorm:"column:network_providers;type:jsonb"`
+	Workers             json.RawMessage `gorm:"column:workers;type:jsonb"`
+	FullNodes           uint64          `gorm:"column:full_nodes"`
+	CreatedAt           time.Time       `gorm:"column:created_at"`
+	UpdatedAt           time.Time       `gorm:"column:updated_at"`
+}
+
+func (d *DecentralizationSnapshot) TableName() string {
+	return "epoch.decentralization_snapshots"
+}
+
+func (d *DecentralizationSnapshot) Import(snapshot *schema.DecentralizationSnapshot) (err error) {
+	d.EpochID = snapshot.EpochID
+	d.Date = snapshot.Date
+	d.Nodes = snapshot.Nodes
+	d.StakingPoolTokens = snapshot.StakingPoolTokens
+	d.NakamotoCoefficient = snapshot.NakamotoCoefficient
+	d.Gini = snapshot.Gini
+	d.FullNodes = snapshot.FullNodes
+
+	if d.Countries, err = json.Marshal(snapshot.Countries); err != nil {
+		return fmt.Errorf("marshal countries: %w", err)
+	}
+
+	if d.NetworkProviders, err = json.Marshal(snapshot.NetworkProviders); err != nil {
+		return fmt.Errorf("marshal network providers: %w", err)
+	}
+
+	if d.Workers, err = json.Marshal(snapshot.Workers); err != nil {
+		return fmt.Errorf("marshal workers: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DecentralizationSnapshot) Export() (*schema.DecentralizationSnapshot, error) {
+	snapshot := schema.DecentralizationSnapshot{
+		EpochID:             d.EpochID,
+		Date:                d.Date,
+		Nodes:               d.Nodes,
+		StakingPoolTokens:   d.StakingPoolTokens,
+		NakamotoCoefficient: d.NakamotoCoefficient,
+		Gini:                d.Gini,
+		Countries:           make([]*schema.DecentralizationDistribution, 0),
+		NetworkProviders:    make([]*schema.DecentralizationDistribution, 0),
+		Workers:             make([]*schema.DecentralizationWorkerCoverage, 0),
+		FullNodes:           d.FullNodes,
+		CreatedAt:           d.CreatedAt,
+		UpdatedAt:           d.UpdatedAt,
+	}
+
+	if err := json.Unmarshal(d.Countries, &snapshot.Countries); len(d.Countries) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal countries: %w", err)
+	}
+
+	if err := json.Unmarshal(d.NetworkProviders, &snapshot.NetworkProviders); len(d.NetworkProviders) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal network providers: %w", err)
+	}
+
+	if err := json.Unmarshal(d.Workers, &snapshot.Workers); len(d.Workers) > 0 && err != nil {
+		return nil, fmt.Errorf("unmarshal workers: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+type DecentralizationSnapshots []DecentralizationSnapshot
+
+func (d *DecentralizationSnapshots) Export() ([]*schema.DecentralizationSnapshot, error) {
+	snapshots := make([]*schema.DecentralizationSnapshot, 0, len(*d))
+
+	for _, snapshot := range *d {
+		exported, err := snapshot.Export()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, exported)
+	}
+
+	return snapshots, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 )
 
@@ -32,6 +33,13 @@ type Node struct {
 	UpdatedAt              time.Time         `gorm:"column:updated_at"`
 }
 
+// NodeLocation is a location of the Node in the location column, it keeps whether the autonomous system has been looked up.
+type NodeLocation struct {
+	schema.NodeLocation
+
+	ASNLookedUp bool `json:"asn_looked_up,omitempty"`
+}
+
 func (*Node) TableName() string {
 	return "node_info"
 }
@@ -51,7 +59,13 @@ func (n *Node) Import(node *schema.Node) (err error) {
 	n.Type = node.Type
 	n.AccessToken = node.AccessToken
 
-	n.Location, err = json.Marshal(node.Location)
+	locations := make([]*NodeLocation, 0, len(node.Location))
+
+	for _, location := range lo.Compact(node.Location) {
+		locations = append(locations, &NodeLocation{NodeLocation: *location, ASNLookedUp: location.ASNLookedUp})
+	}
+
+	n.Location, err = json.Marshal(locations)
 	if err != nil {
 		return fmt.Errorf("marshal node local: %w", err)
 	}
@@ -65,12 +79,19 @@ func (n *Node) Import(node *schema.Node) (err error) {
 }
 
 func (n *Node) Export() (*schema.Node, error) {
-	locations := make([]*schema.NodeLocation, 0)
+	var tLocations []*NodeLocation
 
-	if err := json.Unmarshal(n.Location, &locations); len(n.Location) > 0 && err != nil {
+	if err := json.Unmarshal(n.Location, &tLocations); len(n.Location) > 0 && err != nil {
 		return nil, fmt.Errorf("unmarshal node locations: %w", err)
 	}
 
+	locations := make([]*schema.NodeLocation, 0, len(tLocations))
+
+	for _, location := range lo.Compact(tLocations) {
+		location.NodeLocation.ASNLookedUp = location.ASNLookedUp
+		locations = append(locations, &location.NodeLocation)
+	}
+
 	var avatar *l2.ChipsTokenMetadata
 	if err := json.Unmarshal(n.Avatar, &avatar); len(n.Avatar) > 0 && err != nil {
 		return nil, fmt.Errorf("unmarshal node avatar: %w", err)
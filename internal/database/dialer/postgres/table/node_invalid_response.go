@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lib/pq"
 	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 )
 
 type NodeInvalidResponse struct {
@@ -18,6 +19,10 @@ type NodeInvalidResponse struct {
 	VerifierResponse json.RawMessage                `gorm:"column:verifier_response;type:jsonb"`
 	Node             common.Address                 `gorm:"column:node"`
 	Response         json.RawMessage                `gorm:"column:response;type:jsonb"`
+	AppealStatus     *string                        `gorm:"column:appeal_status"`
+	AppealReason     *string                        `gorm:"column:appeal_reason"`
+	AppealedAt       *time.Time                     `gorm:"column:appealed_at"`
+	ResolvedAt       *time.Time                     `gorm:"column:resolved_at"`
 	CreatedAt        time.Time                      `gorm:"column:created_at"`
 	UpdatedAt        time.Time                      `gorm:"column:updated_at"`
 }
@@ -47,6 +52,23 @@ func (n *NodeInvalidResponse) Export() *schema.NodeInvalidResponse {
 		verifierNodes[i] = common.BytesToAddress(verifierNode)
 	}
 
+	var appeal *schema.NodeInvalidResponseAppeal
+
+	if n.AppealStatus != nil {
+		appeal = &schema.NodeInvalidResponseAppeal{
+			Status: schema.NodeInvalidResponseAppealStatus(*n.AppealStatus),
+			Reason: lo.FromPtr(n.AppealReason),
+		}
+
+		if n.AppealedAt != nil {
+			appeal.AppealedAt = n.AppealedAt.Unix()
+		}
+
+		if n.ResolvedAt != nil {
+			appeal.ResolvedAt = n.ResolvedAt.Unix()
+		}
+	}
+
 	return &schema.NodeInvalidResponse{
 		ID:               n.ID,
 		EpochID:          n.EpochID,
@@ -57,6 +79,7 @@ func (n *NodeInvalidResponse) Export() *schema.NodeInvalidResponse {
 		Node:             n.Node,
 		Response:         n.Response,
 		CreatedAt:        n.CreatedAt.Unix(),
+		Appeal:           appeal,
 	}
 }
 
@@ -73,3 +96,13 @@ func (ns *NodeInvalidResponses) Import(nodeInvalidResponses []*schema.NodeInvali
 		*ns = append(*ns, tNodeInvalidResponse)
 	}
 }
+
+func (ns *NodeInvalidResponses) Export() []*schema.NodeInvalidResponse {
+	nodeInvalidResponses := make([]*schema.NodeInvalidResponse, 0, len(*ns))
+
+	for _, nodeInvalidResponse := range *ns {
+		nodeInvalidResponses = append(nodeInvalidResponses, nodeInvalidResponse.Export())
+	}
+
+	return nodeInvalidResponses
+}
@@ -0,0 +1,111 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+type NodeScoreBreakdown struct {
+	EpochID             uint64          `gorm:"column:epoch_id;primaryKey"`
+	NodeAddress         common.Address  `gorm:"column:node_address;primaryKey"`
+	ValidRequestCount   int64           `gorm:"column:valid_request_count"`
+	InvalidRequestCount int64           `gorm:"column:invalid_request_count"`
+	NetworkCount        int64           `gorm:"column:network_count"`
+	IndexerCount        int64           `gorm:"column:indexer_count"`
+	ActivityCount       int64           `gorm:"column:activity_count"`
+	Uptime              int64           `gorm:"column:uptime"`
+	IsLatestVersion     bool            `gorm:"column:is_latest_version"`
+	ValidScore          float64         `gorm:"column:valid_score"`
+	InvalidScore        float64         `gorm:"column:invalid_score"`
+	NetworkScore        float64         `gorm:"column:network_score"`
+	IndexerScore        float64         `gorm:"column:indexer_score"`
+	ActivityScore       float64         `gorm:"column:activity_score"`
+	UptimeScore         float64         `gorm:"column:uptime_score"`
+	VersionScore        float64         `gorm:"column:version_score"`
+	DistributionScore   float64         `gorm:"column:distribution_score"`
+	DataScore           float64         `gorm:"column:data_score"`
+	StabilityScore      float64         `gorm:"column:stability_score"`
+	Score               float64         `gorm:"column:score"`
+	OperationRewards    decimal.Decimal `gorm:"column:operation_rewards"`
+	CreatedAt           time.Time       `gorm:"column:created_at"`
+	UpdatedAt           time.Time       `gorm:"column:updated_at"`
+}
+
+func (*NodeScoreBreakdown) TableName() string {
+	return "node_score_breakdown"
+}
+
+func (n *NodeScoreBreakdown) Import(breakdown *schema.NodeScoreBreakdown) {
+	n.EpochID = breakdown.EpochID
+	n.NodeAddress = breakdown.NodeAddress
+	n.ValidRequestCount = breakdown.ValidRequestCount
+	n.InvalidRequestCount = breakdown.InvalidRequestCount
+	n.NetworkCount = breakdown.NetworkCount
+	n.IndexerCount = breakdown.IndexerCount
+	n.ActivityCount = breakdown.ActivityCount
+	n.Uptime = breakdown.Uptime
+	n.IsLatestVersion = breakdown.IsLatestVersion
+	n.ValidScore = breakdown.ValidScore
+	n.InvalidScore = breakdown.InvalidScore
+	n.NetworkScore = breakdown.NetworkScore
+	n.IndexerScore = breakdown.IndexerScore
+	n.ActivityScore = breakdown.ActivityScore
+	n.UptimeScore = breakdown.UptimeScore
+	n.VersionScore = breakdown.VersionScore
+	n.DistributionScore = breakdown.DistributionScore
+	n.DataScore = breakdown.DataScore
+	n.StabilityScore = breakdown.StabilityScore
+	n.Score = breakdown.Score
+	n.OperationRewards = breakdown.OperationRewards
+}
+
+func (n *NodeScoreBreakdown) Export() *schema.NodeScoreBreakdown {
+	return &schema.NodeScoreBreakdown{
+		EpochID:             n.EpochID,
+		NodeAddress:         n.NodeAddress,
+		ValidRequestCount:   n.ValidRequestCount,
+		InvalidRequestCount: n.InvalidRequestCount,
+		NetworkCount:        n.NetworkCount,
+		IndexerCount:        n.IndexerCount,
+		ActivityCount:       n.ActivityCount,
+		Uptime:              n.Uptime,
+		IsLatestVersion:     n.IsLatestVersion,
+		ValidScore:          n.ValidScore,
+		InvalidScore:        n.InvalidScore,
+		NetworkScore:        n.NetworkScore,
+		IndexerScore:        n.IndexerScore,
+		ActivityScore:       n.ActivityScore,
+		UptimeScore:         n.UptimeScore,
+		VersionScore:        n.VersionScore,
+		DistributionScore:   n.DistributionScore,
+		DataScore:           n.DataScore,
+		StabilityScore:      n.StabilityScore,
+		Score:               n.Score,
+		OperationRewards:    n.OperationRewards,
+	}
+}
+
+type NodeScoreBreakdowns []NodeScoreBreakdown
+
+func (n *NodeScoreBreakdowns) Import(breakdowns []*schema.NodeScoreBreakdown) {
+	for _, breakdown := range breakdowns {
+		var imported NodeScoreBreakdown
+
+		imported.Import(breakdown)
+
+		*n = append(*n, imported)
+	}
+}
+
+func (n NodeScoreBreakdowns) Export() []*schema.NodeScoreBreakdown {
+	breakdowns := make([]*schema.NodeScoreBreakdown, 0, len(n))
+
+	for index := range n {
+		breakdowns = append(breakdowns, n[index].Export())
+	}
+
+	return breakdowns
+}
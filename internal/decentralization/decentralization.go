@@ -0,0 +1,229 @@
+package decentralization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+// CacheKey is the key of the current concentration of the Network, which is collected by the scheduler every minute.
+const CacheKey = "decentralization:current"
+
+// CacheExpiration outlives a few collections, the snapshot of the latest epoch is served instead once it is expired.
+const CacheExpiration = 10 * time.Minute
+
+// Collect computes the current concentration of the Network from the Nodes, their statistics and workers.
+func Collect(ctx context.Context, databaseClient database.Client) (*schema.DecentralizationSnapshot, error) {
+	nodes, err := databaseClient.FindNodes(ctx, schema.FindNodesQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("find nodes: %w", err)
+	}
+
+	stats, err := databaseClient.FindNodeStats(ctx, &schema.StatQuery{IsFullNode: lo.ToPtr(true)})
+	if err != nil {
+		return nil, fmt.Errorf("find full node stats: %w", err)
+	}
+
+	workers, err := databaseClient.FindNodeWorkers(ctx, &schema.WorkerQuery{IsActive: lo.ToPtr(true)})
+	if err != nil {
+		return nil, fmt.Errorf("find node workers: %w", err)
+	}
+
+	return Compute(nodes, stats, workers)
+}
+
+// Compute computes the concentration of the staking pool tokens over all Nodes,
+// and the distributions of the online Nodes by their locations and workers.
+func Compute(nodes []*schema.Node, stats []*schema.Stat, workers []*schema.Worker) (*schema.DecentralizationSnapshot, error) {
+	snapshot := schema.DecentralizationSnapshot{
+		Date:             time.Now(),
+		Countries:        make([]*schema.DecentralizationDistribution, 0),
+		NetworkProviders: make([]*schema.DecentralizationDistribution, 0),
+		Workers:          make([]*schema.DecentralizationWorkerCoverage, 0),
+	}
+
+	var (
+		tokens           = make([]decimal.Decimal, 0, len(nodes))
+		onlineNodes      = make(map[common.Address]struct{})
+		countries        = make(map[string]*schema.DecentralizationDistribution)
+		networkProviders = make(map[uint]*schema.DecentralizationDistribution)
+	)
+
+	for _, node := range nodes {
+		stakingPoolTokens := decimal.Zero
+
+		if node.StakingPoolTokens != "" {
+			value, err := decimal.NewFromString(node.StakingPoolTokens)
+			if err != nil {
+				return nil, fmt.Errorf("parse staking pool tokens of node %s: %w", node.Address, err)
+			}
+
+			stakingPoolTokens = value
+		}
+
+		if stakingPoolTokens.IsPositive() {
+			tokens = append(tokens, stakingPoolTokens)
+			snapshot.StakingPoolTokens = snapshot.StakingPoolTokens.Add(stakingPoolTokens)
+		}
+
+		if node.Status != schema.NodeStatusOnline {
+			continue
+		}
+
+		onlineNodes[node.Address] = struct{}{}
+
+		// A Node resolving to multiple addresses is located by the first one.
+		if len(node.Location) == 0 || node.Location[0] == nil {
+			continue
+		}
+
+		location := node.Location[0]
+
+		if location.Country != "" {
+			addDistribution(countries, location.Country, &schema.DecentralizationDistribution{Name: location.Country}, stakingPoolTokens)
+		}
+
+		if location.ASN != 0 {
+			addDistribution(networkProviders, location.ASN, &schema.DecentralizationDistribution{Name: location.NetworkProvider, ASN: location.ASN}, stakingPoolTokens)
+		}
+	}
+
+	snapshot.Nodes = uint64(len(tokens))
+	snapshot.NakamotoCoefficient = NakamotoCoefficient(tokens)
+	snapshot.Gini = Gini(tokens)
+	snapshot.Countries = sortDistributions(lo.Values(countries))
+	snapshot.NetworkProviders = sortDistributions(lo.Values(networkProviders))
+
+	// Full Nodes serve every worker, so they have no worker records.
+	for _, stat := range stats {
+		if _, exists := onlineNodes[stat.Address]; exists && stat.IsFullNode {
+			snapshot.FullNodes++
+		}
+	}
+
+	coverage := make(map[[2]string]map[common.Address]struct{})
+
+	for _, worker := range workers {
+		if _, exists := onlineNodes[worker.Address]; !exists || !worker.IsActive {
+			continue
+		}
+
+		key := [2]string{worker.Network, worker.Name}
+
+		if coverage[key] == nil {
+			coverage[key] = make(map[common.Address]struct{})
+		}
+
+		coverage[key][worker.Address] = struct{}{}
+	}
+
+	for key, addresses := range coverage {
+		snapshot.Workers = append(snapshot.Workers, &schema.DecentralizationWorkerCoverage{
+			Network: key[0],
+			Name:    key[1],
+			Nodes:   uint64(len(addresses)) + snapshot.FullNodes,
+		})
+	}
+
+	// The least covered workers come first.
+	sort.Slice(snapshot.Workers, func(i, j int) bool {
+		if snapshot.Workers[i].Nodes != snapshot.Workers[j].Nodes {
+			return snapshot.Workers[i].Nodes < snapshot.Workers[j].Nodes
+		}
+
+		if snapshot.Workers[i].Network != snapshot.Workers[j].Network {
+			return snapshot.Workers[i].Network < snapshot.Workers[j].Network
+		}
+
+		return snapshot.Workers[i].Name < snapshot.Workers[j].Name
+	})
+
+	return &snapshot, nil
+}
+
+// NakamotoCoefficient returns the minimum number of holders controlling more than half of the tokens.
+func NakamotoCoefficient(tokens []decimal.Decimal) uint64 {
+	sorted := sortTokens(tokens)
+	total := decimal.Sum(decimal.Zero, sorted...)
+
+	if !total.IsPositive() {
+		return 0
+	}
+
+	var sum decimal.Decimal
+
+	for index := len(sorted) - 1; index >= 0; index-- {
+		sum = sum.Add(sorted[index])
+
+		if sum.Mul(decimal.NewFromInt(2)).GreaterThan(total) {
+			return uint64(len(sorted) - index)
+		}
+	}
+
+	return uint64(len(sorted))
+}
+
+// Gini returns the Gini coefficient of the tokens.
+// G = 2 * sum(i * x_i) / (n * sum(x_i)) - (n + 1) / n, where x_i is sorted in ascending order and i starts from 1.
+func Gini(tokens []decimal.Decimal) decimal.Decimal {
+	sorted := sortTokens(tokens)
+	total := decimal.Sum(decimal.Zero, sorted...)
+
+	if len(sorted) == 0 || !total.IsPositive() {
+		return decimal.Zero
+	}
+
+	var weighted decimal.Decimal
+
+	for index, value := range sorted {
+		weighted = weighted.Add(value.Mul(decimal.NewFromInt(int64(index + 1))))
+	}
+
+	n := decimal.NewFromInt(int64(len(sorted)))
+
+	return weighted.Mul(decimal.NewFromInt(2)).Div(n.Mul(total)).
+		Sub(n.Add(decimal.NewFromInt(1)).Div(n)).
+		Round(8)
+}
+
+func sortTokens(tokens []decimal.Decimal) []decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(tokens))
+	copy(sorted, tokens)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+
+	return sorted
+}
+
+func addDistribution[K comparable](distributions map[K]*schema.DecentralizationDistribution, key K, initial *schema.DecentralizationDistribution, stakingPoolTokens decimal.Decimal) {
+	distribution, exists := distributions[key]
+	if !exists {
+		distribution = initial
+		distributions[key] = distribution
+	}
+
+	distribution.Nodes++
+	distribution.StakingPoolTokens = distribution.StakingPoolTokens.Add(stakingPoolTokens)
+}
+
+// sortDistributions sorts the distributions from the most concentrated.
+func sortDistributions(distributions []*schema.DecentralizationDistribution) []*schema.DecentralizationDistribution {
+	sort.Slice(distributions, func(i, j int) bool {
+		if distributions[i].Nodes != distributions[j].Nodes {
+			return distributions[i].Nodes > distributions[j].Nodes
+		}
+
+		return distributions[i].Name < distributions[j].Name
+	})
+
+	return distributions
+}
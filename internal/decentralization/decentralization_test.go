@@ -0,0 +1,144 @@
+package decentralization
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNakamotoCoefficientAndGini(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tokens   []int64
+		nakamoto uint64
+		gini     string
+	}{
+		{
+			name:     "empty",
+			tokens:   nil,
+			nakamoto: 0,
+			gini:     "0",
+		},
+		{
+			name:     "single",
+			tokens:   []int64{100},
+			nakamoto: 1,
+			gini:     "0",
+		},
+		{
+			name:     "equal",
+			tokens:   []int64{10, 10, 10, 10},
+			nakamoto: 3,
+			gini:     "0",
+		},
+		{
+			name:     "exactly half is not a majority",
+			tokens:   []int64{50, 25, 25},
+			nakamoto: 2,
+			gini:     "0.16666667",
+		},
+		{
+			name:     "concentrated",
+			tokens:   []int64{1, 1, 1, 97},
+			nakamoto: 1,
+			gini:     "0.72",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokens := make([]decimal.Decimal, 0, len(tt.tokens))
+			for _, value := range tt.tokens {
+				tokens = append(tokens, decimal.NewFromInt(value))
+			}
+
+			assert.Equal(t, tt.nakamoto, NakamotoCoefficient(tokens))
+			assert.Equal(t, tt.gini, Gini(tokens).String())
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addressA = common.HexToAddress("0xA")
+		addressB = common.HexToAddress("0xB")
+		addressC = common.HexToAddress("0xC")
+		addressD = common.HexToAddress("0xD")
+	)
+
+	nodes := []*schema.Node{
+		{
+			Address:           addressA,
+			StakingPoolTokens: "300",
+			Status:            schema.NodeStatusOnline,
+			Location:          []*schema.NodeLocation{{Country: "Germany", ASN: 24940, NetworkProvider: "Hetzner Online GmbH"}},
+		},
+		{
+			Address:           addressB,
+			StakingPoolTokens: "100",
+			Status:            schema.NodeStatusOnline,
+			Location:          []*schema.NodeLocation{{Country: "Germany", ASN: 16509, NetworkProvider: "AMAZON-02"}},
+		},
+		{
+			Address:           addressC,
+			StakingPoolTokens: "100",
+			Status:            schema.NodeStatusOnline,
+			Location:          []*schema.NodeLocation{{Country: "Singapore", ASN: 16509, NetworkProvider: "AMAZON-02"}},
+		},
+		{
+			// Offline Nodes count in the stake but not in the distributions.
+			Address:           addressD,
+			StakingPoolTokens: "500",
+			Status:            schema.NodeStatusOffline,
+			Location:          []*schema.NodeLocation{{Country: "Japan"}},
+		},
+	}
+
+	stats := []*schema.Stat{
+		{Address: addressC, IsFullNode: true},
+		{Address: addressD, IsFullNode: true},
+	}
+
+	workers := []*schema.Worker{
+		{Address: addressA, Network: "ethereum", Name: "core", IsActive: true},
+		{Address: addressB, Network: "ethereum", Name: "core", IsActive: true},
+		{Address: addressA, Network: "farcaster", Name: "core", IsActive: true},
+		{Address: addressB, Network: "farcaster", Name: "core", IsActive: false},
+		{Address: addressD, Network: "arweave", Name: "mirror", IsActive: true},
+	}
+
+	snapshot, err := Compute(nodes, stats, workers)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(4), snapshot.Nodes)
+	assert.Equal(t, "1000", snapshot.StakingPoolTokens.String())
+	assert.Equal(t, uint64(2), snapshot.NakamotoCoefficient)
+	assert.Equal(t, uint64(1), snapshot.FullNodes)
+
+	require.Len(t, snapshot.Countries, 2)
+	assert.Equal(t, "Germany", snapshot.Countries[0].Name)
+	assert.Equal(t, uint64(2), snapshot.Countries[0].Nodes)
+	assert.Equal(t, "400", snapshot.Countries[0].StakingPoolTokens.String())
+	assert.Equal(t, "Singapore", snapshot.Countries[1].Name)
+
+	require.Len(t, snapshot.NetworkProviders, 2)
+	assert.Equal(t, uint(16509), snapshot.NetworkProviders[0].ASN)
+	assert.Equal(t, uint64(2), snapshot.NetworkProviders[0].Nodes)
+
+	assert.Equal(t, []*schema.DecentralizationWorkerCoverage{
+		{Network: "farcaster", Name: "core", Nodes: 2},
+		{Network: "ethereum", Name: "core", Nodes: 3},
+	}, snapshot.Workers)
+}
@@ -0,0 +1,221 @@
+package performance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+)
+
+const (
+	fieldRequests = "requests"
+	fieldErrors   = "errors"
+
+	// retention is how long the performance of an epoch is kept.
+	retention = 30 * 24 * time.Hour
+)
+
+// LatencyBuckets are the upper bounds of the latency histogram buckets,
+// the last bucket also holds the latencies above it.
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	httputil.DefaultTimeout,
+}
+
+// Performance is the latency histogram and error count of a Node in an epoch.
+type Performance struct {
+	EpochID      int64
+	RequestCount int64
+	ErrorCount   int64
+	// Buckets holds the number of successful responses per latency bucket in LatencyBuckets.
+	Buckets []int64
+}
+
+// ErrorRate returns the ratio of failed requests.
+func (p *Performance) ErrorRate() float64 {
+	if p.RequestCount == 0 {
+		return 0
+	}
+
+	return float64(p.ErrorCount) / float64(p.RequestCount)
+}
+
+// Percentile returns the latency percentile (0-100) of the successful responses,
+// interpolated linearly within the matching bucket.
+func (p *Performance) Percentile(percentile float64) time.Duration {
+	var total int64
+
+	for _, count := range p.Buckets {
+		total += count
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	target := percentile / 100 * float64(total)
+
+	var cumulative int64
+
+	for i, count := range p.Buckets {
+		if count == 0 || float64(cumulative+count) < target {
+			cumulative += count
+
+			continue
+		}
+
+		lower := time.Duration(0)
+		if i > 0 {
+			lower = LatencyBuckets[i-1]
+		}
+
+		fraction := (target - float64(cumulative)) / float64(count)
+
+		return lower + time.Duration(fraction*float64(LatencyBuckets[i]-lower))
+	}
+
+	return LatencyBuckets[len(LatencyBuckets)-1]
+}
+
+// Record records the latencies and errors of the responses into the performance of the current epoch.
+// Responses are recorded against the epoch published by the enforcer, nothing is recorded before the first epoch.
+func Record(ctx context.Context, cacheClient cache.Client, responses []*model.DataResponse) error {
+	var epoch int64
+
+	if err := cacheClient.Get(ctx, model.SubscribeNodeCacheKey, &epoch); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		return fmt.Errorf("get current epoch: %w", err)
+	}
+
+	pipeline := cacheClient.Pipeline(ctx)
+
+	for _, response := range responses {
+		key := formatPerformanceRedisKey(epoch, response.Address)
+
+		pipeline.HIncrBy(ctx, key, fieldRequests, 1)
+
+		if response.Err != nil {
+			pipeline.HIncrBy(ctx, key, fieldErrors, 1)
+		} else {
+			pipeline.HIncrBy(ctx, key, formatBucketField(bucketIndex(response.Latency)), 1)
+		}
+
+		pipeline.Expire(ctx, key, retention)
+	}
+
+	if _, err := pipeline.Exec(ctx); err != nil {
+		return fmt.Errorf("record performance: %w", err)
+	}
+
+	return nil
+}
+
+// Find returns the performance of the Node in each epoch, epochs without requests have an empty performance.
+func Find(ctx context.Context, cacheClient cache.Client, address common.Address, epochs []int64) ([]*Performance, error) {
+	pipeline := cacheClient.Pipeline(ctx)
+
+	commands := make([]*redis.MapStringStringCmd, len(epochs))
+	for i, epoch := range epochs {
+		commands[i] = pipeline.HGetAll(ctx, formatPerformanceRedisKey(epoch, address))
+	}
+
+	if _, err := pipeline.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("find performance: %w", err)
+	}
+
+	performances := make([]*Performance, len(epochs))
+
+	for i, command := range commands {
+		fields, err := command.Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("find performance of epoch %d: %w", epochs[i], err)
+		}
+
+		performances[i] = parsePerformance(epochs[i], fields)
+	}
+
+	return performances, nil
+}
+
+// FindByNodes returns the performance of each Node in its epoch in a single round-trip.
+func FindByNodes(ctx context.Context, cacheClient cache.Client, epochs map[common.Address]int64) (map[common.Address]*Performance, error) {
+	pipeline := cacheClient.Pipeline(ctx)
+
+	commands := make(map[common.Address]*redis.MapStringStringCmd, len(epochs))
+	for address, epoch := range epochs {
+		commands[address] = pipeline.HGetAll(ctx, formatPerformanceRedisKey(epoch, address))
+	}
+
+	if len(commands) == 0 {
+		return map[common.Address]*Performance{}, nil
+	}
+
+	if _, err := pipeline.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("find performance: %w", err)
+	}
+
+	performances := make(map[common.Address]*Performance, len(epochs))
+
+	for address, command := range commands {
+		fields, err := command.Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("find performance of %s: %w", address, err)
+		}
+
+		performances[address] = parsePerformance(epochs[address], fields)
+	}
+
+	return performances, nil
+}
+
+func parsePerformance(epoch int64, fields map[string]string) *Performance {
+	performance := Performance{
+		EpochID: epoch,
+		Buckets: make([]int64, len(LatencyBuckets)),
+	}
+
+	performance.RequestCount, _ = strconv.ParseInt(fields[fieldRequests], 10, 64)
+	performance.ErrorCount, _ = strconv.ParseInt(fields[fieldErrors], 10, 64)
+
+	for i := range LatencyBuckets {
+		performance.Buckets[i], _ = strconv.ParseInt(fields[formatBucketField(i)], 10, 64)
+	}
+
+	return &performance
+}
+
+// bucketIndex returns the index of the latency bucket holding the latency.
+func bucketIndex(latency time.Duration) int {
+	for i, bucket := range LatencyBuckets {
+		if latency <= bucket {
+			return i
+		}
+	}
+
+	return len(LatencyBuckets) - 1
+}
+
+func formatBucketField(index int) string {
+	return fmt.Sprintf("le:%d", LatencyBuckets[index].Milliseconds())
+}
+
+func formatPerformanceRedisKey(epoch int64, address common.Address) string {
+	return fmt.Sprintf("%s:%d:%s", model.NodePerformance, epoch, address.String())
+}
@@ -0,0 +1,43 @@
+package performance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketIndex(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, bucketIndex(10*time.Millisecond))
+	assert.Equal(t, 0, bucketIndex(50*time.Millisecond))
+	assert.Equal(t, 1, bucketIndex(51*time.Millisecond))
+	assert.Equal(t, len(LatencyBuckets)-1, bucketIndex(time.Hour))
+}
+
+func TestPerformance(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]string{
+		fieldRequests:        "110",
+		fieldErrors:          "10",
+		formatBucketField(0): "50",
+		formatBucketField(1): "40",
+		formatBucketField(4): "10",
+	}
+
+	performance := parsePerformance(1, fields)
+
+	assert.Equal(t, int64(110), performance.RequestCount)
+	assert.InDelta(t, 10.0/110, performance.ErrorRate(), 1e-9)
+	assert.Equal(t, 50*time.Millisecond, performance.Percentile(50))
+	assert.Equal(t, 100*time.Millisecond, performance.Percentile(90))
+	assert.Equal(t, 750*time.Millisecond, performance.Percentile(95))
+	assert.Equal(t, time.Second, performance.Percentile(100))
+
+	empty := parsePerformance(2, nil)
+
+	assert.Zero(t, empty.ErrorRate())
+	assert.Zero(t, empty.Percentile(99))
+}
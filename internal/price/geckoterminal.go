@@ -0,0 +1,106 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/contract/l1"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/shopspring/decimal"
+)
+
+var _ Provider = (*GeckoTerminal)(nil)
+
+// DefaultGeckoTerminalNetworks maps the chain IDs to the GeckoTerminal networks,
+// get list of supported networks from https://api.geckoterminal.com/api/v2/networks
+var DefaultGeckoTerminalNetworks = map[uint64]string{
+	l1.ChainIDMainnet: "eth",
+	l2.ChainIDMainnet: "rss3-vsl-mainnet",
+}
+
+// GeckoTerminal provides the prices of the GeckoTerminal API, the tokens on chains without a network are omitted.
+type GeckoTerminal struct {
+	httpClient httputil.Client
+	endpoint   string
+	authToken  string
+	networks   map[uint64]string
+}
+
+type geckoTerminalTokenPrice struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			TokenPrices map[string]string `json:"token_prices"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func (g *GeckoTerminal) Prices(ctx context.Context, tokens []Token) (map[common.Address]decimal.Decimal, error) {
+	addressLists := make(map[string][]string)
+
+	for _, token := range tokens {
+		if network, ok := g.networks[token.ChainID]; ok {
+			addressLists[network] = append(addressLists[network], token.Address.String())
+		}
+	}
+
+	prices := make(map[common.Address]decimal.Decimal, len(tokens))
+
+	for network, addressList := range addressLists {
+		sort.Strings(addressList)
+
+		url := fmt.Sprintf("%s/simple/networks/%s/token_price/%s", g.endpoint, network, strings.Join(addressList, ","))
+
+		body, err := g.httpClient.FetchWithMethod(ctx, http.MethodGet, url, g.authToken, nil)
+		if err != nil {
+			return nil, fmt.Errorf("get token price: %w", err)
+		}
+
+		var tokenPrice geckoTerminalTokenPrice
+
+		err = json.NewDecoder(body).Decode(&tokenPrice)
+		_ = body.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("parse token price from response body: %w", err)
+		}
+
+		for address, price := range tokenPrice.Data.Attributes.TokenPrices {
+			value, err := decimal.NewFromString(price)
+			if err != nil {
+				return nil, fmt.Errorf("parse token price %s of %s: %w", price, address, err)
+			}
+
+			prices[common.HexToAddress(address)] = value
+		}
+	}
+
+	return prices, nil
+}
+
+// NewGeckoTerminal returns a GeckoTerminal provider, the networks override DefaultGeckoTerminalNetworks.
+func NewGeckoTerminal(httpClient httputil.Client, endpoint, authToken string, networks map[uint64]string) *GeckoTerminal {
+	instance := GeckoTerminal{
+		httpClient: httpClient,
+		endpoint:   endpoint,
+		authToken:  authToken,
+		networks:   make(map[uint64]string, len(DefaultGeckoTerminalNetworks)+len(networks)),
+	}
+
+	for chainID, network := range DefaultGeckoTerminalNetworks {
+		instance.networks[chainID] = network
+	}
+
+	for chainID, network := range networks {
+		instance.networks[chainID] = network
+	}
+
+	return &instance
+}
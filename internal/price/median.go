@@ -0,0 +1,102 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/sourcegraph/conc/pool"
+)
+
+var _ Provider = (*Median)(nil)
+
+var ErrNoProviders = errors.New("no price provider is configured")
+
+// Median provides the median of the prices of the providers, so that a single provider cannot skew the prices.
+// The providers failing are ignored unless all of them fail, and the tokens priced by fewer than minQuotes providers are omitted.
+type Median struct {
+	minQuotes int
+	providers []Provider
+}
+
+func (m *Median) Prices(ctx context.Context, tokens []Token) (map[common.Address]decimal.Decimal, error) {
+	if len(m.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var (
+		candidates = make(map[common.Address][]decimal.Decimal, len(tokens))
+		errs       []error
+		mu         sync.Mutex
+	)
+
+	p := pool.New().WithContext(ctx)
+
+	for index, provider := range m.providers {
+		index, provider := index, provider
+
+		p.Go(func(ctx context.Context) error {
+			prices, err := provider.Prices(ctx, tokens)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("price provider %d: %w", index, err))
+
+				return nil
+			}
+
+			for address, price := range prices {
+				candidates[address] = append(candidates[address], price)
+			}
+
+			return nil
+		})
+	}
+
+	_ = p.Wait()
+
+	if len(errs) == len(m.providers) {
+		return nil, errors.Join(errs...)
+	}
+
+	prices := make(map[common.Address]decimal.Decimal, len(candidates))
+
+	for address, values := range candidates {
+		if len(values) < m.minQuotes {
+			continue
+		}
+
+		prices[address] = median(values)
+	}
+
+	return prices, nil
+}
+
+// median returns the median of the values, the mean of the two middle values if the number of values is even.
+func median(values []decimal.Decimal) decimal.Decimal {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].LessThan(values[j])
+	})
+
+	middle := len(values) / 2
+
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+
+	return values[middle-1].Add(values[middle]).Div(decimal.NewFromInt(2))
+}
+
+// NewMedian returns a Median of the providers, a token must be priced by at least minQuotes providers.
+func NewMedian(minQuotes int, providers ...Provider) *Median {
+	return &Median{
+		minQuotes: minQuotes,
+		providers: providers,
+	}
+}
@@ -0,0 +1,89 @@
+package price
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/shopspring/decimal"
+)
+
+// Token is a token priced by a Provider.
+type Token struct {
+	ChainID uint64
+	Address common.Address
+}
+
+// Provider provides the prices of tokens in USD.
+type Provider interface {
+	// Prices returns the prices of a whole token of each token by address, the tokens unknown to the provider are omitted.
+	Prices(ctx context.Context, tokens []Token) (map[common.Address]decimal.Decimal, error)
+}
+
+const (
+	ProviderTypeGeckoTerminal = "geckoterminal"
+	ProviderTypeStatic        = "static"
+)
+
+// NewProvider returns the providers of the config, which are aggregated by the median of their prices if there are several.
+// The GeckoTerminal provider of the endpoint of the config comes first, at least one provider must be configured.
+func NewProvider(priceAPI *config.TokenPriceAPI, httpClient httputil.Client) (Provider, error) {
+	var providers []Provider
+
+	if priceAPI != nil {
+		if priceAPI.Endpoint != "" {
+			providers = append(providers, NewGeckoTerminal(httpClient, priceAPI.Endpoint, priceAPI.AuthToken, priceAPI.Networks))
+		}
+
+		for index, providerConfig := range priceAPI.Providers {
+			provider, err := newProvider(providerConfig, httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("new price provider %d: %w", index, err)
+			}
+
+			providers = append(providers, provider)
+		}
+	}
+
+	if len(providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	if priceAPI.MinQuotes > len(providers) {
+		return nil, fmt.Errorf("the minimum number of quotes %d exceeds the %d price providers", priceAPI.MinQuotes, len(providers))
+	}
+
+	if len(providers) == 1 {
+		return providers[0], nil
+	}
+
+	return NewMedian(priceAPI.MinQuotes, providers...), nil
+}
+
+func newProvider(providerConfig *config.PriceProvider, httpClient httputil.Client) (Provider, error) {
+	switch providerConfig.Type {
+	case ProviderTypeGeckoTerminal:
+		return NewGeckoTerminal(httpClient, providerConfig.Endpoint, providerConfig.AuthToken, providerConfig.Networks), nil
+	case ProviderTypeStatic:
+		prices := make(map[common.Address]decimal.Decimal, len(providerConfig.Prices))
+
+		for address, price := range providerConfig.Prices {
+			if !common.IsHexAddress(address) {
+				return nil, fmt.Errorf("invalid token address %s", address)
+			}
+
+			value, err := decimal.NewFromString(price)
+			if err != nil {
+				return nil, fmt.Errorf("parse price %s of %s: %w", price, address, err)
+			}
+
+			prices[common.HexToAddress(address)] = value
+		}
+
+		return NewStatic(prices), nil
+	default:
+		return nil, fmt.Errorf("unsupported price provider type: %s", providerConfig.Type)
+	}
+}
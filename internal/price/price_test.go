@@ -0,0 +1,137 @@
+package price_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/contract/l1"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/price"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tokenL1 = price.Token{ChainID: l1.ChainIDMainnet, Address: common.HexToAddress("0xc98D64DA73a6616c42117b582e832812e7B8D57F")}
+	tokenL2 = price.Token{ChainID: l2.ChainIDMainnet, Address: common.HexToAddress("0x4200000000000000000000000000000000000042")}
+)
+
+func TestGeckoTerminal(t *testing.T) {
+	t.Parallel()
+
+	// The price API returns the prices keyed by the lowercase addresses.
+	prices := map[string]map[string]string{
+		"eth":              {strings.ToLower(tokenL1.Address.String()): "0.1"},
+		"rss3-vsl-mainnet": {strings.ToLower(tokenL2.Address.String()): "0.12"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		network := strings.Split(strings.TrimPrefix(request.URL.Path, "/simple/networks/"), "/")[0]
+
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(map[string]any{
+			"data": map[string]any{
+				"attributes": map[string]any{"token_prices": prices[network]},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient, err := httputil.NewHTTPClient()
+	require.NoError(t, err)
+
+	provider, err := price.NewProvider(&config.TokenPriceAPI{Endpoint: server.URL}, httpClient)
+	require.NoError(t, err)
+	require.IsType(t, &price.GeckoTerminal{}, provider)
+
+	// The tokens on chains without a network are omitted.
+	result, err := provider.Prices(context.Background(), []price.Token{tokenL1, tokenL2, {ChainID: 1234, Address: common.HexToAddress("0x1")}})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	require.Equal(t, "0.1", result[tokenL1.Address].String())
+	require.Equal(t, "0.12", result[tokenL2.Address].String())
+}
+
+func TestStatic(t *testing.T) {
+	t.Parallel()
+
+	provider, err := price.NewProvider(&config.TokenPriceAPI{
+		Providers: []*config.PriceProvider{
+			{Type: price.ProviderTypeStatic, Prices: map[string]string{tokenL1.Address.String(): "0.25"}},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	result, err := provider.Prices(context.Background(), []price.Token{tokenL1, tokenL2})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "0.25", result[tokenL1.Address].String())
+
+	_, err = price.NewProvider(&config.TokenPriceAPI{
+		Providers: []*config.PriceProvider{
+			{Type: price.ProviderTypeStatic, Prices: map[string]string{tokenL1.Address.String(): "free"}},
+		},
+	}, nil)
+	require.Error(t, err)
+}
+
+// failingProvider is a provider whose API is unavailable.
+type failingProvider struct{}
+
+func (failingProvider) Prices(context.Context, []price.Token) (map[common.Address]decimal.Decimal, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestMedian(t *testing.T) {
+	t.Parallel()
+
+	static := func(l1Price, l2Price string) price.Provider {
+		prices := map[common.Address]decimal.Decimal{tokenL1.Address: decimal.RequireFromString(l1Price)}
+		if l2Price != "" {
+			prices[tokenL2.Address] = decimal.RequireFromString(l2Price)
+		}
+
+		return price.NewStatic(prices)
+	}
+
+	tokens := []price.Token{tokenL1, tokenL2}
+
+	// An outlier does not skew the median, the mean of the two middle prices is used for an even number of prices.
+	result, err := price.NewMedian(1, static("0.1", "0.2"), static("0.3", "0.4"), static("100", ""), failingProvider{}).Prices(context.Background(), tokens)
+	require.NoError(t, err)
+	require.Equal(t, "0.3", result[tokenL1.Address].String())
+	require.Equal(t, "0.3", result[tokenL2.Address].String())
+
+	// The token priced by fewer providers than the minimum number of quotes is omitted.
+	result, err = price.NewMedian(3, static("0.1", "0.2"), static("0.3", "0.4"), static("100", ""), failingProvider{}).Prices(context.Background(), tokens)
+	require.NoError(t, err)
+	require.Equal(t, "0.3", result[tokenL1.Address].String())
+	require.NotContains(t, result, tokenL2.Address)
+
+	_, err = price.NewMedian(1, failingProvider{}, failingProvider{}).Prices(context.Background(), tokens)
+	require.Error(t, err)
+
+	// No provider is configured.
+	_, err = price.NewProvider(nil, nil)
+	require.ErrorIs(t, err, price.ErrNoProviders)
+
+	_, err = price.NewProvider(&config.TokenPriceAPI{}, nil)
+	require.ErrorIs(t, err, price.ErrNoProviders)
+
+	// The minimum number of quotes cannot be met by the providers.
+	_, err = price.NewProvider(&config.TokenPriceAPI{
+		Providers: []*config.PriceProvider{
+			{Type: price.ProviderTypeStatic, Prices: map[string]string{tokenL1.Address.String(): "0.25"}},
+		},
+		MinQuotes: 2,
+	}, nil)
+	require.Error(t, err)
+}
@@ -8,10 +8,18 @@ import (
 )
 
 func ProvideEthereumMultiChainClient(configFile *config.File) (*ethereum.MultiChainClient, error) {
-	endpoints := []string{
+	chains := [][]string{
 		configFile.RSS3Chain.EndpointL1,
 		configFile.RSS3Chain.EndpointL2,
 	}
 
-	return ethereum.Dial(context.TODO(), endpoints)
+	failover := configFile.RSS3Chain.Failover
+
+	return ethereum.Dial(context.TODO(), chains, ethereum.Options{
+		FailureThreshold:    failover.FailureThreshold,
+		Cooldown:            failover.Cooldown,
+		HealthCheckInterval: failover.HealthCheckInterval,
+		Quorum:              failover.Quorum,
+		QuorumMethods:       failover.QuorumMethods,
+	})
 }
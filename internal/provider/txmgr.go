@@ -3,22 +3,24 @@ package provider
 import (
 	"fmt"
 	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	gicrypto "github.com/rss3-network/global-indexer/common/crypto"
 	"github.com/rss3-network/global-indexer/common/txmgr"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/samber/lo"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
-func ProvideTxManager(config *config.File, ethereumMultiChainClient *ethereum.MultiChainClient) (*txmgr.SimpleTxManager, error) {
-	signerFactory, from, err := gicrypto.NewSignerFactory(config.Settler.PrivateKey, config.Settler.SignerEndpoint, config.Settler.WalletAddress)
-	if err != nil {
-		return nil, fmt.Errorf("create signer: %w", err)
-	}
-
+func ProvideTxManager(config *config.File, ethereumMultiChainClient *ethereum.MultiChainClient, databaseClient database.Client) (*txmgr.SimpleTxManager, error) {
 	defaultTxConfig := txmgr.Config{
 		ResubmissionTimeout:       20 * time.Second,
 		FeeLimitMultiplier:        5,
@@ -37,10 +39,84 @@ func ProvideTxManager(config *config.File, ethereumMultiChainClient *ethereum.Mu
 		return nil, fmt.Errorf("load l2 ethereum client: %w", err)
 	}
 
-	txManager, err := txmgr.NewSimpleTxManager(defaultTxConfig, chainID, nil, ethereumClient, from, signerFactory(chainID))
+	signers, err := buildSigners(config, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	signerPool, err := txmgr.NewSignerPool(signers)
+	if err != nil {
+		return nil, fmt.Errorf("create signer pool: %w", err)
+	}
+
+	go reloadSignersOnHangup(signerPool, chainID)
+
+	txManager, err := txmgr.NewSimpleTxManager(defaultTxConfig, chainID, ethereumClient, signerPool, databaseClient)
 	if err != nil {
 		return nil, fmt.Errorf("create tx manager %w", err)
 	}
 
 	return txManager, nil
 }
+
+// buildSigners builds the signers of the signer pool,
+// the account of the settler is used by all services if no signer is configured.
+func buildSigners(configFile *config.File, chainID *big.Int) ([]txmgr.Signer, error) {
+	signerConfigs := configFile.Signers
+	if len(signerConfigs) == 0 && configFile.Settler != nil {
+		signerConfigs = append(signerConfigs, &config.Signer{
+			PrivateKey:     configFile.Settler.PrivateKey,
+			WalletAddress:  configFile.Settler.WalletAddress,
+			SignerEndpoint: configFile.Settler.SignerEndpoint,
+		})
+	}
+
+	signers := make([]txmgr.Signer, 0, len(signerConfigs))
+
+	for index, signerConfig := range signerConfigs {
+		signerFactory, from, err := gicrypto.NewSignerFactory(signerConfig.PrivateKey, signerConfig.SignerEndpoint, signerConfig.WalletAddress)
+		if err != nil {
+			return nil, fmt.Errorf("create signer %d: %w", index, err)
+		}
+
+		signers = append(signers, txmgr.Signer{
+			Address:  from,
+			SignerFn: signerFactory(chainID),
+			Services: signerConfig.Services,
+		})
+	}
+
+	return signers, nil
+}
+
+// reloadSignersOnHangup reloads the signers from the config file on SIGHUP, so the keys are rotated without a restart.
+func reloadSignersOnHangup(signerPool *txmgr.SignerPool, chainID *big.Int) {
+	hangupChan := make(chan os.Signal, 1)
+	signal.Notify(hangupChan, syscall.SIGHUP)
+
+	for range hangupChan {
+		configFile, err := config.Setup(viper.GetString(flag.KeyConfig))
+		if err != nil {
+			zap.L().Error("reload config file", zap.Error(err))
+
+			continue
+		}
+
+		signers, err := buildSigners(configFile, chainID)
+		if err != nil {
+			zap.L().Error("build signers", zap.Error(err))
+
+			continue
+		}
+
+		if err := signerPool.Load(signers); err != nil {
+			zap.L().Error("reload signer pool", zap.Error(err))
+
+			continue
+		}
+
+		zap.L().Info("signer pool reloaded", zap.Strings("accounts", lo.Map(signerPool.Addresses(), func(address common.Address, _ int) string {
+			return address.String()
+		})))
+	}
+}
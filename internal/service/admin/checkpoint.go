@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/indexer"
+	"github.com/samber/lo"
+)
+
+// listCheckpoints prints the checkpoints of each chain and their lag behind the chain head.
+// The unfinalized indexer keeps its checkpoint in memory, so the latest block saved by it is printed instead.
+func (s *Server) listCheckpoints(ctx context.Context) error {
+	writer := tabwriter.NewWriter(s.output, 0, 0, 2, ' ', 0)
+
+	if _, err := fmt.Fprintln(writer, "CHAIN\tCHAIN ID\tFINALIZED\tFINALIZED HEAD\tFINALIZED LAG\tUNFINALIZED\tHEAD\tUNFINALIZED LAG"); err != nil {
+		return err
+	}
+
+	for _, chain := range []string{indexer.ChainL1, indexer.ChainL2} {
+		chainID, ethereumClient, err := s.chain(chain)
+		if err != nil {
+			return err
+		}
+
+		checkpoint, err := s.databaseClient.FindCheckpoint(ctx, chainID)
+		if err != nil {
+			return fmt.Errorf("find checkpoint of %s: %w", chain, err)
+		}
+
+		finalizedHead, err := ethereumClient.HeaderByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+		if err != nil {
+			return fmt.Errorf("get finalized block of %s: %w", chain, err)
+		}
+
+		head, err := ethereumClient.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("get latest block number of %s: %w", chain, err)
+		}
+
+		unfinalized, unfinalizedLag := "-", "-"
+
+		block, err := s.databaseClient.FindLatestBlock(ctx, chainID)
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find latest block of %s: %w", chain, err)
+		}
+
+		if block != nil {
+			unfinalized, unfinalizedLag = strconv.FormatUint(block.BlockNumber, 10), strconv.FormatUint(lag(head, block.BlockNumber), 10)
+		}
+
+		if _, err := fmt.Fprintf(
+			writer,
+			"%s\t%d\t%d\t%d\t%d\t%s\t%d\t%s\n",
+			chain, chainID, checkpoint.BlockNumber, finalizedHead.Number.Uint64(), lag(finalizedHead.Number.Uint64(), checkpoint.BlockNumber), unfinalized, head, unfinalizedLag,
+		); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// setCheckpoint sets the checkpoint of the chain to the block, the rows indexed are not changed.
+// It fails while an indexer of the chain holds the checkpoint lock.
+func (s *Server) setCheckpoint(ctx context.Context, chain string, blockNumber uint64) error {
+	chainID, ethereumClient, err := s.chain(chain)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := s.tryLockCheckpoint(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("the checkpoint of %s is locked, stop the indexers of the chain first: %w", chain, err)
+	}
+
+	defer unlock()
+
+	header, err := ethereumClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("get header of block %d: %w", blockNumber, err)
+	}
+
+	checkpoint, err := s.databaseClient.FindCheckpoint(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("find checkpoint: %w", err)
+	}
+
+	prompt := fmt.Sprintf("Set the checkpoint of %s from block %d to block %d %s?", chain, checkpoint.BlockNumber, blockNumber, header.Hash())
+
+	confirmed, err := s.confirm(prompt)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return errCanceled
+	}
+
+	checkpoint.BlockNumber, checkpoint.BlockHash = blockNumber, header.Hash()
+
+	if err := s.databaseClient.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+
+	_, err = fmt.Fprintf(s.output, "The checkpoint of %s is set to block %d.\n", chain, blockNumber)
+
+	return err
+}
+
+// rewindCheckpoint moves the checkpoint of the chain back by the number of blocks,
+// and deletes the rows indexed from these blocks, including the finalized ones, in a database transaction.
+// It fails while an indexer of the chain holds the checkpoint lock.
+func (s *Server) rewindCheckpoint(ctx context.Context, chain string, blocks uint64) error {
+	chainID, ethereumClient, err := s.chain(chain)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := s.tryLockCheckpoint(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("the checkpoint of %s is locked, stop the indexers of the chain first: %w", chain, err)
+	}
+
+	defer unlock()
+
+	checkpoint, err := s.databaseClient.FindCheckpoint(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("find checkpoint: %w", err)
+	}
+
+	if blocks == 0 || blocks > checkpoint.BlockNumber {
+		return fmt.Errorf("invalid number of blocks %d to rewind from block %d", blocks, checkpoint.BlockNumber)
+	}
+
+	blockNumber := checkpoint.BlockNumber - blocks
+
+	header, err := ethereumClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("get header of block %d: %w", blockNumber, err)
+	}
+
+	prompt := fmt.Sprintf("Rewind the checkpoint of %s from block %d to block %d, and delete the rows indexed from %d blocks?", chain, checkpoint.BlockNumber, blockNumber, blocks)
+
+	confirmed, err := s.confirm(prompt)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return errCanceled
+	}
+
+	databaseTransaction, err := s.databaseClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin database transaction: %w", err)
+	}
+
+	defer lo.Try(databaseTransaction.Rollback)
+
+	if err := databaseTransaction.RewindBlocks(ctx, chainID, blockNumber); err != nil {
+		return fmt.Errorf("delete the rows after block %d: %w", blockNumber, err)
+	}
+
+	checkpoint.BlockNumber, checkpoint.BlockHash = blockNumber, header.Hash()
+
+	if err := databaseTransaction.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+
+	if err := databaseTransaction.Commit(); err != nil {
+		return fmt.Errorf("commit database transaction: %w", err)
+	}
+
+	_, err = fmt.Fprintf(s.output, "The checkpoint of %s is rewound to block %d.\n", chain, blockNumber)
+
+	return err
+}
+
+// lag returns the number of blocks the block is behind the head.
+func lag(head, blockNumber uint64) uint64 {
+	if blockNumber >= head {
+		return 0
+	}
+
+	return head - blockNumber
+}
@@ -0,0 +1,206 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/indexer"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 1
+
+func TestSetCheckpoint(t *testing.T) {
+	testcases := []struct {
+		name   string
+		locked bool
+	}{
+		{name: "set"},
+		{name: "locked by an indexer", locked: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			databaseClient := testDatabase{checkpoint: schema.Checkpoint{ChainID: testChainID, BlockNumber: 10}}
+			lock := testLock{locked: testcase.locked}
+
+			server := newTestServer(t, &databaseClient, &lock)
+
+			err := server.setCheckpoint(context.Background(), indexer.ChainL1, 5)
+			if testcase.locked {
+				require.Error(t, err)
+				assert.Equal(t, uint64(10), databaseClient.checkpoint.BlockNumber)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(5), databaseClient.checkpoint.BlockNumber)
+			assert.Equal(t, testHeader(5).Hash(), databaseClient.checkpoint.BlockHash)
+			assert.True(t, lock.unlocked)
+		})
+	}
+}
+
+func TestRewindCheckpoint(t *testing.T) {
+	testcases := []struct {
+		name   string
+		blocks uint64
+		locked bool
+		err    bool
+	}{
+		{name: "rewind", blocks: 4},
+		{name: "locked by an indexer", blocks: 4, locked: true, err: true},
+		{name: "beyond the genesis block", blocks: 11, err: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			databaseClient := testDatabase{checkpoint: schema.Checkpoint{ChainID: testChainID, BlockNumber: 10}}
+			lock := testLock{locked: testcase.locked}
+
+			server := newTestServer(t, &databaseClient, &lock)
+
+			err := server.rewindCheckpoint(context.Background(), indexer.ChainL1, testcase.blocks)
+			if testcase.err {
+				require.Error(t, err)
+				assert.Nil(t, databaseClient.rewoundTo)
+				assert.Equal(t, uint64(10), databaseClient.checkpoint.BlockNumber)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, databaseClient.committed)
+			assert.True(t, lock.unlocked)
+
+			// The rows after the new checkpoint are deleted.
+			require.NotNil(t, databaseClient.rewoundTo)
+			assert.Equal(t, uint64(6), *databaseClient.rewoundTo)
+			assert.Equal(t, uint64(6), databaseClient.checkpoint.BlockNumber)
+			assert.Equal(t, testHeader(6).Hash(), databaseClient.checkpoint.BlockHash)
+		})
+	}
+}
+
+// newTestServer returns a server which confirms the changes, the ethereum client of the chain is served by testChain.
+func newTestServer(t *testing.T, databaseClient database.Client, lock *testLock) *Server {
+	t.Helper()
+
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("eth", new(testChain)))
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "chain.ipc"))
+	require.NoError(t, err)
+
+	go func() {
+		_ = rpcServer.ServeListener(listener)
+	}()
+
+	t.Cleanup(rpcServer.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ethereumMultiChainClient, err := ethereum.Dial(ctx, [][]string{{listener.Addr().String()}}, ethereum.Options{})
+	require.NoError(t, err)
+
+	viper.Set(flag.KeyChainIDL1, testChainID)
+
+	return &Server{
+		databaseClient:           databaseClient,
+		ethereumMultiChainClient: ethereumMultiChainClient,
+		tryLockCheckpoint:        lock.TryLock,
+		yes:                      true,
+		output:                   new(bytes.Buffer),
+	}
+}
+
+func testHeader(blockNumber uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(blockNumber),
+		Difficulty: big.NewInt(0),
+	}
+}
+
+// testChain serves the JSON-RPC methods used by the admin commands.
+type testChain struct{}
+
+// ChainId serves eth_chainId.
+func (c *testChain) ChainId() *hexutil.Big { //nolint:revive,stylecheck
+	return (*hexutil.Big)(big.NewInt(testChainID))
+}
+
+func (c *testChain) GetBlockByNumber(number rpc.BlockNumber, _ bool) *types.Header {
+	return testHeader(uint64(number.Int64()))
+}
+
+// testLock is the checkpoint lock, it is held by an indexer if locked is true.
+type testLock struct {
+	locked   bool
+	unlocked bool
+}
+
+func (l *testLock) TryLock(_ context.Context, _ uint64) (func(), error) {
+	if l.locked {
+		return nil, errors.New("lock already taken")
+	}
+
+	return func() { l.unlocked = true }, nil
+}
+
+// testDatabase keeps the checkpoint of a chain, its transactions write to itself.
+type testDatabase struct {
+	database.Client
+
+	checkpoint schema.Checkpoint
+	rewoundTo  *uint64
+	committed  bool
+}
+
+func (d *testDatabase) Begin(_ context.Context, _ ...*sql.TxOptions) (database.Client, error) {
+	return d, nil
+}
+
+func (d *testDatabase) Commit() error {
+	d.committed = true
+
+	return nil
+}
+
+func (d *testDatabase) Rollback() error {
+	return nil
+}
+
+func (d *testDatabase) FindCheckpoint(_ context.Context, _ uint64) (*schema.Checkpoint, error) {
+	checkpoint := d.checkpoint
+
+	return &checkpoint, nil
+}
+
+func (d *testDatabase) SaveCheckpoint(_ context.Context, checkpoint *schema.Checkpoint) error {
+	d.checkpoint = *checkpoint
+
+	return nil
+}
+
+func (d *testDatabase) RewindBlocks(_ context.Context, _, blockNumber uint64) error {
+	d.rewoundTo = &blockNumber
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config/flag"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/service/indexer"
+	"github.com/spf13/viper"
+)
+
+const Name = "admin"
+
+var errCanceled = errors.New("canceled by the operator")
+
+// The actions of the admin commands.
+const (
+	ActionCheckpointList   = "checkpoint.list"
+	ActionCheckpointSet    = "checkpoint.set"
+	ActionCheckpointRewind = "checkpoint.rewind"
+)
+
+// Server runs an admin command once, the changes are confirmed by the operator from the input.
+type Server struct {
+	databaseClient           database.Client
+	ethereumMultiChainClient *ethereum.MultiChainClient
+	// tryLockCheckpoint acquires the checkpoint lock of a chain, so the checkpoint is never changed under a running indexer.
+	tryLockCheckpoint func(ctx context.Context, chainID uint64) (unlock func(), err error)
+	action            string
+	// yes skips the confirmations.
+	yes    bool
+	input  *bufio.Reader
+	output io.Writer
+}
+
+func (s *Server) Name() string {
+	return Name
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	switch s.action {
+	case ActionCheckpointList:
+		return s.listCheckpoints(ctx)
+	case ActionCheckpointSet:
+		return s.setCheckpoint(ctx, viper.GetString(flag.KeyChain), viper.GetUint64(flag.KeyBlock))
+	case ActionCheckpointRewind:
+		return s.rewindCheckpoint(ctx, viper.GetString(flag.KeyChain), viper.GetUint64(flag.KeyBlocks))
+	default:
+		return fmt.Errorf("unsupported action: %s", s.action)
+	}
+}
+
+// chain returns the chain id and the ethereum client of the chain.
+func (s *Server) chain(chain string) (uint64, *ethclient.Client, error) {
+	var chainID uint64
+
+	switch chain {
+	case indexer.ChainL1:
+		chainID = viper.GetUint64(flag.KeyChainIDL1)
+	case indexer.ChainL2:
+		chainID = viper.GetUint64(flag.KeyChainIDL2)
+	default:
+		return 0, nil, fmt.Errorf("unsupported chain: %s", chain)
+	}
+
+	ethereumClient, err := s.ethereumMultiChainClient.Get(chainID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("load ethereum client: %w", err)
+	}
+
+	return chainID, ethereumClient, nil
+}
+
+// confirm asks the operator to confirm the change, it returns true if the answer is yes.
+func (s *Server) confirm(prompt string) (bool, error) {
+	if s.yes {
+		return true, nil
+	}
+
+	if _, err := fmt.Fprintf(s.output, "%s [y/N] ", prompt); err != nil {
+		return false, err
+	}
+
+	answer, err := s.input.ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("read answer: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+func NewServer(databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient) (service.Server, error) {
+	instance := Server{
+		databaseClient:           databaseClient,
+		ethereumMultiChainClient: ethereumMultiChainClient,
+		tryLockCheckpoint: func(ctx context.Context, chainID uint64) (func(), error) {
+			return indexer.TryLockCheckpoint(ctx, redisClient, chainID)
+		},
+		action: viper.GetString(flag.KeyAction),
+		yes:    viper.GetBool(flag.KeyYes),
+		input:  bufio.NewReader(os.Stdin),
+		output: os.Stdout,
+	}
+
+	return &instance, nil
+}
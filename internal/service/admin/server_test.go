@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name      string
+		yes       bool
+		input     string
+		confirmed bool
+		err       bool
+	}{
+		{name: "yes", input: "y\n", confirmed: true},
+		{name: "yes in full", input: " Yes \n", confirmed: true},
+		{name: "no", input: "n\n"},
+		{name: "empty answer", input: "\n"},
+		{name: "answer without newline", input: "y", confirmed: true},
+		{name: "no answer", input: "", err: true},
+		{name: "skipped", yes: true, confirmed: true},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+
+			server := Server{
+				yes:    testcase.yes,
+				input:  bufio.NewReader(strings.NewReader(testcase.input)),
+				output: &output,
+			}
+
+			confirmed, err := server.confirm("Continue?")
+			if testcase.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testcase.confirmed, confirmed)
+
+			if !testcase.yes {
+				assert.Equal(t, "Continue? [y/N] ", output.String())
+			}
+		})
+	}
+}
@@ -142,6 +142,10 @@ func (d *DSL) BatchGetDecentralizedAccountsActivities(c echo.Context) (err error
 			return errorx.ServiceUnavailableError(c, err)
 		}
 
+		if errors.Is(err, errorx.ErrInvalidCursor) {
+			return errorx.BadRequestError(c, err)
+		}
+
 		zap.L().Error("distribute batch activities data error", zap.Error(err))
 
 		return errorx.InternalError(c)
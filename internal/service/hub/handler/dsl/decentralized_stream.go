@@ -0,0 +1,278 @@
+package dsl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/distributor"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"go.uber.org/zap"
+)
+
+const (
+	// streamPollInterval is the interval between two queries of the qualified Nodes.
+	streamPollInterval = 10 * time.Second
+	// streamSeenSize is the number of recent activity IDs kept to deduplicate the activities.
+	streamSeenSize = 1000
+)
+
+var errTooManySubscriptions = errors.New("too many concurrent subscriptions")
+
+// GetDecentralizedAccountActivitiesStream streams the new activities of the account as Server-Sent Events.
+// The qualified Nodes are queried periodically and only activities not pushed before are sent,
+// the first query is a baseline unless since_timestamp is set.
+func (d *DSL) GetDecentralizedAccountActivitiesStream(c echo.Context) (err error) {
+	var request dsl.ActivitiesRequest
+
+	if err = c.Bind(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if request.Type, err = parseTypes(c.QueryParams()["type"], request.Tag); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err = defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err = c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	workers, networks, err := validateCombinedParams(request.Tag, request.Network, request.Platform)
+	if err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	// Resolve name to EVM address
+	if !validEvmAddress(request.Account) {
+		resolvedName, err := d.getEVMAddress(c.Request().Context(), request.Account)
+		if err == nil {
+			request.Account = resolvedName
+		}
+	}
+
+	client := c.RealIP()
+
+	if !d.subscriptions.acquire(client) {
+		return errorx.TooManyRequestsError(c, errTooManySubscriptions)
+	}
+
+	defer d.subscriptions.release(client)
+
+	incrementRequestCounter("GetDecentralizedAccountActivitiesStream", request.Network, request.Tag, request.Platform)
+
+	// Every query starts from the latest activities.
+	request.Cursor = nil
+
+	params := make(url.Values, len(c.QueryParams()))
+
+	for key, values := range c.QueryParams() {
+		if key != "cursor" {
+			params[key] = values
+		}
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	// The response cache would serve the same activities until it expires,
+	// and the polls of the stream are not counted toward the rewards of the Nodes.
+	ctx := distributor.WithoutCounting(distributor.WithCacheBypass(c.Request().Context()))
+
+	seen := newSeenActivities(streamSeenSize)
+	baseline := request.SinceTimestamp == nil
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		activities, err := d.pollActivities(ctx, request, params, workers, networks)
+
+		switch {
+		case err != nil:
+			zap.L().Error("poll stream activities", zap.Error(err), zap.String("account", request.Account))
+
+			err = writeStreamComment(response, "keep-alive")
+		case baseline:
+			for _, activity := range activities {
+				seen.add(activity.ID)
+			}
+
+			err = writeStreamComment(response, "keep-alive")
+		default:
+			err = writeStreamActivities(response, seen, activities)
+		}
+
+		if err != nil {
+			// The client is gone.
+			return nil
+		}
+
+		baseline = false
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamActivity is an activity pushed to the stream, the activity is kept raw so that it is pushed as is.
+type streamActivity struct {
+	ID   string
+	Data json.RawMessage
+}
+
+// pollActivities queries the qualified Nodes for the latest activities of the account, in descending order of timestamp.
+func (d *DSL) pollActivities(ctx context.Context, request dsl.ActivitiesRequest, params url.Values, workers, networks []string) ([]streamActivity, error) {
+	data, err := d.distributor.DistributeData(ctx, model.DistributorRequestAccountActivities, model.ComponentDecentralized, request, params, workers, networks)
+	if err != nil {
+		return nil, fmt.Errorf("distribute activities data: %w", err)
+	}
+
+	var response struct {
+		Data []json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("unmarshal activities: %w", err)
+	}
+
+	activities := make([]streamActivity, 0, len(response.Data))
+
+	for _, data := range response.Data {
+		var activity struct {
+			ID string `json:"id"`
+		}
+
+		if err := json.Unmarshal(data, &activity); err != nil {
+			return nil, fmt.Errorf("unmarshal activity: %w", err)
+		}
+
+		activities = append(activities, streamActivity{ID: activity.ID, Data: data})
+	}
+
+	return activities, nil
+}
+
+// writeStreamActivities pushes the activities not seen before, oldest first.
+func writeStreamActivities(response *echo.Response, seen *seenActivities, activities []streamActivity) error {
+	var pushed bool
+
+	for i := len(activities) - 1; i >= 0; i-- {
+		if !seen.add(activities[i].ID) {
+			continue
+		}
+
+		if _, err := fmt.Fprintf(response, "id: %s\nevent: activity\ndata: %s\n\n", activities[i].ID, activities[i].Data); err != nil {
+			return err
+		}
+
+		pushed = true
+	}
+
+	if !pushed {
+		return writeStreamComment(response, "keep-alive")
+	}
+
+	response.Flush()
+
+	return nil
+}
+
+// writeStreamComment writes a comment ignored by the clients, keeping the connection alive.
+func writeStreamComment(response *echo.Response, comment string) error {
+	if _, err := fmt.Fprintf(response, ": %s\n\n", comment); err != nil {
+		return err
+	}
+
+	response.Flush()
+
+	return nil
+}
+
+// seenActivities is a bounded set of activity IDs, the oldest IDs are evicted first.
+type seenActivities struct {
+	size  int
+	ids   map[string]struct{}
+	order []string
+}
+
+func newSeenActivities(size int) *seenActivities {
+	return &seenActivities{
+		size: size,
+		ids:  make(map[string]struct{}, size),
+	}
+}
+
+// add adds the ID to the set and returns whether it was not in the set.
+func (s *seenActivities) add(id string) bool {
+	if _, exists := s.ids[id]; exists {
+		return false
+	}
+
+	if len(s.order) >= s.size {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	s.ids[id] = struct{}{}
+	s.order = append(s.order, id)
+
+	return true
+}
+
+// subscriptions counts the concurrent streams of each client.
+type subscriptions struct {
+	limit int
+
+	mu      sync.Mutex
+	clients map[string]int
+}
+
+func newSubscriptions(limit int) *subscriptions {
+	return &subscriptions{
+		limit:   limit,
+		clients: make(map[string]int),
+	}
+}
+
+// acquire returns whether the client can open another stream, and counts it if so.
+func (s *subscriptions) acquire(client string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.clients[client] >= s.limit {
+		return false
+	}
+
+	s.clients[client]++
+
+	return true
+}
+
+func (s *subscriptions) release(client string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.clients[client]--; s.clients[client] <= 0 {
+		delete(s.clients, client)
+	}
+}
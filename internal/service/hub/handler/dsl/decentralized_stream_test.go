@@ -0,0 +1,38 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeenActivities(t *testing.T) {
+	t.Parallel()
+
+	seen := newSeenActivities(2)
+
+	assert.True(t, seen.add("a"))
+	assert.True(t, seen.add("b"))
+	assert.False(t, seen.add("a"))
+
+	// The oldest ID is evicted once the set is full.
+	assert.True(t, seen.add("c"))
+	assert.True(t, seen.add("a"))
+	assert.False(t, seen.add("c"))
+}
+
+func TestSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	subscriptions := newSubscriptions(2)
+
+	assert.True(t, subscriptions.acquire("127.0.0.1"))
+	assert.True(t, subscriptions.acquire("127.0.0.1"))
+	assert.False(t, subscriptions.acquire("127.0.0.1"))
+
+	// The limit applies per client.
+	assert.True(t, subscriptions.acquire("127.0.0.2"))
+
+	subscriptions.release("127.0.0.1")
+	assert.True(t, subscriptions.acquire("127.0.0.1"))
+}
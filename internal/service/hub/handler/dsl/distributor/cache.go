@@ -0,0 +1,135 @@
+package distributor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"go.uber.org/zap"
+)
+
+// HeaderCacheBypass is the request header that skips the response cache when set to a non-empty value.
+const HeaderCacheBypass = "X-Cache-Bypass"
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context that makes the Distributor skip the response cache.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func isCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+
+	return bypass
+}
+
+// cachedResponse is a Node response stored in the response cache.
+type cachedResponse struct {
+	Data     []byte `json:"data"`
+	CachedAt int64  `json:"cached_at"`
+}
+
+type fetchFunc func(ctx context.Context) ([]byte, error)
+
+// responseCacheTTL returns the TTL of cached responses for the request type and whether the request is cacheable.
+func (d *Distributor) responseCacheTTL(ctx context.Context, requestType string) (time.Duration, bool) {
+	if d.cacheConfig == nil || !d.cacheConfig.Enable || isCacheBypassed(ctx) {
+		return 0, false
+	}
+
+	ttl := d.cacheConfig.TTL
+
+	if routeTTL, exists := d.cacheConfig.Routes[requestType]; exists {
+		ttl = routeTTL
+	}
+
+	return ttl, ttl > 0
+}
+
+// buildResponseCacheKey builds the cache key from the normalized path, query and body of the request.
+func (d *Distributor) buildResponseCacheKey(requestType, component string, request interface{}, params url.Values) (string, error) {
+	method, path, body, err := buildRequest(requestType, component, request)
+	if err != nil {
+		return "", err
+	}
+
+	// url.Values.Encode sorts the keys, the values of each key are sorted here
+	// so that the order of repeated query parameters does not matter.
+	query := make(url.Values, len(params))
+
+	for key, values := range params {
+		query[key] = slices.Clone(values)
+		slices.Sort(query[key])
+	}
+
+	hash := sha256.New()
+
+	for _, part := range [][]byte{[]byte(method), []byte(path), []byte(query.Encode()), body} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+
+	return model.DistributorResponsePrefixCacheKey + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// distributeCachedData serves the request from the response cache, fetching it from the Nodes on a miss.
+// A stale response is served while it is refreshed in the background.
+// Cached responses never reach the Nodes, so they are not verified and do not count towards Node requests.
+func (d *Distributor) distributeCachedData(ctx context.Context, key string, ttl time.Duration, fetch fetchFunc) ([]byte, error) {
+	var response cachedResponse
+
+	if err := d.cacheClient.Get(ctx, key, &response); err == nil {
+		if time.Since(time.UnixMilli(response.CachedAt)) >= ttl {
+			go d.refreshResponseCache(context.WithoutCancel(ctx), key, ttl, fetch)
+		}
+
+		return response.Data, nil
+	} else if !errors.Is(err, redis.Nil) {
+		zap.L().Error("get cached response", zap.String("key", key), zap.Error(err))
+	}
+
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d.saveResponseCache(ctx, key, ttl, data)
+
+	return data, nil
+}
+
+// refreshResponseCache fetches the request from the Nodes and updates the cached response.
+// Concurrent refreshes of the same key are collapsed into one.
+func (d *Distributor) refreshResponseCache(ctx context.Context, key string, ttl time.Duration, fetch fetchFunc) {
+	_, _, _ = d.refreshGroup.Do(key, func() (interface{}, error) {
+		data, err := fetch(ctx)
+		if err != nil {
+			zap.L().Error("refresh cached response", zap.String("key", key), zap.Error(err))
+
+			return nil, err
+		}
+
+		d.saveResponseCache(ctx, key, ttl, data)
+
+		return nil, nil
+	})
+}
+
+// saveResponseCache stores the response, keeping it for the stale period after the TTL.
+func (d *Distributor) saveResponseCache(ctx context.Context, key string, ttl time.Duration, data []byte) {
+	response := cachedResponse{
+		Data:     data,
+		CachedAt: time.Now().UnixMilli(),
+	}
+
+	if err := d.cacheClient.Set(ctx, key, response, ttl+d.cacheConfig.StaleTTL); err != nil {
+		zap.L().Error("save cached response", zap.String("key", key), zap.Error(err))
+	}
+}
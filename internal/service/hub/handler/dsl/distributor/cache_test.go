@@ -0,0 +1,162 @@
+package distributor
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-memory cache.Client supporting Get and Set.
+type memoryCache struct {
+	cache.Client
+
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, exists := m.values[key]
+	if !exists {
+		return redis.Nil
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[key] = data
+
+	return nil
+}
+
+func newCachedDistributor(cacheConfig *config.DistributorCache) *Distributor {
+	return &Distributor{
+		cacheClient: &memoryCache{values: make(map[string][]byte)},
+		cacheConfig: cacheConfig,
+	}
+}
+
+func TestDistributor_ResponseCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	d := newCachedDistributor(&config.DistributorCache{
+		Enable: true,
+		TTL:    time.Minute,
+		Routes: map[string]time.Duration{
+			model.DistributorRequestActivity:          time.Hour,
+			model.DistributorRequestNetworkActivities: 0,
+		},
+	})
+
+	ttl, ok := d.responseCacheTTL(context.Background(), model.DistributorRequestAccountActivities)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, ttl)
+
+	ttl, ok = d.responseCacheTTL(context.Background(), model.DistributorRequestActivity)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, ttl)
+
+	_, ok = d.responseCacheTTL(context.Background(), model.DistributorRequestNetworkActivities)
+	assert.False(t, ok)
+
+	_, ok = d.responseCacheTTL(WithCacheBypass(context.Background()), model.DistributorRequestActivity)
+	assert.False(t, ok)
+
+	_, ok = newCachedDistributor(nil).responseCacheTTL(context.Background(), model.DistributorRequestActivity)
+	assert.False(t, ok)
+}
+
+func TestDistributor_BuildResponseCacheKey(t *testing.T) {
+	t.Parallel()
+
+	d := newCachedDistributor(nil)
+	request := dsl.ActivitiesRequest{Account: "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"}
+
+	key, err := d.buildResponseCacheKey(model.DistributorRequestAccountActivities, model.ComponentDecentralized, request, url.Values{
+		"network": []string{"ethereum", "farcaster"},
+		"limit":   []string{"20"},
+	})
+	require.NoError(t, err)
+
+	reorderedKey, err := d.buildResponseCacheKey(model.DistributorRequestAccountActivities, model.ComponentDecentralized, request, url.Values{
+		"limit":   []string{"20"},
+		"network": []string{"farcaster", "ethereum"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, key, reorderedKey)
+
+	otherKey, err := d.buildResponseCacheKey(model.DistributorRequestAccountActivities, model.ComponentDecentralized, request, url.Values{
+		"limit": []string{"20"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+}
+
+func TestDistributor_DistributeCachedData(t *testing.T) {
+	t.Parallel()
+
+	d := newCachedDistributor(&config.DistributorCache{
+		Enable:   true,
+		StaleTTL: time.Minute,
+	})
+
+	var fetches atomic.Int32
+
+	refreshed := make(chan struct{}, 1)
+
+	fetch := func(_ context.Context) ([]byte, error) {
+		if fetches.Add(1) > 1 {
+			defer func() { refreshed <- struct{}{} }()
+		}
+
+		return []byte(`{"data":null}`), nil
+	}
+
+	// A miss fetches from the Nodes.
+	data, err := d.distributeCachedData(context.Background(), "key", time.Minute, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":null}`, string(data))
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// A fresh hit does not reach the Nodes.
+	data, err = d.distributeCachedData(context.Background(), "key", time.Minute, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":null}`, string(data))
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// A stale hit is served and refreshed in the background.
+	data, err = d.distributeCachedData(context.Background(), "key", 0, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":null}`, string(data))
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale response was not refreshed")
+	}
+
+	assert.Equal(t, int32(2), fetches.Load())
+}
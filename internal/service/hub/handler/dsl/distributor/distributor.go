@@ -22,6 +22,7 @@ import (
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
 type Distributor struct {
@@ -29,6 +30,9 @@ type Distributor struct {
 	simpleRouter   *router.SimpleRouter
 	databaseClient database.Client
 	cacheClient    cache.Client
+	cacheConfig    *config.DistributorCache
+	refreshGroup   singleflight.Group
+	batchShardSize int
 }
 
 // DistributeRSSHubData distributes RSSHub requests to qualified Nodes.
@@ -45,7 +49,7 @@ func (d *Distributor) DistributeRSSHubData(ctx context.Context, path, query stri
 		return nil, err
 	}
 
-	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, d.processRSSHubResponses)
+	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, model.DistributorRequestRSSHub, nodeMap, d.processRSSHubResponses)
 
 	if err != nil {
 		return nil, err
@@ -73,13 +77,41 @@ func (d *Distributor) generateRSSHubPath(param, query string, nodes []*model.Nod
 type nodeRetriever func(ctx context.Context, workers, networks []string) ([]*model.NodeEndpointCache, error)
 type responseProcessor func([]*model.DataResponse)
 
-// DistributeData distributes requests to qualified Nodes.
+// DistributeData distributes requests to qualified Nodes, serving them from the response cache when enabled.
+// Batch requests with more accounts than a shard holds are split into shards.
 func (d *Distributor) DistributeData(ctx context.Context, requestType, component string, request interface{}, params url.Values, workers, networks []string) ([]byte, error) {
+	fetch := func(ctx context.Context) ([]byte, error) {
+		if d.isShardedRequest(request) {
+			return d.distributeShardedData(ctx, requestType, component, request.(dsl.AccountsActivitiesRequest), params, workers, networks)
+		}
+
+		return d.distributeData(ctx, requestType, component, request, params, workers, networks)
+	}
+
+	ttl, ok := d.responseCacheTTL(ctx, requestType)
+	if !ok {
+		return fetch(ctx)
+	}
+
+	key, err := d.buildResponseCacheKey(requestType, component, request, params)
+	if err != nil {
+		return nil, fmt.Errorf("build response cache key: %w", err)
+	}
+
+	return d.distributeCachedData(ctx, key, ttl, fetch)
+}
+
+// distributeData distributes requests to qualified Nodes.
+func (d *Distributor) distributeData(ctx context.Context, requestType, component string, request interface{}, params url.Values, workers, networks []string) ([]byte, error) {
 	retriever, processor, err := d.getStrategyForRequest(requestType, component, request)
 	if err != nil {
 		return nil, fmt.Errorf("get strategy for request: %w", err)
 	}
 
+	if isUncounted(ctx) {
+		processor = processUncountedResponses
+	}
+
 	nodes, err := retriever(ctx, workers, networks)
 	if err != nil {
 		return nil, fmt.Errorf("retrieving nodes: %w", err)
@@ -94,7 +126,7 @@ func (d *Distributor) DistributeData(ctx context.Context, requestType, component
 		return nil, fmt.Errorf("generate path: %w", err)
 	}
 
-	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, nodeMap, processor)
+	nodeResponse, err := d.simpleRouter.DistributeRequest(ctx, requestType, nodeMap, processor)
 	if err != nil {
 		return nil, fmt.Errorf("distribute request: %w", err)
 	}
@@ -209,13 +241,22 @@ func extractFediverseAddress(activityURL string) (string, error) {
 
 // generatePath builds the path for distributor requests.
 func (d *Distributor) generatePath(requestType, component string, request interface{}, params url.Values, nodes []*model.NodeEndpointCache) (map[common.Address]model.RequestMeta, error) {
-	var (
-		path   string
-		method = http.MethodGet
+	method, path, body, err := buildRequest(requestType, component, request)
+	if err != nil {
+		return nil, err
+	}
 
-		body []byte
-		err  error
-	)
+	endpointMap, err := d.simpleRouter.BuildPath(method, path, params, nodes, body)
+	if err != nil {
+		return nil, fmt.Errorf("build path: %w", err)
+	}
+
+	return endpointMap, nil
+}
+
+// buildRequest returns the method, path and body sent to the Nodes for distributor requests.
+func buildRequest(requestType, component string, request interface{}) (method, path string, body []byte, err error) {
+	method = http.MethodGet
 
 	switch req := request.(type) {
 	case dsl.ActivityRequest:
@@ -228,36 +269,38 @@ func (d *Distributor) generatePath(requestType, component string, request interf
 		body, err = json.Marshal(req)
 
 		if err != nil {
-			return nil, fmt.Errorf("marshal request data: %w", err)
+			return "", "", nil, fmt.Errorf("marshal request data: %w", err)
 		}
 	case dsl.NetworkActivitiesRequest:
 		path = fmt.Sprintf("/%s/network/%s", component, req.Network)
 	case dsl.PlatformActivitiesRequest:
 		path = fmt.Sprintf("/%s/platform/%s", component, req.Platform)
 	default:
-		return nil, fmt.Errorf("invalid request type: %s", requestType)
-	}
-
-	endpointMap, err := d.simpleRouter.BuildPath(method, path, params, nodes, body)
-	if err != nil {
-		return nil, fmt.Errorf("build path: %w", err)
+		return "", "", nil, fmt.Errorf("invalid request type: %s", requestType)
 	}
 
-	return endpointMap, nil
+	return method, path, body, nil
 }
 
 // NewDistributor creates a new distributor.
-func NewDistributor(ctx context.Context, database database.Client, cache cache.Client, httpClient httputil.Client, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, txManager *txmgr.SimpleTxManager, settlerConfig *config.Settler, chainID *big.Int) (*Distributor, error) {
+func NewDistributor(ctx context.Context, database database.Client, cache cache.Client, httpClient httputil.Client, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, txManager *txmgr.SimpleTxManager, settlerConfig *config.Settler, distributorConfig *config.Distributor, chainID *big.Int) (*Distributor, error) {
 	simpleEnforcer, err := enforcer.NewSimpleEnforcer(ctx, database, cache, stakingContract, networkParamsContract, httpClient, txManager, settlerConfig, chainID, true)
 
 	if err != nil {
 		return nil, err
 	}
 
+	simpleRouter, err := router.NewSimpleRouter(httpClient, cache, distributorConfig.Routing)
+	if err != nil {
+		return nil, fmt.Errorf("new simple router: %w", err)
+	}
+
 	return &Distributor{
 		simpleEnforcer: simpleEnforcer,
-		simpleRouter:   router.NewSimpleRouter(httpClient),
+		simpleRouter:   simpleRouter,
 		databaseClient: database,
 		cacheClient:    cache,
+		cacheConfig:    distributorConfig.Cache,
+		batchShardSize: distributorConfig.BatchShardSize,
 	}, nil
 }
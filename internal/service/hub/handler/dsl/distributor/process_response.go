@@ -11,11 +11,28 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+type uncountedKey struct{}
+
+// WithoutCounting returns a context that makes the Distributor neither verify the responses nor count the requests toward the rewards of the Nodes.
+func WithoutCounting(ctx context.Context) context.Context {
+	return context.WithValue(ctx, uncountedKey{}, true)
+}
+
+func isUncounted(ctx context.Context) bool {
+	uncounted, _ := ctx.Value(uncountedKey{}).(bool)
+
+	return uncounted
+}
+
+// processUncountedResponses ignores the responses of the requests not counted toward the rewards.
+func processUncountedResponses(_ []*model.DataResponse) {}
+
 // processRSSHubResponses processes responses for RSSHub requests.
 func (d *Distributor) processRSSHubResponses(_ []*model.DataResponse) {
 	// No rewards or slash for RSS responses due to unstable RSSHub server.
@@ -195,5 +212,11 @@ func (d *Distributor) saveInvalidResponses(ctx context.Context, epochID uint64,
 
 	if err := d.databaseClient.SaveNodeInvalidResponses(ctx, nodeInvalidResponses); err != nil {
 		zap.L().Error("save node invalid response", zap.Error(err))
+
+		return
+	}
+
+	if err := webhook.EmitNodeInvalidResponses(ctx, d.databaseClient, nodeInvalidResponses); err != nil {
+		zap.L().Error("emit node invalid response events", zap.Error(err))
 	}
 }
@@ -72,6 +72,7 @@ func (d *Distributor) generateQualifiedNodeCache(ctx context.Context, nodeAddres
 			Address:     stat.Address.String(),
 			Endpoint:    stat.Endpoint,
 			AccessToken: stat.AccessToken,
+			Score:       stat.Score,
 		}
 	}
 
@@ -121,6 +122,7 @@ func (d *Distributor) getFederatedQualifiedNodes(ctx context.Context, account st
 			Address:     stat.Address.String(),
 			Endpoint:    stat.Endpoint,
 			AccessToken: stat.AccessToken,
+			Score:       stat.Score,
 		}
 	}
 
@@ -160,6 +162,7 @@ func (d *Distributor) getFederatedDefaultNodes(ctx context.Context) ([]*model.No
 			Address:     stat.Address.String(),
 			Endpoint:    stat.Endpoint,
 			AccessToken: stat.AccessToken,
+			Score:       stat.Score,
 		}
 	}
 
@@ -0,0 +1,213 @@
+package distributor
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/dsl"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+)
+
+// shardedCursor is the cursor of a sharded request, it holds the Node cursor of each shard.
+type shardedCursor struct {
+	Shards []shardCursor `json:"shards"`
+}
+
+type shardCursor struct {
+	// Cursor is the Node cursor of the last activity returned from the shard.
+	Cursor string `json:"cursor,omitempty"`
+	// Done is set once all activities of the shard have been returned.
+	Done bool `json:"done,omitempty"`
+}
+
+// shardActivities is the response of a shard, the activities are kept raw so that they are returned as is.
+type shardActivities struct {
+	Data []json.RawMessage `json:"data"`
+	Meta *model.MetaCursor `json:"meta,omitempty"`
+}
+
+// activityOrder holds the fields of an activity used to merge and resume the shards.
+type activityOrder struct {
+	ID        string `json:"id"`
+	Network   string `json:"network"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// shardAccounts splits the sorted accounts into shards of at most size accounts.
+func shardAccounts(accounts []string, size int) [][]string {
+	accounts = slices.Clone(accounts)
+	slices.Sort(accounts)
+
+	return lo.Chunk(accounts, size)
+}
+
+// isShardedRequest returns whether the request has more accounts than a shard holds.
+func (d *Distributor) isShardedRequest(request interface{}) bool {
+	accountsRequest, ok := request.(dsl.AccountsActivitiesRequest)
+
+	return ok && d.batchShardSize > 0 && len(accountsRequest.Accounts) > d.batchShardSize
+}
+
+// distributeShardedData splits the accounts of the request into shards distributed to qualified Nodes in parallel,
+// then merges the activities of all shards by timestamp and returns a cursor resuming every shard.
+func (d *Distributor) distributeShardedData(ctx context.Context, requestType, component string, request dsl.AccountsActivitiesRequest, params url.Values, workers, networks []string) ([]byte, error) {
+	shards := shardAccounts(request.Accounts, d.batchShardSize)
+
+	cursor, err := decodeShardedCursor(request.Cursor, len(shards))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*shardActivities, len(shards))
+	shardPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
+
+	for i, accounts := range shards {
+		if cursor.Shards[i].Done {
+			continue
+		}
+
+		shardRequest := request
+		shardRequest.Accounts = accounts
+		shardRequest.Cursor = lo.EmptyableToPtr(cursor.Shards[i].Cursor)
+
+		shardPool.Go(func(ctx context.Context) error {
+			data, err := d.distributeData(ctx, requestType, component, shardRequest, params, workers, networks)
+			if err != nil {
+				return fmt.Errorf("distribute shard %d: %w", i, err)
+			}
+
+			var response shardActivities
+			if err := json.Unmarshal(data, &response); err != nil {
+				return fmt.Errorf("unmarshal shard %d: %w", i, err)
+			}
+
+			responses[i] = &response
+
+			return nil
+		})
+	}
+
+	if err := shardPool.Wait(); err != nil {
+		return nil, err
+	}
+
+	activities, err := mergeShardActivities(responses, cursor, request.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := shardActivities{Data: activities}
+
+	if !lo.EveryBy(cursor.Shards, func(shard shardCursor) bool { return shard.Done }) {
+		encodedCursor, err := encodeShardedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Meta = &model.MetaCursor{Cursor: encodedCursor}
+	}
+
+	return json.Marshal(response)
+}
+
+// mergeShardActivities merges the activities of the shards by timestamp in descending order up to limit,
+// keeping the order of the activities within each shard, and advances the cursor of each shard.
+func mergeShardActivities(responses []*shardActivities, cursor *shardedCursor, limit int) ([]json.RawMessage, error) {
+	orders := make([][]activityOrder, len(responses))
+
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+
+		orders[i] = make([]activityOrder, len(response.Data))
+
+		for j, activity := range response.Data {
+			if err := json.Unmarshal(activity, &orders[i][j]); err != nil {
+				return nil, fmt.Errorf("unmarshal activity of shard %d: %w", i, err)
+			}
+		}
+	}
+
+	var (
+		activities = make([]json.RawMessage, 0, limit)
+		consumed   = make([]int, len(responses))
+	)
+
+	for len(activities) < limit {
+		next := -1
+
+		for i := range responses {
+			if consumed[i] >= len(orders[i]) {
+				continue
+			}
+
+			if next == -1 || orders[i][consumed[i]].Timestamp > orders[next][consumed[next]].Timestamp {
+				next = i
+			}
+		}
+
+		if next == -1 {
+			break
+		}
+
+		activities = append(activities, responses[next].Data[consumed[next]])
+		consumed[next]++
+	}
+
+	for i, response := range responses {
+		if response == nil {
+			continue
+		}
+
+		if consumed[i] > 0 {
+			last := orders[i][consumed[i]-1]
+			cursor.Shards[i].Cursor = fmt.Sprintf("%s:%s", last.ID, last.Network)
+		}
+
+		// The shard is done once all of its activities are returned and the Node has no further page.
+		if consumed[i] == len(orders[i]) && (response.Meta == nil || response.Meta.Cursor == "") {
+			cursor.Shards[i].Done = true
+		}
+	}
+
+	return activities, nil
+}
+
+func decodeShardedCursor(cursor *string, shards int) (*shardedCursor, error) {
+	if cursor == nil || *cursor == "" {
+		return &shardedCursor{Shards: make([]shardCursor, shards)}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(*cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errorx.ErrInvalidCursor, err)
+	}
+
+	var decodedCursor shardedCursor
+	if err := json.Unmarshal(data, &decodedCursor); err != nil {
+		return nil, fmt.Errorf("%w: %w", errorx.ErrInvalidCursor, err)
+	}
+
+	if len(decodedCursor.Shards) != shards {
+		return nil, fmt.Errorf("%w: expected %d shards, got %d", errorx.ErrInvalidCursor, shards, len(decodedCursor.Shards))
+	}
+
+	return &decodedCursor, nil
+}
+
+func encodeShardedCursor(cursor *shardedCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,86 @@
+package distributor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardAccounts(t *testing.T) {
+	t.Parallel()
+
+	shards := shardAccounts([]string{"0xc", "0xa", "0xe", "0xb", "0xd"}, 2)
+
+	assert.Equal(t, [][]string{{"0xa", "0xb"}, {"0xc", "0xd"}, {"0xe"}}, shards)
+}
+
+func TestShardedCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor, err := decodeShardedCursor(nil, 2)
+	require.NoError(t, err)
+	assert.Len(t, cursor.Shards, 2)
+
+	cursor.Shards[0] = shardCursor{Cursor: "0x1:ethereum"}
+	cursor.Shards[1] = shardCursor{Done: true}
+
+	encodedCursor, err := encodeShardedCursor(cursor)
+	require.NoError(t, err)
+
+	decodedCursor, err := decodeShardedCursor(lo.ToPtr(encodedCursor), 2)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decodedCursor)
+
+	_, err = decodeShardedCursor(lo.ToPtr(encodedCursor), 3)
+	assert.ErrorIs(t, err, errorx.ErrInvalidCursor)
+
+	_, err = decodeShardedCursor(lo.ToPtr("0x1:ethereum"), 2)
+	assert.ErrorIs(t, err, errorx.ErrInvalidCursor)
+}
+
+func TestMergeShardActivities(t *testing.T) {
+	t.Parallel()
+
+	responses := []*shardActivities{
+		{
+			Data: []json.RawMessage{
+				json.RawMessage(`{"id":"0x1","network":"ethereum","timestamp":5}`),
+				json.RawMessage(`{"id":"0x2","network":"ethereum","timestamp":3}`),
+			},
+		},
+		{
+			Data: []json.RawMessage{
+				json.RawMessage(`{"id":"0x3","network":"farcaster","timestamp":4}`),
+				json.RawMessage(`{"id":"0x4","network":"farcaster","timestamp":1}`),
+			},
+			Meta: &model.MetaCursor{Cursor: "0x4:farcaster"},
+		},
+		// The shard is done since a previous page.
+		nil,
+	}
+
+	cursor := &shardedCursor{Shards: []shardCursor{{}, {}, {Done: true}}}
+
+	activities, err := mergeShardActivities(responses, cursor, 3)
+	require.NoError(t, err)
+
+	ids := lo.Map(activities, func(activity json.RawMessage, _ int) string {
+		var order activityOrder
+
+		require.NoError(t, json.Unmarshal(activity, &order))
+
+		return order.ID
+	})
+
+	assert.Equal(t, []string{"0x1", "0x3", "0x2"}, ids)
+
+	// The first shard has returned all of its activities, the second one resumes after the last returned activity.
+	assert.Equal(t, shardCursor{Cursor: "0x2:ethereum", Done: true}, cursor.Shards[0])
+	assert.Equal(t, shardCursor{Cursor: "0x3:farcaster"}, cursor.Shards[1])
+	assert.Equal(t, shardCursor{Done: true}, cursor.Shards[2])
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 
+	"github.com/labstack/echo/v4"
 	"github.com/rss3-network/global-indexer/common/httputil"
 	"github.com/rss3-network/global-indexer/common/txmgr"
 	"github.com/rss3-network/global-indexer/contract/l2"
@@ -19,10 +20,11 @@ type DSL struct {
 	databaseClient database.Client
 	cacheClient    cache.Client
 	nameService    *nameresolver.NameResolver
+	subscriptions  *subscriptions
 }
 
-func NewDSL(ctx context.Context, databaseClient database.Client, cacheClient cache.Client, nameService *nameresolver.NameResolver, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, httpClient httputil.Client, txManager *txmgr.SimpleTxManager, settlerConfig *config.Settler, chainID *big.Int) (*DSL, error) {
-	distributorService, err := distributor.NewDistributor(ctx, databaseClient, cacheClient, httpClient, stakingContract, networkParamsContract, txManager, settlerConfig, chainID)
+func NewDSL(ctx context.Context, databaseClient database.Client, cacheClient cache.Client, nameService *nameresolver.NameResolver, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, httpClient httputil.Client, txManager *txmgr.SimpleTxManager, settlerConfig *config.Settler, distributorConfig *config.Distributor, chainID *big.Int) (*DSL, error) {
+	distributorService, err := distributor.NewDistributor(ctx, databaseClient, cacheClient, httpClient, stakingContract, networkParamsContract, txManager, settlerConfig, distributorConfig, chainID)
 	if err != nil {
 		return nil, err
 	}
@@ -32,5 +34,19 @@ func NewDSL(ctx context.Context, databaseClient database.Client, cacheClient cac
 		databaseClient: databaseClient,
 		cacheClient:    cacheClient,
 		nameService:    nameService,
+		subscriptions:  newSubscriptions(distributorConfig.StreamSubscriptionLimit),
 	}, nil
 }
+
+// CacheBypass skips the Distributor response cache for requests carrying the bypass header or Cache-Control: no-cache.
+func (d *DSL) CacheBypass(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		header := c.Request().Header
+
+		if header.Get(distributor.HeaderCacheBypass) != "" || header.Get(echo.HeaderCacheControl) == "no-cache" {
+			c.SetRequest(c.Request().WithContext(distributor.WithCacheBypass(c.Request().Context())))
+		}
+
+		return next(c)
+	}
+}
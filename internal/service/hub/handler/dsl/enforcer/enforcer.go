@@ -153,7 +153,15 @@ func (e *SimpleEnforcer) MaintainNodeStatus(ctx context.Context) error {
 	return nil
 }
 
-func (e *SimpleEnforcer) ChallengeStates(_ context.Context) error {
+// ChallengeStates resolves the appeals raised by Nodes against their recorded invalid responses.
+// The invalid responses with a pending or upheld appeal are not counted toward DemotionCountBeforeSlashing.
+func (e *SimpleEnforcer) ChallengeStates(ctx context.Context) error {
+	if err := e.resolvePendingAppeals(ctx); err != nil {
+		return err
+	}
+
+	zap.L().Info("challenge states completed")
+
 	return nil
 }
 
@@ -188,7 +196,7 @@ func NewSimpleEnforcer(ctx context.Context, databaseClient database.Client, cach
 		stakingContract:       stakingContract,
 		networkParamsContract: networkParamsContract,
 		httpClient:            httpClient,
-		txManager:             txManager,
+		txManager:             txManager.WithService(txmgr.ServiceEnforcer),
 		settlerConfig:         settlerConfig,
 		chainID:               chainID,
 	}
@@ -0,0 +1,235 @@
+package enforcer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const activityPathPrefix = "/decentralized/tx/"
+
+// resolvePendingAppeals re-verifies every pending appeal and counts the demotions of the appealing Nodes again.
+func (e *SimpleEnforcer) resolvePendingAppeals(ctx context.Context) error {
+	query := schema.NodeInvalidResponsesQuery{
+		AppealStatus: lo.ToPtr(schema.NodeInvalidResponseAppealStatusPending),
+		Limit:        lo.ToPtr(defaultLimit),
+	}
+
+	for {
+		invalidResponses, err := e.databaseClient.FindNodeInvalidResponses(ctx, query)
+		if err != nil {
+			return fmt.Errorf("find pending appeals: %w", err)
+		}
+
+		if len(invalidResponses) == 0 {
+			return nil
+		}
+
+		for _, invalidResponse := range invalidResponses {
+			if err := e.resolveAppeal(ctx, invalidResponse); err != nil {
+				zap.L().Error("resolve appeal", zap.Error(err), zap.Uint64("id", invalidResponse.ID), zap.String("node", invalidResponse.Node.String()))
+			}
+		}
+
+		query.Cursor = lo.ToPtr(invalidResponses[len(invalidResponses)-1].ID)
+	}
+}
+
+// resolveAppeal upholds the appeal if the other Nodes now agree with the response of the Node.
+// The appeal is left pending to be resolved again when it cannot be verified for now.
+// The demotions of the Node are counted again either way, as the pending and upheld appeals are not counted.
+func (e *SimpleEnforcer) resolveAppeal(ctx context.Context, invalidResponse *schema.NodeInvalidResponse) error {
+	upheld, err := e.reverifyInvalidResponse(ctx, invalidResponse)
+	if err != nil {
+		if err := e.recountDemotions(ctx, invalidResponse.Node, invalidResponse.EpochID); err != nil {
+			return fmt.Errorf("recount demotions: %w", err)
+		}
+
+		return fmt.Errorf("reverify invalid response: %w", err)
+	}
+
+	status := lo.Ternary(upheld, schema.NodeInvalidResponseAppealStatusUpheld, schema.NodeInvalidResponseAppealStatusRejected)
+
+	if err = e.databaseClient.UpdateNodeInvalidResponseAppealStatus(ctx, invalidResponse.ID, status); err != nil {
+		// The appeal has been resolved by another enforcer.
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("update appeal status: %w", err)
+	}
+
+	return e.recountDemotions(ctx, invalidResponse.Node, invalidResponse.EpochID)
+}
+
+// reverifyInvalidResponse verifies the stored response of the Node again with the activity fetched from other qualified Nodes,
+// the response is valid if the majority of them return an identical activity.
+// The response cannot be fixed afterward by the Node, so only the inconsistent responses of activities are verified again.
+func (e *SimpleEnforcer) reverifyInvalidResponse(ctx context.Context, invalidResponse *schema.NodeInvalidResponse) (bool, error) {
+	if invalidResponse.Type != schema.NodeInvalidResponseTypeInconsistent {
+		return false, nil
+	}
+
+	index := strings.LastIndex(invalidResponse.Request, activityPathPrefix)
+	if index < 0 {
+		return false, nil
+	}
+
+	var nodeActivity model.Activity
+
+	// The activities without a platform cannot be attributed to the workers verifying them.
+	if err := json.Unmarshal(invalidResponse.Response, &nodeActivity); err != nil || len(nodeActivity.Platform) == 0 {
+		return false, nil
+	}
+
+	stats, err := e.findStatsByPlatform(ctx, &nodeActivity, []common.Address{invalidResponse.Node})
+	if err != nil {
+		return false, fmt.Errorf("find verifiers: %w", err)
+	}
+
+	var verifiedCount, identicalCount int
+
+	for _, stat := range stats {
+		if verifiedCount == model.RequiredVerificationCount {
+			break
+		}
+
+		activityFetched, err := e.fetchActivityByTxID(ctx, stat.Endpoint, stat.AccessToken, invalidResponse.Request[index+len(activityPathPrefix):])
+		if err != nil || activityFetched.Data == nil {
+			continue
+		}
+
+		verifiedCount++
+
+		if isActivityIdentical(activityFetched.Data, &nodeActivity) {
+			identicalCount++
+		}
+	}
+
+	if verifiedCount == 0 {
+		return false, fmt.Errorf("no verifier available")
+	}
+
+	return identicalCount*2 > verifiedCount, nil
+}
+
+// countDemotions returns the demotions of the Node in the current epoch counted toward DemotionCountBeforeSlashing,
+// which are the invalid requests except the invalid responses with a pending or upheld appeal.
+// The appeals are only looked up for the Nodes with invalid requests.
+func (e *SimpleEnforcer) countDemotions(ctx context.Context, stat *schema.Stat, invalidCount int64) (int64, error) {
+	if invalidCount <= 0 {
+		return 0, nil
+	}
+
+	var appealedCount int64
+
+	for _, status := range []schema.NodeInvalidResponseAppealStatus{schema.NodeInvalidResponseAppealStatusPending, schema.NodeInvalidResponseAppealStatusUpheld} {
+		query := schema.NodeInvalidResponsesQuery{
+			NodeAddress:  lo.ToPtr(stat.Address),
+			EpochID:      lo.ToPtr(uint64(stat.Epoch)),
+			AppealStatus: lo.ToPtr(status),
+			Limit:        lo.ToPtr(defaultLimit),
+		}
+
+		for {
+			invalidResponses, err := e.databaseClient.FindNodeInvalidResponses(ctx, query)
+			if err != nil {
+				return 0, fmt.Errorf("find appealed invalid responses: %w", err)
+			}
+
+			appealedCount += int64(len(invalidResponses))
+
+			if len(invalidResponses) < defaultLimit {
+				break
+			}
+
+			query.Cursor = lo.ToPtr(invalidResponses[len(invalidResponses)-1].ID)
+		}
+	}
+
+	return max(invalidCount-appealedCount, 0), nil
+}
+
+// recountDemotions counts the demotions of the Node again after an appeal of the epoch is raised or resolved.
+// The demotions are counted per epoch, so the ones of the past epochs have been reset already.
+func (e *SimpleEnforcer) recountDemotions(ctx context.Context, nodeAddress common.Address, epochID uint64) error {
+	stat, err := e.databaseClient.FindNodeStat(ctx, nodeAddress)
+	if err != nil {
+		return fmt.Errorf("find node stat: %w", err)
+	}
+
+	if stat == nil || stat.Epoch != int64(epochID) {
+		return nil
+	}
+
+	var invalidCount int64
+
+	if err = getCacheCount(ctx, e.cacheClient, model.InvalidRequestCount, stat.Address, &invalidCount, stat.EpochInvalidRequest); err != nil {
+		return fmt.Errorf("get invalid request count: %w", err)
+	}
+
+	if stat.EpochInvalidRequest, err = e.countDemotions(ctx, stat, invalidCount); err != nil {
+		return fmt.Errorf("count demotions: %w", err)
+	}
+
+	calculateReliabilityScore(stat, findNodePerformances(ctx, e.cacheClient, []*schema.Stat{stat})[stat.Address])
+
+	if err = e.databaseClient.SaveNodeStat(ctx, stat); err != nil {
+		return fmt.Errorf("save node stat: %w", err)
+	}
+
+	return e.updateScore(ctx, stat)
+}
+
+// updateScore updates the score of the Node in the sorted sets of the qualified Nodes,
+// the Node is removed from them once it is demoted, and only an online Node is added back.
+// The enforcers of the scheduler maintain no Nodes, so the sorted sets shared with the Hubs are updated directly.
+func (e *SimpleEnforcer) updateScore(ctx context.Context, stat *schema.Stat) error {
+	node, err := e.databaseClient.FindNode(ctx, stat.Address)
+	if err != nil {
+		return fmt.Errorf("find node: %w", err)
+	}
+
+	if node.Status != schema.NodeStatusOnline {
+		return nil
+	}
+
+	if e.fullNodeScoreMaintainer != nil && e.rssNodeScoreMaintainer != nil {
+		e.updateScoreMaintainer(ctx, stat)
+
+		return nil
+	}
+
+	for setKey, member := range map[string]bool{
+		model.FullNodeCacheKey: stat.IsFullNode,
+		model.RssNodeCacheKey:  stat.IsRssNode,
+	} {
+		if !member {
+			continue
+		}
+
+		if stat.EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
+			if err := e.cacheClient.ZRem(ctx, setKey, stat.Address.String()); err != nil {
+				return fmt.Errorf("remove from %s: %w", setKey, err)
+			}
+
+			continue
+		}
+
+		if err := e.cacheClient.ZAdd(ctx, setKey, redis.Z{Member: stat.Address.String(), Score: stat.Score}); err != nil {
+			return fmt.Errorf("update score of %s: %w", setKey, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package enforcer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	appealActivity        = `{"id":"0xabc","network":"ethereum","index":0,"from":"0x1","to":"0x2","tag":"exchange","type":"swap","platform":"Uniswap","actions":[]}`
+	appealVerifierRequest = "https://verifier.example.com/decentralized/tx/0xabc"
+)
+
+func TestResolvePendingAppeals(t *testing.T) {
+	t.Parallel()
+
+	appellant := common.HexToAddress("0x1")
+
+	testcases := []struct {
+		name string
+		// verifierResponse is the activity returned by the verifier, the verifier is unavailable if it is empty.
+		verifierResponse string
+		status           schema.NodeStatus
+		appealStatus     schema.NodeInvalidResponseAppealStatus
+		demotionCount    int64
+		qualified        bool
+	}{
+		{
+			name:             "upheld",
+			verifierResponse: `{"data":` + appealActivity + `}`,
+			status:           schema.NodeStatusOnline,
+			appealStatus:     schema.NodeInvalidResponseAppealStatusUpheld,
+			demotionCount:    int64(model.DemotionCountBeforeSlashing) - 1,
+			qualified:        true,
+		},
+		{
+			name:             "upheld for an offline node",
+			verifierResponse: `{"data":` + appealActivity + `}`,
+			status:           schema.NodeStatusOffline,
+			appealStatus:     schema.NodeInvalidResponseAppealStatusUpheld,
+			demotionCount:    int64(model.DemotionCountBeforeSlashing) - 1,
+			qualified:        false,
+		},
+		{
+			name:             "rejected",
+			verifierResponse: `{"data":{"id":"0xabc","network":"ethereum","platform":"Uniswap","actions":[]}}`,
+			status:           schema.NodeStatusOnline,
+			appealStatus:     schema.NodeInvalidResponseAppealStatusRejected,
+			demotionCount:    int64(model.DemotionCountBeforeSlashing),
+			qualified:        false,
+		},
+		{
+			name:          "pending",
+			status:        schema.NodeStatusOnline,
+			appealStatus:  schema.NodeInvalidResponseAppealStatusPending,
+			demotionCount: int64(model.DemotionCountBeforeSlashing) - 1,
+			qualified:     true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The Node has been demoted by the invalid responses of the epoch, one of them is appealed.
+			cacheClient := newAppealCache(t)
+			require.NoError(t, cacheClient.Set(context.Background(), formatNodeStatRedisKey(model.InvalidRequestCount, appellant.String()), model.DemotionCountBeforeSlashing, 0))
+
+			databaseClient := appealDatabase{
+				node: &schema.Node{Address: appellant, Status: testcase.status},
+				stat: &schema.Stat{
+					Address:             appellant,
+					IsFullNode:          true,
+					Epoch:               3,
+					EpochInvalidRequest: int64(model.DemotionCountBeforeSlashing),
+				},
+				verifier: &schema.Stat{Address: common.HexToAddress("0x2"), Endpoint: "https://verifier.example.com"},
+				invalidResponses: []*schema.NodeInvalidResponse{
+					{
+						ID:       1,
+						EpochID:  3,
+						Type:     schema.NodeInvalidResponseTypeInconsistent,
+						Request:  "https://node.example.com/decentralized/tx/0xabc",
+						Node:     appellant,
+						Response: json.RawMessage(appealActivity),
+						Appeal:   &schema.NodeInvalidResponseAppeal{Status: schema.NodeInvalidResponseAppealStatusPending},
+					},
+				},
+			}
+
+			httpClient := new(MockHTTPClient)
+			if testcase.verifierResponse == "" {
+				httpClient.On("FetchWithMethod", mock.Anything, appealVerifierRequest).Return(io.NopCloser(bytes.NewReader(nil)), errors.New("unavailable"))
+			} else {
+				httpClient.On("FetchWithMethod", mock.Anything, appealVerifierRequest).Return(io.NopCloser(bytes.NewReader([]byte(testcase.verifierResponse))), nil)
+			}
+
+			enforcer := SimpleEnforcer{
+				cacheClient:    cacheClient,
+				databaseClient: &databaseClient,
+				httpClient:     httpClient,
+			}
+
+			require.NoError(t, enforcer.resolvePendingAppeals(context.Background()))
+
+			assert.Equal(t, testcase.appealStatus, databaseClient.invalidResponses[0].Appeal.Status)
+			assert.Equal(t, testcase.demotionCount, databaseClient.stat.EpochInvalidRequest)
+
+			_, qualified := cacheClient.members[model.FullNodeCacheKey][appellant.String()]
+			assert.Equal(t, testcase.qualified, qualified)
+		})
+	}
+}
+
+// appealDatabase keeps the Node appealing an invalid response and a verifier of the response.
+type appealDatabase struct {
+	database.Client
+
+	mu               sync.Mutex
+	node             *schema.Node
+	stat             *schema.Stat
+	verifier         *schema.Stat
+	invalidResponses []*schema.NodeInvalidResponse
+}
+
+func (d *appealDatabase) FindNode(_ context.Context, _ common.Address) (*schema.Node, error) {
+	return d.node, nil
+}
+
+func (d *appealDatabase) FindNodeStat(_ context.Context, _ common.Address) (*schema.Stat, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stat := *d.stat
+
+	return &stat, nil
+}
+
+func (d *appealDatabase) SaveNodeStat(_ context.Context, stat *schema.Stat) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stat = stat
+
+	return nil
+}
+
+func (d *appealDatabase) FindNodeWorkers(_ context.Context, _ *schema.WorkerQuery) ([]*schema.Worker, error) {
+	return []*schema.Worker{{Address: d.verifier.Address, Network: "ethereum", Name: "uniswap"}}, nil
+}
+
+func (d *appealDatabase) FindNodeStats(_ context.Context, _ *schema.StatQuery) ([]*schema.Stat, error) {
+	return []*schema.Stat{d.verifier}, nil
+}
+
+func (d *appealDatabase) FindNodeInvalidResponses(_ context.Context, query schema.NodeInvalidResponsesQuery) ([]*schema.NodeInvalidResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return lo.Filter(d.invalidResponses, func(invalidResponse *schema.NodeInvalidResponse, _ int) bool {
+		return (query.AppealStatus == nil || invalidResponse.Appeal != nil && invalidResponse.Appeal.Status == *query.AppealStatus) &&
+			(query.EpochID == nil || invalidResponse.EpochID == *query.EpochID) &&
+			(query.Cursor == nil || invalidResponse.ID < *query.Cursor)
+	}), nil
+}
+
+func (d *appealDatabase) UpdateNodeInvalidResponseAppealStatus(_ context.Context, id uint64, status schema.NodeInvalidResponseAppealStatus) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, invalidResponse := range d.invalidResponses {
+		if invalidResponse.ID == id && invalidResponse.Appeal.Status == schema.NodeInvalidResponseAppealStatusPending {
+			invalidResponse.Appeal.Status = status
+
+			return nil
+		}
+	}
+
+	return database.ErrorRowNotFound
+}
+
+// appealCache is an in-memory cache.Client supporting the counters and the sorted sets.
+type appealCache struct {
+	cache.Client
+
+	mu       sync.Mutex
+	values   map[string][]byte
+	members  map[string]map[string]float64
+	pipeline redis.Pipeliner
+}
+
+func newAppealCache(t *testing.T) *appealCache {
+	t.Helper()
+
+	// The performances are not cached, so the pipeline fails without retries.
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0", MaxRetries: -1, DialTimeout: time.Second})
+	t.Cleanup(func() { _ = redisClient.Close() })
+
+	return &appealCache{
+		values:   make(map[string][]byte),
+		members:  make(map[string]map[string]float64),
+		pipeline: redisClient.Pipeline(),
+	}
+}
+
+func (c *appealCache) Get(_ context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, exists := c.values[key]
+	if !exists {
+		return redis.Nil
+	}
+
+	return json.Unmarshal(data, dest)
+}
+
+func (c *appealCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = data
+
+	return nil
+}
+
+func (c *appealCache) ZAdd(_ context.Context, key string, members ...redis.Z) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.members[key] == nil {
+		c.members[key] = make(map[string]float64)
+	}
+
+	for _, member := range members {
+		c.members[key][member.Member.(string)] = member.Score
+	}
+
+	return nil
+}
+
+func (c *appealCache) ZRem(_ context.Context, key string, members ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, member := range members {
+		delete(c.members[key], member.(string))
+	}
+
+	return nil
+}
+
+func (c *appealCache) Pipeline(_ context.Context) redis.Pipeliner {
+	return c.pipeline
+}
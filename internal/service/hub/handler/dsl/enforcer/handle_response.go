@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/rss3-network/node/schema/worker/decentralized"
 	"github.com/samber/lo"
@@ -72,6 +73,7 @@ func (e *SimpleEnforcer) getNodeStatsMap(ctx context.Context, responses []*model
 
 func (e *SimpleEnforcer) batchUpdateScoreMaintainer(ctx context.Context, responses []*model.DataResponse) {
 	nodeStatsMap, _ := e.getNodeStatsMap(ctx, responses)
+	performances := findNodePerformances(ctx, e.cacheClient, lo.Values(nodeStatsMap))
 
 	statsPool := pool.New().WithContext(ctx).WithMaxGoroutines(lo.Ternary(len(nodeStatsMap) < 20*runtime.NumCPU() && len(nodeStatsMap) > 0, len(nodeStatsMap), 20*runtime.NumCPU()))
 
@@ -94,7 +96,12 @@ func (e *SimpleEnforcer) batchUpdateScoreMaintainer(ctx context.Context, respons
 				return err
 			}
 
-			stat.EpochInvalidRequest = invalidCount
+			demotionCount, err := e.countDemotions(ctx, stat, invalidCount)
+			if err != nil {
+				return err
+			}
+
+			stat.EpochInvalidRequest = demotionCount
 
 			if stat.EpochRequest < validCount {
 				stat.TotalRequest += validCount - stat.EpochRequest
@@ -102,7 +109,7 @@ func (e *SimpleEnforcer) batchUpdateScoreMaintainer(ctx context.Context, respons
 
 			stat.EpochRequest = validCount
 
-			calculateReliabilityScore(stat)
+			calculateReliabilityScore(stat, performances[stat.Address])
 
 			e.updateScoreMaintainer(ctx, stat)
 
@@ -257,6 +264,8 @@ func (e *SimpleEnforcer) verifyActivityByStats(ctx context.Context, activity *mo
 
 				if err = e.databaseClient.SaveNodeInvalidResponses(ctx, []*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
 					zap.L().Error("save node invalid response", zap.Error(err))
+				} else if err = webhook.EmitNodeInvalidResponses(ctx, e.databaseClient, []*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
+					zap.L().Error("emit node invalid response events", zap.Error(err))
 				}
 			}
 
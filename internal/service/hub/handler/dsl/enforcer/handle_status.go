@@ -14,6 +14,7 @@ import (
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	"github.com/rss3-network/global-indexer/common/txmgr"
 	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/rss3-network/node/schema/worker"
 	"github.com/samber/lo"
@@ -199,6 +200,12 @@ func (e *SimpleEnforcer) saveOfflineStatusToInvalidResponse(ctx context.Context,
 
 	if err := e.databaseClient.SaveNodeInvalidResponses(ctx, []*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
 		zap.L().Error("save node invalid response", zap.Error(err))
+
+		return
+	}
+
+	if err := webhook.EmitNodeInvalidResponses(ctx, e.databaseClient, []*schema.NodeInvalidResponse{nodeInvalidResponse}); err != nil {
+		zap.L().Error("emit node invalid response events", zap.Error(err))
 	}
 }
 
@@ -214,9 +221,30 @@ func (e *SimpleEnforcer) updateNodeStatusAndSubmitDemotionToVSL(ctx context.Cont
 		return fmt.Errorf("invoke settlement contract: %w", err)
 	}
 
+	e.emitNodeStatusEvents(ctx, nodeAddresses, nodeStatusList, demotionNodeAddresses, reasons, reporters)
+
 	return nil
 }
 
+// emitNodeStatusEvents notifies the operators of the statuses set and the demotions submitted on the VSL.
+func (e *SimpleEnforcer) emitNodeStatusEvents(ctx context.Context, nodeAddresses []common.Address, nodeStatusList []uint8, demotionNodeAddresses []common.Address, reasons []string, reporters []common.Address) {
+	for i, nodeAddress := range nodeAddresses {
+		data := webhook.StatusChangedData{Status: schema.NodeStatus(nodeStatusList[i])}
+
+		if err := webhook.Emit(ctx, e.databaseClient, nodeAddress, schema.NodeWebhookEventTypeStatusChanged, data); err != nil {
+			zap.L().Error("emit node status changed event", zap.Error(err), zap.Stringer("node", nodeAddress))
+		}
+	}
+
+	for i, nodeAddress := range demotionNodeAddresses {
+		data := webhook.DemotedData{Reason: reasons[i], Reporter: reporters[i]}
+
+		if err := webhook.Emit(ctx, e.databaseClient, nodeAddress, schema.NodeWebhookEventTypeDemoted, data); err != nil {
+			zap.L().Error("emit node demoted event", zap.Error(err), zap.Stringer("node", nodeAddress))
+		}
+	}
+}
+
 // prepareSetNodeStatusAndSubmitDemotionsData prepares the data for setting node statuses and submitting demotions
 func prepareSetNodeStatusAndSubmitDemotionsData(nodeAddresses []common.Address, nodeStatusList []uint8, demotionNodeAddresses []common.Address, reasons []string, reporters []common.Address) ([][]byte, error) {
 	data := make([][]byte, 0)
@@ -13,7 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/redis/go-redis/v9"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/performance"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
@@ -42,6 +44,11 @@ const (
 	perSlashScore                        = 0.5
 	nonExistScore                float64 = 0
 	existScore                           = 1
+	performanceMinRequests               = 20
+	errorRateMaxPenalty                  = 0.5
+	latencyMaxPenalty                    = 0.3
+	latencyPenaltyStart                  = time.Second
+	latencyPenaltyEnd                    = 10 * time.Second
 
 	defaultLimit = 50
 )
@@ -151,6 +158,8 @@ func sortNodes(nodeAddresses []common.Address, nodes []*schema.Node) []*schema.N
 
 // updateStatsInPool concurrently updates the stats of the Nodes.
 func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.Stat, nodesInfo []stakingv2.Node, nodes []*schema.Node, reset bool) error {
+	performances := findNodePerformances(ctx, e.cacheClient, stats)
+
 	statsPool := pool.New().WithContext(ctx).WithMaxGoroutines(lo.Ternary(len(stats) < 20*runtime.NumCPU() && len(stats) > 0, len(stats), 20*runtime.NumCPU()))
 
 	for i := range stats {
@@ -177,7 +186,14 @@ func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.
 			// If the reset flag is true, initialize the valid and invalid request counts to zero,
 			// effectively resetting the node's counters for the new epoch.
 			if !reset {
-				if err := e.cacheClient.Get(ctx, formatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), &stats[i].EpochInvalidRequest); err != nil && !errors.Is(err, redis.Nil) {
+				var invalidCount int64
+
+				if err := e.cacheClient.Get(ctx, formatNodeStatRedisKey(model.InvalidRequestCount, stats[i].Address.String()), &invalidCount); err == nil {
+					// The invalid responses with a pending or upheld appeal are not counted.
+					if stats[i].EpochInvalidRequest, err = e.countDemotions(ctx, stats[i], invalidCount); err != nil {
+						return fmt.Errorf("count demotions: %w", err)
+					}
+				} else if !errors.Is(err, redis.Nil) {
 					return fmt.Errorf("get invalid request count: %w", err)
 				}
 			} else {
@@ -192,7 +208,7 @@ func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.
 				}
 			}
 
-			updateNodeStat(stats[i], nodesInfo[i].StakingPoolTokens, nodes[i].Status)
+			updateNodeStat(stats[i], nodesInfo[i].StakingPoolTokens, nodes[i].Status, performances[stats[i].Address])
 
 			return nil
 		})
@@ -202,7 +218,7 @@ func (e *SimpleEnforcer) updateStatsInPool(ctx context.Context, stats []*schema.
 }
 
 // updateNodeStat updates Node's stat with Reliability Score.
-func updateNodeStat(stat *schema.Stat, staking *big.Int, status schema.NodeStatus) {
+func updateNodeStat(stat *schema.Stat, staking *big.Int, status schema.NodeStatus, nodePerformance *performance.Performance) {
 	// Convert the staking to float64.
 	stat.Staking, _ = staking.Div(staking, big.NewInt(1e18)).Float64()
 
@@ -212,12 +228,12 @@ func updateNodeStat(stat *schema.Stat, staking *big.Int, status schema.NodeStatu
 	}
 
 	// Calculate the Reliability Score.
-	calculateReliabilityScore(stat)
+	calculateReliabilityScore(stat, nodePerformance)
 }
 
 // calculateReliabilityScore calculates the Reliability Score σ of a given Node.
 // σ is used to determine the probability of a Node receiving a request on DSL.
-func calculateReliabilityScore(stat *schema.Stat) {
+func calculateReliabilityScore(stat *schema.Stat, nodePerformance *performance.Performance) {
 	// baseline score
 	baselineScore := math.Min(math.Log(stat.Staking/stakingToScoreRate+1)/math.Log(stakingLogBase), stakingMaxScore)
 
@@ -250,6 +266,10 @@ func calculateReliabilityScore(stat *schema.Stat) {
 	// maximum score is 0.2
 	stat.Score += math.Min(float64(stat.Indexer)*perIndexerScore, indexerMaxScore)
 
+	// latency and availability in the current Epoch
+	// maximum penalty is 0.8
+	stat.Score -= calculatePerformancePenalty(nodePerformance)
+
 	// invalid request count in the current Epoch
 	if stat.EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
 		// If the number of invalid requests in the epoch is greater than the threshold, then the score is baseline score.
@@ -259,3 +279,42 @@ func calculateReliabilityScore(stat *schema.Stat) {
 		stat.Score = math.Max(baselineScore, stat.Score-perSlashScore*float64(stat.EpochInvalidRequest))
 	}
 }
+
+// calculatePerformancePenalty calculates the penalty of a Node from its error rate and p95 latency in the current epoch.
+// Nodes without enough requests are not penalized.
+func calculatePerformancePenalty(nodePerformance *performance.Performance) float64 {
+	if nodePerformance == nil || nodePerformance.RequestCount < performanceMinRequests {
+		return 0
+	}
+
+	// error rate
+	// maximum penalty is 0.5
+	penalty := nodePerformance.ErrorRate() * errorRateMaxPenalty
+
+	// p95 latency
+	// maximum penalty is 0.3, reached when p95 latency is above 10 seconds
+	latencyRatio := float64(nodePerformance.Percentile(95)-latencyPenaltyStart) / float64(latencyPenaltyEnd-latencyPenaltyStart)
+	penalty += math.Max(0, math.Min(latencyRatio, 1)) * latencyMaxPenalty
+
+	return penalty
+}
+
+// findNodePerformances returns the performance of each Node in its current epoch, which is nil if it is unavailable.
+func findNodePerformances(ctx context.Context, cacheClient cache.Client, stats []*schema.Stat) map[common.Address]*performance.Performance {
+	epochs := make(map[common.Address]int64, len(stats))
+
+	for _, stat := range stats {
+		if stat != nil {
+			epochs[stat.Address] = stat.Epoch
+		}
+	}
+
+	performances, err := performance.FindByNodes(ctx, cacheClient, epochs)
+	if err != nil {
+		zap.L().Warn("find node performances", zap.Int("count", len(epochs)), zap.Error(err))
+
+		return nil
+	}
+
+	return performances
+}
@@ -76,6 +76,7 @@ func (sm *ScoreMaintainer) retrieveQualifiedNodes(ctx context.Context, setKey st
 				Address:     item.Member.(string),
 				Endpoint:    endpointCache.Endpoint,
 				AccessToken: endpointCache.AccessToken,
+				Score:       item.Score,
 			})
 		}
 	}
@@ -144,6 +145,7 @@ func prepareNodeCachesAndMembers(ctx context.Context, nodeStats []*schema.Stat,
 
 	nodeEndpointMap := make(map[string]*EndpointCache, len(nodeStats))
 	members := make([]redis.Z, 0, len(nodeStats))
+	performances := findNodePerformances(ctx, cacheClient, nodeStats)
 
 	statsPool := pool.New().WithContext(ctx).WithMaxGoroutines(lo.Ternary(len(nodeStats) < 20*runtime.NumCPU() && len(nodeStats) > 0, len(nodeStats), 20*runtime.NumCPU()))
 
@@ -182,7 +184,7 @@ func prepareNodeCachesAndMembers(ctx context.Context, nodeStats []*schema.Stat,
 			// If the invalid request count is less than the demotion count, add the node to the map and sorted set.
 			if invalidCount < int64(model.DemotionCountBeforeSlashing) {
 				// Calculate the reliability score.
-				calculateReliabilityScore(stat)
+				calculateReliabilityScore(stat, performances[stat.Address])
 
 				mu.Lock()
 				nodeEndpointMap[stat.Address.String()] = &EndpointCache{
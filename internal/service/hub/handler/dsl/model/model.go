@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/node/schema/worker/decentralized"
@@ -18,6 +19,7 @@ const (
 	DistributorRequestBatchAccountActivities = "batch_activities"
 	DistributorRequestNetworkActivities      = "network_activities"
 	DistributorRequestPlatformActivities     = "platform_activities"
+	DistributorRequestRSSHub                 = "rsshub"
 
 	ComponentDecentralized = "decentralized"
 	ComponentFederated     = "federated"
@@ -30,11 +32,15 @@ var (
 	FullNodeCacheKey = "nodes:full"
 	// FederatedHandlesPrefixCacheKey is the cache key prefix for the handles of federated nodes.
 	FederatedHandlesPrefixCacheKey = "federated:handles:"
+	// DistributorResponsePrefixCacheKey is the cache key prefix for the Node responses cached by the Distributor.
+	DistributorResponsePrefixCacheKey = "distributor:response:"
 
 	// InvalidRequestCount is the prefix used for cache keys related to storing invalid request counts in the current epoch.
 	InvalidRequestCount = "node:request:count:invalid"
 	// ValidRequestCount is the prefix used for cache keys related to storing valid request counts in the current epoch.
 	ValidRequestCount = "node:request:count:valid"
+	// NodePerformance is the prefix used for cache keys related to storing the latency histograms and error counts of each epoch.
+	NodePerformance = "node:performance"
 
 	// WorkerToNetworksMapKey is the cache key for the map of Workers to Networks.
 	WorkerToNetworksMapKey = "map:worker_to_networks"
@@ -80,9 +86,10 @@ var (
 
 // NodeEndpointCache stores the elements in the heap.
 type NodeEndpointCache struct {
-	Address     string `json:"address"`
-	Endpoint    string `json:"endpoint"`
-	AccessToken string `json:"access_token"`
+	Address     string  `json:"address"`
+	Endpoint    string  `json:"endpoint"`
+	AccessToken string  `json:"access_token"`
+	Score       float64 `json:"score,omitempty"`
 }
 
 // DataResponse represents the response returned by a Node.
@@ -98,6 +105,8 @@ type DataResponse struct {
 	ValidPoint int
 	// InvalidPoint is the points given to the response when it is invalid
 	InvalidPoint int
+	// Latency is the duration between sending the request and reading the response
+	Latency time.Duration
 }
 
 type RequestMeta struct {
@@ -105,6 +114,8 @@ type RequestMeta struct {
 	Endpoint    string
 	AccessToken string
 	Body        []byte
+	// Score is the reliability score of the Node, used by the weighted routing strategy.
+	Score float64
 }
 
 type ErrResponse struct {
@@ -11,9 +11,13 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/performance"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"go.uber.org/zap"
 )
@@ -27,6 +31,11 @@ type Router interface {
 
 type SimpleRouter struct {
 	httpClient httputil.Client
+	// cacheClient records the performance of the Nodes, nothing is recorded when it is nil.
+	cacheClient cache.Client
+	// defaultStrategy is used by routes without a strategy in routeStrategies.
+	defaultStrategy Strategy
+	routeStrategies map[string]Strategy
 }
 
 func (r *SimpleRouter) BuildPath(method, path string, query url.Values, nodes []*model.NodeEndpointCache, body []byte) (map[common.Address]model.RequestMeta, error) {
@@ -53,6 +62,7 @@ func (r *SimpleRouter) BuildPath(method, path string, query url.Values, nodes []
 			Endpoint:    fullURL,
 			AccessToken: node.AccessToken,
 			Body:        body,
+			Score:       node.Score,
 		}
 	}
 
@@ -72,12 +82,13 @@ func buildFullURL(endpoint, urlPath string) string {
 	return endpoint + urlPath
 }
 
-func (r *SimpleRouter) DistributeRequest(ctx context.Context, nodeMap map[common.Address]model.RequestMeta, processResponses func([]*model.DataResponse)) (model.DataResponse, error) {
+// DistributeRequest sends the request to the Nodes using the routing strategy of the route, and returns the first valid response.
+func (r *SimpleRouter) DistributeRequest(ctx context.Context, route string, nodeMap map[common.Address]model.RequestMeta, processResponses func([]*model.DataResponse)) (model.DataResponse, error) {
 	// firstResponse is a channel that will be used to send the first response
 	var firstResponse = make(chan model.DataResponse, 1)
 
 	// Distribute the request to the Nodes
-	r.distribute(ctx, nodeMap, processResponses, firstResponse)
+	r.distribute(ctx, r.strategy(route), nodeMap, processResponses, firstResponse)
 
 	select {
 	case response := <-firstResponse:
@@ -88,8 +99,17 @@ func (r *SimpleRouter) DistributeRequest(ctx context.Context, nodeMap map[common
 	}
 }
 
-// distribute sends the request to the Nodes and processes the responses
-func (r *SimpleRouter) distribute(ctx context.Context, nodeMap map[common.Address]model.RequestMeta, processResponses func([]*model.DataResponse), firstResponse chan<- model.DataResponse) {
+// strategy returns the routing strategy of the route.
+func (r *SimpleRouter) strategy(route string) Strategy {
+	if strategy, exists := r.routeStrategies[route]; exists {
+		return strategy
+	}
+
+	return r.defaultStrategy
+}
+
+// distribute sends the request to the Nodes as scheduled by the strategy and processes the responses
+func (r *SimpleRouter) distribute(ctx context.Context, strategy Strategy, nodeMap map[common.Address]model.RequestMeta, processResponses func([]*model.DataResponse), firstResponse chan<- model.DataResponse) {
 	var (
 		waitGroup sync.WaitGroup
 		mu        sync.Mutex
@@ -105,12 +125,28 @@ func (r *SimpleRouter) distribute(ctx context.Context, nodeMap map[common.Addres
 
 	defer cancel()
 
-	for address, requestMeta := range nodeMap {
+	dispatches := strategy.Schedule(nodeMap)
+
+	var (
+		// validReturned is closed once a valid response is returned, the delayed requests are then skipped.
+		validReturned = make(chan struct{})
+		closeOnce     sync.Once
+		// failed releases one delayed request for each failed or invalid response.
+		failed = make(chan struct{}, len(dispatches))
+	)
+
+	for _, dispatch := range dispatches {
 		waitGroup.Add(1)
 
-		go func(address common.Address, requestMeta model.RequestMeta) {
+		go func(address common.Address, requestMeta model.RequestMeta, delay time.Duration) {
 			defer waitGroup.Done()
 
+			if delay != 0 && !waitForDispatch(ctx, delay, validReturned, failed) {
+				return
+			}
+
+			start := time.Now()
+
 			response := &model.DataResponse{Address: address, Endpoint: requestMeta.Endpoint}
 			// Fetch the data from the Node.
 			body, err := r.httpClient.FetchWithMethod(ctx, requestMeta.Method, requestMeta.Endpoint, requestMeta.AccessToken, bytes.NewReader(requestMeta.Body))
@@ -149,8 +185,20 @@ func (r *SimpleRouter) distribute(ctx context.Context, nodeMap map[common.Addres
 				}
 			}
 
-			sendResponse(&mu, &responses, response, &responseSent, firstResponse, len(nodeMap))
-		}(address, requestMeta)
+			response.Latency = time.Since(start)
+
+			sendResponse(&mu, &responses, response, &responseSent, firstResponse, len(dispatches))
+
+			if response.Err == nil && response.Valid {
+				strategy.Observe(response.Latency)
+				closeOnce.Do(func() { close(validReturned) })
+			} else {
+				select {
+				case failed <- struct{}{}:
+				default:
+				}
+			}
+		}(dispatch.Address, dispatch.RequestMeta, dispatch.Delay)
 	}
 
 	waitGroup.Wait()
@@ -168,6 +216,48 @@ func (r *SimpleRouter) distribute(ctx context.Context, nodeMap map[common.Addres
 		zap.L().Info("begin to process responses", zap.Any("responses", len(responses)))
 		// Process the responses to calculate the actual request of each node
 		go processResponses(responses)
+		// Record the latency and errors of each node
+		go r.recordPerformance(responses)
+	}
+}
+
+// recordPerformance records the latency and errors of the responses.
+func (r *SimpleRouter) recordPerformance(responses []*model.DataResponse) {
+	if r.cacheClient == nil {
+		return
+	}
+
+	if err := performance.Record(context.Background(), r.cacheClient, responses); err != nil {
+		zap.L().Error("failed to record node performance", zap.Error(err))
+	}
+}
+
+// waitForDispatch waits until a delayed request should be sent, it returns false if the request is no longer needed.
+func waitForDispatch(ctx context.Context, delay time.Duration, validReturned <-chan struct{}, failed <-chan struct{}) bool {
+	var timeout <-chan time.Time
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case <-validReturned:
+		return false
+	case <-timeout:
+	case <-failed:
+	case <-ctx.Done():
+		// The request fails immediately, so that every scheduled Node returns a response.
+	}
+
+	// A valid response may have been returned at the same time.
+	select {
+	case <-validReturned:
+		return false
+	default:
+		return true
 	}
 }
 
@@ -219,8 +309,29 @@ func validateData(data []byte, target any) bool {
 	return false
 }
 
-func NewSimpleRouter(httpClient httputil.Client) *SimpleRouter {
-	return &SimpleRouter{
-		httpClient: httpClient,
+func NewSimpleRouter(httpClient httputil.Client, cacheClient cache.Client, routingConfig *config.DistributorRouting) (*SimpleRouter, error) {
+	router := SimpleRouter{
+		httpClient:      httpClient,
+		cacheClient:     cacheClient,
+		defaultStrategy: &BroadcastStrategy{},
+		routeStrategies: make(map[string]Strategy),
+	}
+
+	if routingConfig == nil {
+		return &router, nil
 	}
+
+	var err error
+
+	if router.defaultStrategy, err = NewStrategy(routingConfig.Strategy); err != nil {
+		return nil, fmt.Errorf("new default strategy: %w", err)
+	}
+
+	for route, strategyConfig := range routingConfig.Routes {
+		if router.routeStrategies[route], err = NewStrategy(strategyConfig); err != nil {
+			return nil, fmt.Errorf("new strategy for route %s: %w", route, err)
+		}
+	}
+
+	return &router, nil
 }
@@ -51,13 +51,13 @@ func TestDistributeRequestWithValidData(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(validActivitiesData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -84,13 +84,13 @@ func TestDistributeRequestWithNullData(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -113,13 +113,13 @@ func TestDistributeRequestWithNodeError(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(errResponse)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(errResponse)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(errResponse)), nil)
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -138,13 +138,13 @@ func TestDistributeRequestWithError(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(errResponse)), errors.New("error 8070"))
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(errResponse)), errors.New("error 8080"))
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(errResponse)), errors.New("error 8090"))
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -163,13 +163,13 @@ func TestDistributeRequestAndReturnNull(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(nullData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(errResponse)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(errResponse)), errors.New("error 8090"))
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -188,14 +188,14 @@ func TestDistributeRequest(t *testing.T) {
 	t.Parallel()
 
 	mockClient := new(MockHTTPClient)
-	r := SimpleRouter{httpClient: mockClient}
+	r := SimpleRouter{httpClient: mockClient, defaultStrategy: &BroadcastStrategy{}}
 
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
 	//mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(errResponse)), errors.New("error 8090"))
 	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
 
-	response, err := r.DistributeRequest(context.Background(), nodeMap, process)
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, nodeMap, process)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -0,0 +1,203 @@
+package router
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+)
+
+const (
+	StrategyBroadcast = "broadcast"
+	StrategyHedged    = "hedged"
+	StrategyWeighted  = "weighted"
+)
+
+// waitForFailure is the Dispatch delay of a request that is only sent when a previous request fails.
+const waitForFailure time.Duration = -1
+
+// latencyWindowSize is the number of recent latencies kept by the hedged strategy.
+const latencyWindowSize = 512
+
+// Strategy decides when each Node is requested.
+type Strategy interface {
+	// Schedule returns the requests to send, in the order they should be sent.
+	Schedule(nodeMap map[common.Address]model.RequestMeta) []Dispatch
+	// Observe records the latency of a valid response.
+	Observe(latency time.Duration)
+}
+
+// Dispatch is a request scheduled by a Strategy.
+// A delayed request is sent early when a previous request fails,
+// and is never sent once a valid response has been returned.
+type Dispatch struct {
+	Address     common.Address
+	RequestMeta model.RequestMeta
+	// Delay is the duration to wait before sending the request, waitForFailure waits for a failure only.
+	Delay time.Duration
+}
+
+// NewStrategy creates a Strategy from the config, broadcasting when the config is nil.
+func NewStrategy(strategyConfig *config.RoutingStrategy) (Strategy, error) {
+	if strategyConfig == nil {
+		return &BroadcastStrategy{}, nil
+	}
+
+	fanout := max(strategyConfig.Fanout, 1)
+
+	switch strategyConfig.Name {
+	case StrategyBroadcast, "":
+		return &BroadcastStrategy{}, nil
+	case StrategyHedged:
+		return &HedgedStrategy{
+			fanout:     fanout,
+			percentile: strategyConfig.HedgePercentile,
+			delay:      strategyConfig.HedgeDelay,
+		}, nil
+	case StrategyWeighted:
+		return &WeightedStrategy{
+			fanout: fanout,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown routing strategy: %s", strategyConfig.Name)
+	}
+}
+
+// BroadcastStrategy requests all Nodes at once.
+type BroadcastStrategy struct{}
+
+func (s *BroadcastStrategy) Schedule(nodeMap map[common.Address]model.RequestMeta) []Dispatch {
+	dispatches := make([]Dispatch, 0, len(nodeMap))
+
+	for address, requestMeta := range nodeMap {
+		dispatches = append(dispatches, Dispatch{Address: address, RequestMeta: requestMeta})
+	}
+
+	return dispatches
+}
+
+func (s *BroadcastStrategy) Observe(_ time.Duration) {}
+
+// HedgedStrategy requests the Nodes with the highest scores first,
+// and the remaining Nodes once the first ones are slower than the latency percentile of previous responses.
+type HedgedStrategy struct {
+	fanout     int
+	percentile float64
+	delay      time.Duration
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func (s *HedgedStrategy) Schedule(nodeMap map[common.Address]model.RequestMeta) []Dispatch {
+	dispatches := (&BroadcastStrategy{}).Schedule(nodeMap)
+
+	rand.Shuffle(len(dispatches), func(i, j int) {
+		dispatches[i], dispatches[j] = dispatches[j], dispatches[i]
+	})
+
+	sort.SliceStable(dispatches, func(i, j int) bool {
+		return dispatches[i].RequestMeta.Score > dispatches[j].RequestMeta.Score
+	})
+
+	delay := s.hedgeDelay()
+
+	for i := s.fanout; i < len(dispatches); i++ {
+		dispatches[i].Delay = delay
+	}
+
+	return dispatches
+}
+
+func (s *HedgedStrategy) Observe(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.latencies) < latencyWindowSize {
+		s.latencies = append(s.latencies, latency)
+
+		return
+	}
+
+	s.latencies[s.next] = latency
+	s.next = (s.next + 1) % latencyWindowSize
+}
+
+// hedgeDelay returns the latency percentile of previous responses, or the configured delay until the window is full enough.
+func (s *HedgedStrategy) hedgeDelay() time.Duration {
+	s.mu.Lock()
+	latencies := slices.Clone(s.latencies)
+	s.mu.Unlock()
+
+	if len(latencies) < latencyWindowSize/10 {
+		return s.delay
+	}
+
+	slices.Sort(latencies)
+
+	index := int(math.Ceil(s.percentile/100*float64(len(latencies)))) - 1
+
+	return latencies[min(max(index, 0), len(latencies)-1)]
+}
+
+// WeightedStrategy requests Nodes picked at random with a probability proportional to their reliability scores,
+// the remaining Nodes are only requested when previous requests fail.
+type WeightedStrategy struct {
+	fanout int
+}
+
+func (s *WeightedStrategy) Schedule(nodeMap map[common.Address]model.RequestMeta) []Dispatch {
+	candidates := (&BroadcastStrategy{}).Schedule(nodeMap)
+	dispatches := make([]Dispatch, 0, len(candidates))
+
+	// Weighted random sampling without replacement.
+	for len(candidates) > 0 {
+		index := pickWeighted(candidates)
+
+		dispatch := candidates[index]
+		if len(dispatches) >= s.fanout {
+			dispatch.Delay = waitForFailure
+		}
+
+		dispatches = append(dispatches, dispatch)
+		candidates = slices.Delete(candidates, index, index+1)
+	}
+
+	return dispatches
+}
+
+func (s *WeightedStrategy) Observe(_ time.Duration) {}
+
+// pickWeighted returns the index of a random dispatch weighted by the Node score,
+// Nodes without a positive score are picked uniformly when no Node has one.
+func pickWeighted(dispatches []Dispatch) int {
+	var total float64
+
+	for _, dispatch := range dispatches {
+		total += max(dispatch.RequestMeta.Score, 0)
+	}
+
+	if total == 0 {
+		return rand.IntN(len(dispatches))
+	}
+
+	target := rand.Float64() * total
+
+	for i, dispatch := range dispatches {
+		target -= max(dispatch.RequestMeta.Score, 0)
+
+		if target < 0 {
+			return i
+		}
+	}
+
+	return len(dispatches) - 1
+}
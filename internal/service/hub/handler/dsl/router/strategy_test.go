@@ -0,0 +1,135 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/config"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var scoredNodeMap = map[common.Address]model.RequestMeta{
+	common.HexToAddress("0x123"): {
+		Method:   "GET",
+		Endpoint: "http://localhost:8070",
+		Score:    0,
+	},
+	common.HexToAddress("0x234"): {
+		Method:   "GET",
+		Endpoint: "http://localhost:8080",
+		Score:    0,
+	},
+	common.HexToAddress("0x567"): {
+		Method:   "GET",
+		Endpoint: "http://localhost:8090",
+		Score:    1,
+	},
+}
+
+func TestNewStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := NewStrategy(nil)
+	require.NoError(t, err)
+	assert.IsType(t, &BroadcastStrategy{}, strategy)
+
+	strategy, err = NewStrategy(&config.RoutingStrategy{Name: StrategyHedged})
+	require.NoError(t, err)
+	assert.IsType(t, &HedgedStrategy{}, strategy)
+
+	strategy, err = NewStrategy(&config.RoutingStrategy{Name: StrategyWeighted})
+	require.NoError(t, err)
+	assert.IsType(t, &WeightedStrategy{}, strategy)
+
+	_, err = NewStrategy(&config.RoutingStrategy{Name: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestHedgedStrategySchedule(t *testing.T) {
+	t.Parallel()
+
+	strategy := &HedgedStrategy{fanout: 1, percentile: 50, delay: time.Second}
+
+	dispatches := strategy.Schedule(scoredNodeMap)
+	require.Len(t, dispatches, 3)
+
+	// The Node with the highest score is requested immediately.
+	assert.Equal(t, common.HexToAddress("0x567"), dispatches[0].Address)
+	assert.Zero(t, dispatches[0].Delay)
+	assert.Equal(t, time.Second, dispatches[1].Delay)
+	assert.Equal(t, time.Second, dispatches[2].Delay)
+
+	// The delay follows the latency percentile once enough latencies are observed.
+	for i := 1; i <= latencyWindowSize; i++ {
+		strategy.Observe(time.Duration(i) * time.Millisecond)
+	}
+
+	dispatches = strategy.Schedule(scoredNodeMap)
+	assert.Equal(t, latencyWindowSize/2*time.Millisecond, dispatches[1].Delay)
+}
+
+func TestWeightedStrategySchedule(t *testing.T) {
+	t.Parallel()
+
+	strategy := &WeightedStrategy{fanout: 1}
+
+	dispatches := strategy.Schedule(scoredNodeMap)
+	require.Len(t, dispatches, 3)
+
+	// Only the Node with a positive score can be picked first.
+	assert.Equal(t, common.HexToAddress("0x567"), dispatches[0].Address)
+	assert.Zero(t, dispatches[0].Delay)
+	assert.Equal(t, waitForFailure, dispatches[1].Delay)
+	assert.Equal(t, waitForFailure, dispatches[2].Delay)
+}
+
+func TestDistributeRequestWithWeightedStrategy(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockHTTPClient)
+	r := SimpleRouter{
+		httpClient:      mockClient,
+		defaultStrategy: &BroadcastStrategy{},
+		routeStrategies: map[string]Strategy{
+			model.DistributorRequestActivity: &WeightedStrategy{fanout: 1},
+		},
+	}
+
+	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
+
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, scoredNodeMap, process)
+	require.NoError(t, err)
+	require.NoError(t, response.Err)
+	assert.True(t, response.Valid)
+
+	// The other Nodes are not requested after a valid response.
+	mockClient.AssertNumberOfCalls(t, "FetchWithMethod", 1)
+}
+
+func TestDistributeRequestWithHedgedStrategy(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockHTTPClient)
+	r := SimpleRouter{
+		httpClient:      mockClient,
+		defaultStrategy: &HedgedStrategy{fanout: 1, percentile: 95, delay: time.Hour},
+	}
+
+	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8090").Return(io.NopCloser(bytes.NewBufferString(errResponse)), nil)
+	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8080").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
+	mockClient.On("FetchWithMethod", mock.Anything, "http://localhost:8070").Return(io.NopCloser(bytes.NewBufferString(validActivityData)), nil)
+
+	// The failure of the first Node sends the hedged requests without waiting for the delay.
+	response, err := r.DistributeRequest(context.Background(), model.DistributorRequestActivity, scoredNodeMap, process)
+	require.NoError(t, err)
+	require.NoError(t, response.Err)
+	assert.True(t, response.Valid)
+	assert.NotEqual(t, common.HexToAddress("0x567"), response.Address)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
@@ -21,6 +22,13 @@ func (n *NTA) GetBridgeTransactions(c echo.Context) error {
 		return c.NoContent(http.StatusBadRequest)
 	}
 
+	if err := c.Validate(&request); err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	withdrawalPeriod := *n.withdrawalPeriod
+	now := time.Now()
+
 	databaseTransactionOptions := sql.TxOptions{
 		ReadOnly: true,
 	}
@@ -35,9 +43,12 @@ func (n *NTA) GetBridgeTransactions(c echo.Context) error {
 	defer lo.Try(databaseTransaction.Rollback)
 
 	bridgeTransactionsQuery := schema.BridgeTransactionsQuery{
-		Cursor:  request.Cursor,
-		Address: request.Address,
-		Type:    request.Type,
+		Cursor:            request.Cursor,
+		Address:           request.Address,
+		Type:              request.Type,
+		WithdrawalStatus:  request.Status,
+		ProvableBefore:    now.Add(-withdrawalPeriod.ProposalInterval),
+		FinalizableBefore: now.Add(-withdrawalPeriod.FinalizationPeriod),
 	}
 
 	transactions, err := databaseTransaction.FindBridgeTransactions(c.Request().Context(), bridgeTransactionsQuery)
@@ -79,7 +90,7 @@ func (n *NTA) GetBridgeTransactions(c echo.Context) error {
 			return event.ID == transaction.ID
 		})
 
-		transactionModels = append(transactionModels, nta.NewBridgeTransaction(transaction, events))
+		transactionModels = append(transactionModels, nta.NewBridgeTransaction(transaction, events, withdrawalPeriod, now))
 	}
 
 	response := nta.Response{
@@ -153,7 +164,7 @@ func (n *NTA) GetBridgeTransaction(c echo.Context) error {
 	})
 
 	var response nta.Response
-	response.Data = nta.NewBridgeTransaction(bridgeTransaction, bridgeEvents)
+	response.Data = nta.NewBridgeTransaction(bridgeTransaction, bridgeEvents, *n.withdrawalPeriod, time.Now())
 
 	return c.JSON(http.StatusOK, response)
 }
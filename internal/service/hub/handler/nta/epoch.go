@@ -164,6 +164,34 @@ func (n *NTA) GetEpochNodeRewards(c echo.Context) error {
 	})
 }
 
+// GetEpochNodeScoreBreakdown returns how the Operation Rewards of the Node in the epoch are calculated.
+func (n *NTA) GetEpochNodeScoreBreakdown(c echo.Context) error {
+	var request nta.GetEpochNodeScoreBreakdownRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bad request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	breakdown, err := n.databaseClient.FindNodeScoreBreakdown(c.Request().Context(), request.EpochID, request.NodeAddress)
+	if err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		zap.L().Error("get epoch node score breakdown failed", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.GetEpochNodeScoreBreakdownResponseData(breakdown),
+	})
+}
+
 func (n *NTA) GetEpochsAPY(c echo.Context) error {
 	var apy decimal.Decimal
 
@@ -0,0 +1,199 @@
+package nta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// GetStakerExport streams the rewards of the staker in each epoch, its stake transactions and its bridge transactions in the range.
+// The records are grouped by category and in chronological order within each category.
+func (n *NTA) GetStakerExport(c echo.Context) error {
+	var request nta.GetStakerExportRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, err)
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	writer := newExportWriter(c, request.StakerAddress, request.Format)
+
+	if err := n.exportStaker(c.Request().Context(), writer, request); err != nil {
+		zap.L().Error("export staker", zap.Error(err), zap.Any("request", request))
+
+		return abortExport(c, writer)
+	}
+
+	return finishExport(c, writer)
+}
+
+// GetNodeExport streams the rewards of the Node in each epoch, the stake transactions of the Node and the bridge transactions of the Node address in the range.
+// The records are grouped by category and in chronological order within each category.
+func (n *NTA) GetNodeExport(c echo.Context) error {
+	var request nta.GetNodeExportRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, err)
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, err)
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	writer := newExportWriter(c, request.NodeAddress, request.Format)
+
+	if err := n.exportNode(c.Request().Context(), writer, request); err != nil {
+		zap.L().Error("export node", zap.Error(err), zap.Any("request", request))
+
+		return abortExport(c, writer)
+	}
+
+	return finishExport(c, writer)
+}
+
+func (n *NTA) exportStaker(ctx context.Context, writer nta.ExportWriter, request nta.GetStakerExportRequest) error {
+	since, until := request.Since(), request.Until()
+
+	var rewards nta.StakerRewards
+
+	// The rewards are derived from the whole history of the staker, so the records before the range are iterated but not written.
+	if err := n.databaseClient.IterateStakeTransactions(ctx, schema.ExportQuery{Staker: lo.ToPtr(request.StakerAddress), Until: until}, func(transaction *schema.StakeTransaction) error {
+		rewards.AddStakeTransaction(transaction)
+
+		if since != nil && transaction.BlockTimestamp.Before(*since) {
+			return nil
+		}
+
+		return writer.Write(nta.NewStakeTransactionExportRecord(transaction))
+	}); err != nil {
+		return fmt.Errorf("iterate stake transactions: %w", err)
+	}
+
+	if err := n.databaseClient.IterateStakerProfitSnapshots(ctx, schema.ExportQuery{Staker: lo.ToPtr(request.StakerAddress), Until: until}, func(snapshot *schema.StakerProfitSnapshot) error {
+		record := rewards.Next(snapshot)
+
+		if since != nil && snapshot.Date.Before(*since) {
+			return nil
+		}
+
+		return writer.Write(record)
+	}); err != nil {
+		return fmt.Errorf("iterate staker profit snapshots: %w", err)
+	}
+
+	return n.exportBridgeTransactions(ctx, writer, request.StakerAddress, request.ExportRequest)
+}
+
+func (n *NTA) exportNode(ctx context.Context, writer nta.ExportWriter, request nta.GetNodeExportRequest) error {
+	query := schema.ExportQuery{
+		Node:  lo.ToPtr(request.NodeAddress),
+		Since: request.Since(),
+		Until: request.Until(),
+	}
+
+	if err := n.databaseClient.IterateEpochNodeRewards(ctx, query, func(epoch *schema.Epoch) error {
+		for _, record := range nta.NewEpochNodeRewardExportRecords(epoch) {
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("iterate epoch node rewards: %w", err)
+	}
+
+	if err := n.databaseClient.IterateStakeTransactions(ctx, query, func(transaction *schema.StakeTransaction) error {
+		return writer.Write(nta.NewStakeTransactionExportRecord(transaction))
+	}); err != nil {
+		return fmt.Errorf("iterate stake transactions: %w", err)
+	}
+
+	return n.exportBridgeTransactions(ctx, writer, request.NodeAddress, request.ExportRequest)
+}
+
+func (n *NTA) exportBridgeTransactions(ctx context.Context, writer nta.ExportWriter, address common.Address, request nta.ExportRequest) error {
+	query := schema.ExportQuery{
+		Address: lo.ToPtr(address),
+		Since:   request.Since(),
+		Until:   request.Until(),
+	}
+
+	if err := n.databaseClient.IterateBridgeTransactions(ctx, query, func(transaction *schema.BridgeTransaction) error {
+		return writer.Write(nta.NewBridgeTransactionExportRecord(transaction))
+	}); err != nil {
+		return fmt.Errorf("iterate bridge transactions: %w", err)
+	}
+
+	return nil
+}
+
+// exportResponse writes the headers of the export response once the first bytes are written,
+// so an export failing before any record is written still gets an error response.
+type exportResponse struct {
+	response *echo.Response
+	address  common.Address
+	format   nta.ExportFormat
+}
+
+func (r *exportResponse) Write(data []byte) (int, error) {
+	if !r.response.Committed {
+		r.response.Header().Set(echo.HeaderContentType, lo.Ternary(r.format == nta.ExportFormatJSON, echo.MIMEApplicationJSONCharsetUTF8, "text/csv; charset=UTF-8"))
+		r.response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, r.address.String(), r.format))
+		r.response.WriteHeader(http.StatusOK)
+	}
+
+	return r.response.Write(data)
+}
+
+// newExportWriter returns the writer of the records to the export response.
+func newExportWriter(c echo.Context, address common.Address, format nta.ExportFormat) nta.ExportWriter {
+	return nta.NewExportWriter(&exportResponse{response: c.Response(), address: address, format: format}, format)
+}
+
+// abortExport responds with an error if the response has not been started, otherwise ends the export as failed.
+func abortExport(c echo.Context, writer nta.ExportWriter) error {
+	if !c.Response().Committed {
+		return errorx.InternalError(c)
+	}
+
+	if err := writer.Abort(); err != nil {
+		zap.L().Error("abort export", zap.Error(err))
+	}
+
+	c.Response().Flush()
+
+	return nil
+}
+
+func finishExport(c echo.Context, writer nta.ExportWriter) error {
+	if err := writer.Close(); err != nil {
+		zap.L().Error("close export", zap.Error(err))
+
+		return nil
+	}
+
+	c.Response().Flush()
+
+	return nil
+}
@@ -2,14 +2,22 @@ package nta
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/decentralization"
+	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
 )
 
 // GetAssets returns all assets supported by the DSL.
@@ -59,3 +67,94 @@ func (n *NTA) GetNetworkConfig(c echo.Context) error {
 		FederatedConfig:     networkParam.NetworkConfig["federated"],
 	}})
 }
+
+// GetNetworkDecentralization returns the current concentration of the Network, or the snapshot of an epoch.
+// The current concentration is collected by the scheduler, the snapshot of the latest epoch is returned if it is not available.
+func (n *NTA) GetNetworkDecentralization(c echo.Context) error {
+	var request nta.GetNetworkDecentralizationRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bad request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if request.EpochID == nil {
+		var data schema.DecentralizationSnapshot
+
+		err := n.cacheClient.Get(c.Request().Context(), decentralization.CacheKey, &data)
+		if err == nil {
+			return c.JSON(http.StatusOK, nta.Response{Data: data})
+		}
+
+		if !errors.Is(err, redis.Nil) {
+			zap.L().Error("get decentralization from cache", zap.Error(err))
+
+			return errorx.InternalError(c)
+		}
+	}
+
+	// The snapshot of the latest epoch is found without an epoch ID.
+	snapshots, err := n.databaseClient.FindDecentralizationSnapshots(c.Request().Context(), schema.DecentralizationSnapshotQuery{
+		EpochID: request.EpochID,
+		Limit:   lo.ToPtr(1),
+	})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find decentralization snapshots", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if len(snapshots) == 0 {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{Data: snapshots[0]})
+}
+
+// GetNetworkCoverage returns the Nodes serving each network, platform and worker, and the ones under-served.
+func (n *NTA) GetNetworkCoverage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// The maps are built by the enforcer at the beginning of each epoch.
+	var workerToNetworks, networkToWorkers, platformToWorkers map[string][]string
+
+	for key, value := range map[string]*map[string][]string{
+		model.WorkerToNetworksMapKey:  &workerToNetworks,
+		model.NetworkToWorkersMapKey:  &networkToWorkers,
+		model.PlatformToWorkersMapKey: &platformToWorkers,
+	} {
+		if err := n.cacheClient.Get(ctx, key, value); err != nil && !errors.Is(err, redis.Nil) {
+			zap.L().Error("get worker map from cache", zap.Error(err), zap.String("key", key))
+
+			return errorx.InternalError(c)
+		}
+	}
+
+	nodes, err := n.databaseClient.FindNodes(ctx, schema.FindNodesQuery{})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find nodes", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	fullNodes, err := n.databaseClient.FindNodeStats(ctx, &schema.StatQuery{IsFullNode: lo.ToPtr(true)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find full node stats", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	workers, err := n.databaseClient.FindNodeWorkers(ctx, &schema.WorkerQuery{IsActive: lo.ToPtr(true)})
+	if err != nil {
+		zap.L().Error("find node workers", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NewNetworkCoverage(workerToNetworks, networkToWorkers, platformToWorkers, nodes, fullNodes, workers, model.RequiredQualifiedNodeCount),
+	})
+}
@@ -11,8 +11,12 @@ import (
 )
 
 var (
-	registrationMessage = "I, %s, am signing this message for registering my intention to operate an RSS3 Node."
-	hideTaxRateMessage  = "I, %s, am signing this message for registering my intention to hide the tax rate on Explorer for my RSS3 Node."
+	registrationMessage   = "I, %s, am signing this message for registering my intention to operate an RSS3 Node."
+	hideTaxRateMessage    = "I, %s, am signing this message for registering my intention to hide the tax rate on Explorer for my RSS3 Node."
+	appealMessage         = "I, %s, am signing this message for appealing the invalid response %d recorded against my RSS3 Node."
+	webhookMessage        = "I, %s, am signing this message for registering the webhook %s for my RSS3 Node at %d."
+	webhookRemovalMessage = "I, %s, am signing this message for removing the webhook %d from my RSS3 Node."
+	webhookReadMessage    = "I, %s, am signing this message for reading the webhooks of my RSS3 Node at %d."
 )
 
 func (n *NTA) GetNodeChallenge(c echo.Context) error {
@@ -33,6 +37,30 @@ func (n *NTA) GetNodeChallenge(c echo.Context) error {
 		data = nta.NodeChallengeResponseData(fmt.Sprintf(registrationMessage, strings.ToLower(request.NodeAddress.String())))
 	case "hideTaxRate":
 		data = nta.NodeChallengeResponseData(fmt.Sprintf(hideTaxRateMessage, strings.ToLower(request.NodeAddress.String())))
+	case "appeal":
+		if request.InvalidResponseID == nil {
+			return errorx.BadParamsError(c, fmt.Errorf("invalid_response_id is required for challenge type: %s", request.Type))
+		}
+
+		data = nta.NodeChallengeResponseData(fmt.Sprintf(appealMessage, strings.ToLower(request.NodeAddress.String()), *request.InvalidResponseID))
+	case "webhook":
+		if request.URL == nil || request.Timestamp == nil {
+			return errorx.BadParamsError(c, fmt.Errorf("url and timestamp are required for challenge type: %s", request.Type))
+		}
+
+		data = nta.NodeChallengeResponseData(fmt.Sprintf(webhookMessage, strings.ToLower(request.NodeAddress.String()), *request.URL, *request.Timestamp))
+	case "webhookRemoval":
+		if request.WebhookID == nil {
+			return errorx.BadParamsError(c, fmt.Errorf("webhook_id is required for challenge type: %s", request.Type))
+		}
+
+		data = nta.NodeChallengeResponseData(fmt.Sprintf(webhookRemovalMessage, strings.ToLower(request.NodeAddress.String()), *request.WebhookID))
+	case "webhookRead":
+		if request.Timestamp == nil {
+			return errorx.BadParamsError(c, fmt.Errorf("timestamp is required for challenge type: %s", request.Type))
+		}
+
+		data = nta.NodeChallengeResponseData(fmt.Sprintf(webhookReadMessage, strings.ToLower(request.NodeAddress.String()), *request.Timestamp))
 	default:
 		return errorx.BadRequestError(c, fmt.Errorf("invalid challenge type: %s", request.Type))
 	}
@@ -0,0 +1,123 @@
+package nta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+func (n *NTA) GetNodeInvalidResponses(c echo.Context) error {
+	var request nta.NodeInvalidResponsesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	query := schema.NodeInvalidResponsesQuery{
+		NodeAddress: lo.ToPtr(request.NodeAddress),
+		EpochID:     request.EpochID,
+		Cursor:      request.Cursor,
+		Limit:       lo.ToPtr(request.Limit),
+	}
+
+	if request.Type != nil {
+		invalidResponseType, err := schema.NodeInvalidResponseTypeString(*request.Type)
+		if err != nil {
+			return errorx.BadParamsError(c, fmt.Errorf("invalid type: %w", err))
+		}
+
+		query.Type = lo.ToPtr(invalidResponseType)
+	}
+
+	if request.AppealStatus != nil {
+		query.AppealStatus = lo.ToPtr(schema.NodeInvalidResponseAppealStatus(*request.AppealStatus))
+	}
+
+	invalidResponses, err := n.databaseClient.FindNodeInvalidResponses(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find node invalid responses", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(invalidResponses) > 0 && len(invalidResponses) == request.Limit {
+		last, _ := lo.Last(invalidResponses)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NodeInvalidResponsesResponseData(invalidResponses),
+		Cursor: cursor,
+	})
+}
+
+func (n *NTA) PostNodeInvalidResponseAppeal(c echo.Context) error {
+	var request nta.NodeInvalidResponseAppealRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	message := fmt.Sprintf(appealMessage, strings.ToLower(request.NodeAddress.String()), request.InvalidResponseID)
+
+	if err := n.checkSignature(c.Request().Context(), request.NodeAddress, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	invalidResponse, err := n.databaseClient.FindNodeInvalidResponse(c.Request().Context(), request.InvalidResponseID)
+	if err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		zap.L().Error("find node invalid response", zap.Error(err), zap.Uint64("id", request.InvalidResponseID))
+
+		return errorx.InternalError(c)
+	}
+
+	// A Node can only appeal the invalid responses recorded against itself.
+	if invalidResponse.Node != request.NodeAddress {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	if invalidResponse.Appeal != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("invalid response %d has already been appealed", request.InvalidResponseID))
+	}
+
+	if err := n.databaseClient.SaveNodeInvalidResponseAppeal(c.Request().Context(), request.InvalidResponseID, request.Reason); err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return errorx.BadRequestError(c, fmt.Errorf("invalid response %d has already been appealed", request.InvalidResponseID))
+		}
+
+		zap.L().Error("save node invalid response appeal", zap.Error(err), zap.Uint64("id", request.InvalidResponseID))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
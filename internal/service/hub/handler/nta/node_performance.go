@@ -0,0 +1,63 @@
+package nta
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/performance"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"go.uber.org/zap"
+)
+
+func (n *NTA) GetNodePerformance(c echo.Context) error {
+	var request nta.NodePerformanceRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	epochID := request.EpochID
+
+	if epochID == nil {
+		stat, err := n.databaseClient.FindNodeStat(c.Request().Context(), request.NodeAddress)
+		if err != nil {
+			zap.L().Error("find node stat", zap.Error(err), zap.Any("request", request))
+
+			return errorx.InternalError(c)
+		}
+
+		if stat == nil {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		epochID = &stat.Epoch
+	}
+
+	epochs := make([]int64, 0, request.Limit)
+
+	for epoch := *epochID; epoch >= 0 && len(epochs) < request.Limit; epoch-- {
+		epochs = append(epochs, epoch)
+	}
+
+	performances, err := performance.Find(c.Request().Context(), n.cacheClient, request.NodeAddress, epochs)
+	if err != nil {
+		zap.L().Error("find node performance", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NewNodePerformances(performances),
+	})
+}
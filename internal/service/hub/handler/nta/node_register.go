@@ -277,8 +277,8 @@ func (n *NTA) updateNodeStat(ctx context.Context, node *schema.Node, nodeInfo st
 // saveHeartbeat saves the heartbeat to the database.
 func (n *NTA) saveHeartbeat(ctx context.Context, node *schema.Node, requestIP string) error {
 	var err error
-	// Get node local info.
-	if len(node.Location) == 0 {
+	// Get node local info, the locations saved without the autonomous systems are looked up again once the ASN database is loaded.
+	if len(node.Location) == 0 || n.geoLite2.ASNLoaded() && lo.SomeBy(node.Location, func(location *schema.NodeLocation) bool { return !location.ASNLookedUp }) {
 		node.Location, err = n.geoLite2.LookupNodeLocation(ctx, requestIP)
 		if err != nil {
 			zap.L().Error("failed to get Node local", zap.Error(err))
@@ -0,0 +1,264 @@
+package nta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/creasty/defaults"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// maxNodeWebhooks is the number of webhooks a Node can register.
+const maxNodeWebhooks = 5
+
+// webhookChallengeExpiration is the period a signed webhook challenge is accepted in, so it cannot be replayed later.
+const webhookChallengeExpiration = 5 * time.Minute
+
+func (n *NTA) GetNodeWebhooks(c echo.Context) error {
+	var request nta.NodeWebhooksRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if err := n.checkWebhookRead(c.Request().Context(), request.NodeAddress, request.NodeWebhookReadRequest); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	webhooks, err := n.databaseClient.FindNodeWebhooks(c.Request().Context(), schema.NodeWebhookQuery{NodeAddress: lo.ToPtr(request.NodeAddress)})
+	if err != nil {
+		zap.L().Error("find node webhooks", zap.Error(err), zap.Stringer("node", request.NodeAddress))
+
+		return errorx.InternalError(c)
+	}
+
+	// The secrets are only returned on registration.
+	for _, nodeWebhook := range webhooks {
+		nodeWebhook.Secret = ""
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NodeWebhooksResponseData(webhooks),
+	})
+}
+
+func (n *NTA) PostNodeWebhook(c echo.Context) error {
+	var request nta.NodeWebhookRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if err := checkChallengeTimestamp(request.Timestamp); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	message := fmt.Sprintf(webhookMessage, strings.ToLower(request.NodeAddress.String()), request.URL, request.Timestamp)
+
+	if err := n.checkSignature(c.Request().Context(), request.NodeAddress, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	if err := webhook.ValidateURL(c.Request().Context(), request.URL); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("invalid webhook url %s: %w", request.URL, err))
+	}
+
+	webhooks, err := n.databaseClient.FindNodeWebhooks(c.Request().Context(), schema.NodeWebhookQuery{NodeAddress: lo.ToPtr(request.NodeAddress)})
+	if err != nil {
+		zap.L().Error("find node webhooks", zap.Error(err), zap.Stringer("node", request.NodeAddress))
+
+		return errorx.InternalError(c)
+	}
+
+	// Registering an existing URL again replaces its events, but keeps its secret, which is not returned again.
+	existing, found := lo.Find(webhooks, func(nodeWebhook *schema.NodeWebhook) bool { return nodeWebhook.URL == request.URL })
+	if !found && len(webhooks) >= maxNodeWebhooks {
+		return errorx.BadRequestError(c, fmt.Errorf("a node can register at most %d webhooks", maxNodeWebhooks))
+	}
+
+	nodeWebhook := schema.NodeWebhook{
+		NodeAddress: request.NodeAddress,
+		URL:         request.URL,
+		Events: lo.Map(lo.Uniq(request.Events), func(event string, _ int) schema.NodeWebhookEventType {
+			return schema.NodeWebhookEventType(event)
+		}),
+	}
+
+	if found {
+		nodeWebhook.Secret = existing.Secret
+	} else if nodeWebhook.Secret, err = webhook.NewSecret(); err != nil {
+		zap.L().Error("new node webhook secret", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	if err := n.databaseClient.SaveNodeWebhook(c.Request().Context(), &nodeWebhook); err != nil {
+		zap.L().Error("save node webhook", zap.Error(err), zap.Stringer("node", request.NodeAddress))
+
+		return errorx.InternalError(c)
+	}
+
+	if found {
+		nodeWebhook.Secret = ""
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.NodeWebhookResponseData(&nodeWebhook),
+	})
+}
+
+func (n *NTA) DeleteNodeWebhook(c echo.Context) error {
+	var request nta.NodeWebhookRemovalRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	message := fmt.Sprintf(webhookRemovalMessage, strings.ToLower(request.NodeAddress.String()), request.WebhookID)
+
+	if err := n.checkSignature(c.Request().Context(), request.NodeAddress, message, request.Signature); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("check signature: %w", err))
+	}
+
+	nodeWebhook, err := n.findNodeWebhook(c.Request().Context(), request.NodeAddress, request.WebhookID)
+	if err != nil {
+		zap.L().Error("find node webhook", zap.Error(err), zap.Uint64("id", request.WebhookID))
+
+		return errorx.InternalError(c)
+	}
+
+	if nodeWebhook == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	if err := n.databaseClient.DeleteNodeWebhook(c.Request().Context(), request.WebhookID); err != nil {
+		if errors.Is(err, database.ErrorRowNotFound) {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		zap.L().Error("delete node webhook", zap.Error(err), zap.Uint64("id", request.WebhookID))
+
+		return errorx.InternalError(c)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (n *NTA) GetNodeWebhookDeliveries(c echo.Context) error {
+	var request nta.NodeWebhookDeliveriesRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	if err := n.checkWebhookRead(c.Request().Context(), request.NodeAddress, request.NodeWebhookReadRequest); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	nodeWebhook, err := n.findNodeWebhook(c.Request().Context(), request.NodeAddress, request.WebhookID)
+	if err != nil {
+		zap.L().Error("find node webhook", zap.Error(err), zap.Uint64("id", request.WebhookID))
+
+		return errorx.InternalError(c)
+	}
+
+	if nodeWebhook == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	query := schema.NodeWebhookDeliveryQuery{
+		WebhookID: lo.ToPtr(request.WebhookID),
+		Cursor:    request.Cursor,
+		Limit:     lo.ToPtr(request.Limit),
+	}
+
+	if request.Status != nil {
+		query.Status = lo.ToPtr(schema.NodeWebhookDeliveryStatus(*request.Status))
+	}
+
+	deliveries, err := n.databaseClient.FindNodeWebhookDeliveries(c.Request().Context(), query)
+	if err != nil {
+		zap.L().Error("find node webhook deliveries", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(deliveries) > 0 && len(deliveries) == request.Limit {
+		last, _ := lo.Last(deliveries)
+		cursor = strconv.FormatUint(last.ID, 10)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   nta.NodeWebhookDeliveriesResponseData(deliveries),
+		Cursor: cursor,
+	})
+}
+
+// findNodeWebhook returns the webhook if it is registered by the Node, or nil if not found.
+func (n *NTA) findNodeWebhook(ctx context.Context, nodeAddress common.Address, id uint64) (*schema.NodeWebhook, error) {
+	webhooks, err := n.databaseClient.FindNodeWebhooks(ctx, schema.NodeWebhookQuery{ID: lo.ToPtr(id), NodeAddress: lo.ToPtr(nodeAddress)})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.FirstOrEmpty(webhooks), nil
+}
+
+// checkWebhookRead checks the webhookRead challenge signed by the Node.
+func (n *NTA) checkWebhookRead(ctx context.Context, address common.Address, request nta.NodeWebhookReadRequest) error {
+	if err := checkChallengeTimestamp(request.Timestamp); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf(webhookReadMessage, strings.ToLower(address.String()), request.Timestamp)
+
+	if err := n.checkSignature(ctx, address, message, request.Signature); err != nil {
+		return fmt.Errorf("check signature: %w", err)
+	}
+
+	return nil
+}
+
+// checkChallengeTimestamp checks that the challenge is signed within webhookChallengeExpiration, so it cannot be replayed later.
+func checkChallengeTimestamp(timestamp int64) error {
+	if elapsed := time.Since(time.Unix(timestamp, 0)); elapsed > webhookChallengeExpiration || elapsed < -webhookChallengeExpiration {
+		return fmt.Errorf("the challenge at %d is expired", timestamp)
+	}
+
+	return nil
+}
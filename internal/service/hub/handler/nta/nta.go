@@ -6,14 +6,15 @@ import (
 	"net/url"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
 	"github.com/rss3-network/global-indexer/common/geolite2"
 	"github.com/rss3-network/global-indexer/common/httputil"
+	"github.com/rss3-network/global-indexer/contract/l1"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/tvl"
 )
 
 type NTA struct {
@@ -24,9 +25,9 @@ type NTA struct {
 	geoLite2                *geolite2.Client
 	cacheClient             cache.Client
 	httpClient              httputil.Client
-	erc20TokenMap           map[common.Address]*bindings.GovernanceToken
+	tvlCalculator           *tvl.Calculator
 	configFile              *config.File
-	chainL1ID               uint64
+	withdrawalPeriod        *l1.WithdrawalPeriod
 	chainL2ID               uint64
 }
 
@@ -39,7 +40,7 @@ func (n *NTA) baseURL(c echo.Context) url.URL {
 	}
 }
 
-func NewNTA(_ context.Context, configFile *config.File, databaseClient database.Client, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, contractGovernanceToken *bindings.GovernanceToken, geoLite2 *geolite2.Client, cacheClient cache.Client, httpClient httputil.Client, erc20TokenMap map[common.Address]*bindings.GovernanceToken, chainL1ID, chainL2ID uint64) *NTA {
+func NewNTA(_ context.Context, configFile *config.File, databaseClient database.Client, stakingContract *l2.StakingV2MulticallClient, networkParamsContract *l2.NetworkParams, contractGovernanceToken *bindings.GovernanceToken, geoLite2 *geolite2.Client, cacheClient cache.Client, httpClient httputil.Client, tvlCalculator *tvl.Calculator, withdrawalPeriod *l1.WithdrawalPeriod, chainL2ID uint64) *NTA {
 	return &NTA{
 		databaseClient:          databaseClient,
 		stakingContract:         stakingContract,
@@ -48,9 +49,9 @@ func NewNTA(_ context.Context, configFile *config.File, databaseClient database.
 		geoLite2:                geoLite2,
 		cacheClient:             cacheClient,
 		httpClient:              httpClient,
-		erc20TokenMap:           erc20TokenMap,
+		tvlCalculator:           tvlCalculator,
 		configFile:              configFile,
-		chainL1ID:               chainL1ID,
+		withdrawalPeriod:        withdrawalPeriod,
 		chainL2ID:               chainL2ID,
 	}
 }
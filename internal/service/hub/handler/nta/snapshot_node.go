@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/creasty/defaults"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
 	"github.com/rss3-network/global-indexer/internal/database"
@@ -84,6 +85,43 @@ func (n *NTA) GetEpochsAPYSnapshots(c echo.Context) error {
 	})
 }
 
+func (n *NTA) GetDecentralizationSnapshots(c echo.Context) error {
+	var request nta.GetDecentralizationSnapshotsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	snapshots, err := n.databaseClient.FindDecentralizationSnapshots(c.Request().Context(), schema.DecentralizationSnapshotQuery{
+		Cursor: request.Cursor,
+		Limit:  lo.ToPtr(request.Limit),
+	})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find decentralization snapshots", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(snapshots) > 0 && len(snapshots) == request.Limit {
+		cursor = fmt.Sprintf("%d", snapshots[len(snapshots)-1].EpochID)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   snapshots,
+		Cursor: cursor,
+	})
+}
+
 func (n *NTA) findNodeOperationProfitSnapshots(ctx context.Context, operator common.Address, profit *nta.GetNodeOperationProfitResponse) ([]*nta.NodeProfitChangeDetail, error) {
 	if profit == nil {
 		return nil, nil
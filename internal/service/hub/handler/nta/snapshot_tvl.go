@@ -0,0 +1,53 @@
+package nta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/creasty/defaults"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+func (n *NTA) GetTVLSnapshots(c echo.Context) error {
+	var request nta.GetTVLSnapshotsRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, fmt.Errorf("bind request: %w", err))
+	}
+
+	if err := defaults.Set(&request); err != nil {
+		return errorx.BadRequestError(c, fmt.Errorf("set default failed: %w", err))
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, fmt.Errorf("validation failed: %w", err))
+	}
+
+	snapshots, err := n.databaseClient.FindTVLSnapshots(c.Request().Context(), schema.TVLSnapshotQuery{
+		Cursor: request.Cursor,
+		Limit:  lo.ToPtr(request.Limit),
+	})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find tvl snapshots", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	var cursor string
+
+	if len(snapshots) > 0 && len(snapshots) == request.Limit {
+		cursor = fmt.Sprintf("%d", snapshots[len(snapshots)-1].EpochID)
+	}
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data:   snapshots,
+		Cursor: cursor,
+	})
+}
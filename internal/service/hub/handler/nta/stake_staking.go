@@ -71,7 +71,7 @@ func (n *NTA) GetStakerProfit(c echo.Context) error {
 	}
 
 	// Find history profit snapshots
-	data, err := n.findStakerHistoryProfitSnapshots(c.Request().Context(), request.StakerAddress)
+	data, err := n.findStakerHistoryProfitSnapshots(c.Request().Context(), n.databaseClient, request.StakerAddress)
 	if err != nil {
 		zap.L().Error("find staker history profit snapshots", zap.Error(err))
 
@@ -83,14 +83,14 @@ func (n *NTA) GetStakerProfit(c echo.Context) error {
 	})
 }
 
-func (n *NTA) findStakerHistoryProfitSnapshots(ctx context.Context, owner common.Address) (*nta.GetStakerProfitResponseData, error) {
+func (n *NTA) findStakerHistoryProfitSnapshots(ctx context.Context, databaseClient database.Client, owner common.Address) (*nta.GetStakerProfitResponseData, error) {
 	// Find current profit snapshot.
 	query := schema.StakerProfitSnapshotsQuery{
 		OwnerAddress: lo.ToPtr(owner),
 		Limit:        lo.ToPtr(1),
 	}
 
-	currentProfit, err := n.databaseClient.FindStakerProfitSnapshots(ctx, query)
+	currentProfit, err := databaseClient.FindStakerProfitSnapshots(ctx, query)
 	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
 		return nil, fmt.Errorf("find staker profit snapshots: %w", err)
 	}
@@ -118,7 +118,7 @@ func (n *NTA) findStakerHistoryProfitSnapshots(ctx context.Context, owner common
 	}
 
 	// Calculate profit changes from staking transactions.
-	transactions, err := n.databaseClient.FindStakeTransactions(ctx, schema.StakeTransactionsQuery{
+	transactions, err := databaseClient.FindStakeTransactions(ctx, schema.StakeTransactionsQuery{
 		User:           lo.ToPtr(owner),
 		BlockTimestamp: lo.ToPtr(blockTimestamp),
 		Order:          "block_timestamp ASC",
@@ -146,7 +146,7 @@ func (n *NTA) findStakerHistoryProfitSnapshots(ctx context.Context, owner common
 		Dates:        []time.Time{profit.OneDay.Date, profit.OneWeek.Date, profit.OneMonth.Date},
 	}
 
-	snapshots, err := n.databaseClient.FindStakerProfitSnapshots(ctx, query)
+	snapshots, err := databaseClient.FindStakerProfitSnapshots(ctx, query)
 	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
 		return nil, fmt.Errorf("find staker profit snapshots: %w", err)
 	}
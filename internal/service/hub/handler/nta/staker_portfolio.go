@@ -0,0 +1,135 @@
+package nta
+
+import (
+	"database/sql"
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func (n *NTA) GetStakerPortfolio(c echo.Context) error {
+	var request nta.GetStakerPortfolioRequest
+
+	if err := c.Bind(&request); err != nil {
+		return errorx.BadParamsError(c, err)
+	}
+
+	if err := c.Validate(&request); err != nil {
+		return errorx.ValidationFailedError(c, err)
+	}
+
+	ctx := c.Request().Context()
+
+	// Read the chips, the unstake transactions and the profit snapshots in one consistent read.
+	databaseTransaction, err := n.databaseClient.Begin(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		zap.L().Error("begin database transaction", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	defer lo.Try(databaseTransaction.Rollback)
+
+	chips, err := databaseTransaction.FindStakeChips(ctx, schema.StakeChipsQuery{Owner: lo.ToPtr(request.StakerAddress)})
+	if err != nil {
+		zap.L().Error("find stake chips", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	unstakeTransactions, err := databaseTransaction.FindStakeTransactions(ctx, schema.StakeTransactionsQuery{
+		User: lo.ToPtr(request.StakerAddress),
+		Type: lo.ToPtr(schema.StakeTransactionTypeUnstake),
+	})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		zap.L().Error("find unstake transactions", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	var (
+		unstakeEvents []*schema.StakeEvent
+		unstakedChips []*schema.StakeChip
+	)
+
+	if len(unstakeTransactions) > 0 {
+		unstakeEvents, err = databaseTransaction.FindStakeEvents(ctx, schema.StakeEventsQuery{
+			IDs: lo.Map(unstakeTransactions, func(transaction *schema.StakeTransaction, _ int) common.Hash {
+				return transaction.ID
+			}),
+		})
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			zap.L().Error("find unstake events", zap.Error(err), zap.Any("request", request))
+
+			return errorx.InternalError(c)
+		}
+
+		unstakedChips, err = databaseTransaction.FindStakeChips(ctx, schema.StakeChipsQuery{
+			IDs: lo.FlatMap(unstakeTransactions, func(transaction *schema.StakeTransaction, _ int) []*big.Int {
+				return transaction.ChipIDs
+			}),
+		})
+		if err != nil {
+			zap.L().Error("find unstaked chips", zap.Error(err), zap.Any("request", request))
+
+			return errorx.InternalError(c)
+		}
+	}
+
+	profit, err := n.findStakerHistoryProfitSnapshots(ctx, databaseTransaction, request.StakerAddress)
+	if err != nil {
+		zap.L().Error("find staker history profit snapshots", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	if err := databaseTransaction.Commit(); err != nil {
+		zap.L().Error("commit database transaction", zap.Error(err), zap.Any("request", request))
+
+		return errorx.InternalError(c)
+	}
+
+	if len(chips) > 0 {
+		chipsInfo, err := n.stakingContract.StakingV2GetChipsInfo(ctx, nil, lo.Map(chips, func(chip *schema.StakeChip, _ int) *big.Int {
+			return chip.ID
+		}))
+		if err != nil {
+			zap.L().Error("get chips info by multicall", zap.Error(err))
+
+			return errorx.InternalError(c)
+		}
+
+		for i, chipInfo := range chipsInfo {
+			chips[i].LatestValue = decimal.NewFromBigInt(chipInfo.Tokens, 0)
+		}
+	}
+
+	unbondingPeriod, err := n.stakingContract.STAKEUNBONDINGPERIOD(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		zap.L().Error("get stake unbonding period", zap.Error(err))
+
+		return errorx.InternalError(c)
+	}
+
+	portfolio := nta.NewStakerPortfolio(request.StakerAddress, chips, unstakeTransactions, unstakeEvents, unstakedChips, time.Duration(unbondingPeriod.Int64())*time.Second, time.Now())
+	portfolio.Profit = profit
+
+	return c.JSON(http.StatusOK, nta.Response{
+		Data: nta.GetStakerPortfolioResponseData(portfolio),
+	})
+}
@@ -2,22 +2,18 @@ package nta
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"math/big"
 	"net/http"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/labstack/echo/v4"
-	"github.com/rss3-network/global-indexer/contract/l1"
-	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/errorx"
 	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/internal/tvl"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
-	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/zap"
 )
 
@@ -50,136 +46,69 @@ func (n *NTA) getTokensTotalSupply() (decimal.Decimal, error) {
 
 type GetTvlResponse struct {
 	Tvl decimal.Decimal `json:"tvl"`
+	// PriceSnapshot is the TVL snapshot whose prices are used, if the price providers are unavailable.
+	PriceSnapshot *GetTvlPriceSnapshot `json:"price_snapshot,omitempty"`
+}
+
+type GetTvlPriceSnapshot struct {
+	EpochID uint64    `json:"epoch_id"`
+	Date    time.Time `json:"date"`
 }
 
 func (n *NTA) GetTvl(c echo.Context) error {
 	ctx := c.Request().Context()
-	tokenPriceMap, err := n.getTokenPrices(ctx)
 
+	tokenPrices, priceSnapshot, err := n.getTokenPrices(ctx)
 	if err != nil {
 		zap.L().Error("get token price", zap.Error(err))
 		return errorx.InternalError(c)
 	}
 
-	var (
-		tvl decimal.Decimal
-		mu  sync.Mutex
-	)
-
-	p := pool.New().WithContext(ctx).WithCancelOnError().WithMaxGoroutines(10)
-
-	for address, bind := range n.erc20TokenMap {
-		address, bind := address, bind
-
-		p.Go(func(_ context.Context) error {
-			var (
-				balance *big.Int
-				err     error
-			)
-
-			if address == l2.ContractMap[n.chainL2ID].AddressPowerToken {
-				balance, err = bind.TotalSupply(nil)
-			} else {
-				balance, err = bind.BalanceOf(nil, l1.ContractMap[n.chainL1ID].AddressL1StandardBridgeProxy)
-			}
-
-			if err != nil {
-				zap.L().Error("get token balance", zap.String("token", address.String()), zap.Error(err))
-				return err
-			}
-
-			tokenValue := n.calculateTokenValue(address, balance, tokenPriceMap)
-
-			mu.Lock()
-			tvl = tvl.Add(tokenValue)
-			mu.Unlock()
-
-			return nil
-		})
-	}
-
-	if err := p.Wait(); err != nil {
+	snapshot, err := n.tvlCalculator.Calculate(ctx, tokenPrices)
+	if err != nil {
 		zap.L().Error("get tvl", zap.Error(err))
 		return errorx.InternalError(c)
 	}
 
+	response := GetTvlResponse{Tvl: snapshot.TVL}
+
+	if priceSnapshot != nil {
+		response.PriceSnapshot = &GetTvlPriceSnapshot{
+			EpochID: priceSnapshot.EpochID,
+			Date:    priceSnapshot.Date,
+		}
+	}
+
 	return c.JSON(http.StatusOK, nta.Response{
-		Data: GetTvlResponse{Tvl: tvl},
+		Data: response,
 	})
 }
 
-type TokenPrice struct {
-	Data struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			TokenPrices map[string]string `json:"token_prices"`
-		} `json:"attributes"`
-	} `json:"data"`
-}
-
-const (
-	tokenPriceKey = "token:price:map"
+const tokenPriceKey = "token:price:map"
 
-	// Get list of supported networks from https://api.geckoterminal.com/api/v2/networks
-	ethereumNetwork = "eth"
-	rss3Network     = "rss3-vsl-mainnet"
-)
-
-func (n *NTA) getTokenPrices(ctx context.Context) (map[string]string, error) {
-	tokenPriceMap := make(map[string]string, 3)
-	if err := n.cacheClient.Get(ctx, tokenPriceKey, &tokenPriceMap); err == nil && len(tokenPriceMap) == 3 {
-		return tokenPriceMap, nil
+// getTokenPrices returns the prices of the tokens, which fall back to the prices of the last TVL snapshot when the price providers are unavailable,
+// or when any token is not priced by them. The snapshot is returned if its prices are used.
+func (n *NTA) getTokenPrices(ctx context.Context) (map[common.Address]decimal.Decimal, *schema.TVLSnapshot, error) {
+	tokenPrices := make(map[common.Address]decimal.Decimal)
+	if err := n.cacheClient.Get(ctx, tokenPriceKey, &tokenPrices); err == nil && len(tokenPrices) == len(n.tvlCalculator.PricedTokens()) {
+		return tokenPrices, nil, nil
 	}
 
-	networks := []string{ethereumNetwork, rss3Network}
-	endpoint := n.configFile.TokenPriceAPI.Endpoint
-
-	for _, network := range networks {
-		var addressList []string
-		if network == ethereumNetwork {
-			addressList = []string{
-				l1.ContractMap[n.chainL1ID].AddressWETHToken.String(),
-				l1.ContractMap[n.chainL1ID].AddressGovernanceTokenProxy.String(),
-			}
-		} else {
-			addressList = []string{
-				l2.ContractMap[n.chainL2ID].AddressPowerToken.String(),
-			}
-		}
-
-		url := fmt.Sprintf("%s/simple/networks/%s/token_price/%s", endpoint, network, strings.Join(addressList, ","))
-
-		body, err := n.httpClient.FetchWithMethod(ctx, http.MethodGet, url, n.configFile.TokenPriceAPI.AuthToken, nil)
-		if err != nil {
-			return nil, fmt.Errorf("get token price: %w", err)
+	tokenPrices, err := n.tvlCalculator.FetchPrices(ctx)
+	if err != nil {
+		snapshots, findErr := n.databaseClient.FindTVLSnapshots(ctx, schema.TVLSnapshotQuery{Limit: lo.ToPtr(1)})
+		if findErr != nil || len(snapshots) == 0 {
+			return nil, nil, err
 		}
 
-		var tokenPrice TokenPrice
-		if err = json.NewDecoder(body).Decode(&tokenPrice); err != nil {
-			return nil, fmt.Errorf("parse token price from response body: %w", err)
-		}
+		zap.L().Warn("fall back to the token prices of the last tvl snapshot", zap.Error(err), zap.Uint64("epochID", snapshots[0].EpochID))
 
-		for address, price := range tokenPrice.Data.Attributes.TokenPrices {
-			tokenPriceMap[common.HexToAddress(address).String()] = price
-		}
+		return tvl.SnapshotPrices(snapshots[0]), snapshots[0], nil
 	}
 
-	if err := n.cacheClient.Set(ctx, tokenPriceKey, tokenPriceMap, 30*60*time.Second); err != nil {
+	if err := n.cacheClient.Set(ctx, tokenPriceKey, tokenPrices, 30*60*time.Second); err != nil {
 		zap.L().Warn("set token price map to cache", zap.Error(err))
 	}
 
-	return tokenPriceMap, nil
-}
-
-func (n *NTA) calculateTokenValue(address common.Address, balance *big.Int, priceMap map[string]string) decimal.Decimal {
-	switch address {
-	case l1.ContractMap[n.chainL1ID].AddressGovernanceTokenProxy, l1.ContractMap[n.chainL1ID].AddressWETHToken, l2.ContractMap[n.chainL2ID].AddressPowerToken:
-		price, _ := decimal.NewFromString(priceMap[address.String()])
-		return decimal.NewFromBigInt(balance, -18).Mul(price)
-	case l1.ContractMap[n.chainL1ID].AddressUSDTToken, l1.ContractMap[n.chainL1ID].AddressUSDCToken:
-		return decimal.NewFromBigInt(balance, -6)
-	default:
-		return decimal.Zero
-	}
+	return tokenPrices, nil, nil
 }
@@ -6,7 +6,6 @@ import (
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
@@ -21,8 +20,10 @@ import (
 	"github.com/rss3-network/global-indexer/internal/config/flag"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/nameresolver"
+	"github.com/rss3-network/global-indexer/internal/price"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/nta"
+	"github.com/rss3-network/global-indexer/internal/tvl"
 	"github.com/samber/lo"
 	"github.com/spf13/viper"
 )
@@ -71,7 +72,7 @@ func NewHub(ctx context.Context, databaseClient database.Client, redisClient *re
 
 	cacheClient := cache.New(redisClient)
 
-	dslService, err := dsl.NewDSL(ctx, databaseClient, cacheClient, nameService, stakingV2MulticallClient, networkParamsContract, httpClient, txManager, config.Settler, new(big.Int).SetUint64(chainL2ID))
+	dslService, err := dsl.NewDSL(ctx, databaseClient, cacheClient, nameService, stakingV2MulticallClient, networkParamsContract, httpClient, txManager, config.Settler, config.Distributor, new(big.Int).SetUint64(chainL2ID))
 	if err != nil {
 		return nil, fmt.Errorf("new dsl: %w", err)
 	}
@@ -85,16 +86,23 @@ func NewHub(ctx context.Context, databaseClient database.Client, redisClient *re
 		return nil, fmt.Errorf("get ethereum l1 client: %w", err)
 	}
 
-	erc20TokenMap := map[common.Address]*bindings.GovernanceToken{
-		l1.ContractMap[chainL1ID].AddressGovernanceTokenProxy: lo.Must(bindings.NewGovernanceToken(l1.ContractMap[chainL1ID].AddressGovernanceTokenProxy, ethereumL1Client)),
-		l1.ContractMap[chainL1ID].AddressUSDCToken:            lo.Must(bindings.NewGovernanceToken(l1.ContractMap[chainL1ID].AddressUSDCToken, ethereumL1Client)),
-		l1.ContractMap[chainL1ID].AddressUSDTToken:            lo.Must(bindings.NewGovernanceToken(l1.ContractMap[chainL1ID].AddressUSDTToken, ethereumL1Client)),
-		l1.ContractMap[chainL1ID].AddressWETHToken:            lo.Must(bindings.NewGovernanceToken(l1.ContractMap[chainL1ID].AddressWETHToken, ethereumL1Client)),
-		l2.ContractMap[chainL2ID].AddressPowerToken:           lo.Must(bindings.NewGovernanceToken(l2.ContractMap[chainL2ID].AddressPowerToken, ethereumClient)),
+	priceProvider, err := price.NewProvider(config.TokenPriceAPI, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("new price provider: %w", err)
+	}
+
+	tvlCalculator, err := tvl.NewCalculator(ethereumL1Client, ethereumClient, priceProvider, chainL1ID, chainL2ID)
+	if err != nil {
+		return nil, fmt.Errorf("new tvl calculator: %w", err)
+	}
+
+	withdrawalPeriod, err := l1.NewWithdrawalPeriod(ctx, chainL1ID, ethereumL1Client)
+	if err != nil {
+		return nil, fmt.Errorf("new withdrawal period: %w", err)
 	}
 
 	return &Hub{
 		dsl: dslService,
-		nta: nta.NewNTA(ctx, config, databaseClient, stakingV2MulticallClient, networkParamsContract, contractGovernanceToken, geoLite2, cacheClient, httpClient, erc20TokenMap, chainL1ID, chainL2ID),
+		nta: nta.NewNTA(ctx, config, databaseClient, stakingV2MulticallClient, networkParamsContract, contractGovernanceToken, geoLite2, cacheClient, httpClient, tvlCalculator, withdrawalPeriod, chainL2ID),
 	}, nil
 }
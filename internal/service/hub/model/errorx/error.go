@@ -17,9 +17,13 @@ const (
 	ErrorCodeBadParams
 	ErrorCodeInternalError
 	ErrorCodeServiceUnavailable
+	ErrorCodeTooManyRequests
 )
 
-var ErrNoNodesAvailable = errors.New("no Nodes are available to process this request")
+var (
+	ErrNoNodesAvailable = errors.New("no Nodes are available to process this request")
+	ErrInvalidCursor    = errors.New("invalid cursor")
+)
 
 type ErrorResponse struct {
 	Error     string    `json:"error"`
@@ -59,6 +63,14 @@ func ServiceUnavailableError(c echo.Context, err error) error {
 	})
 }
 
+func TooManyRequestsError(c echo.Context, err error) error {
+	return c.JSON(http.StatusTooManyRequests, &ErrorResponse{
+		ErrorCode: ErrorCodeTooManyRequests,
+		Error:     "Too many requests, please try again later.",
+		Details:   fmt.Sprintf("%v", err),
+	})
+}
+
 func InternalError(c echo.Context) error {
 	return c.JSON(http.StatusInternalServerError, &ErrorResponse{
 		ErrorCode: ErrorCodeInternalError,
@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _ErrorCodeName = "bad_requestvalidation_failedbad_paramsinternal_errorservice_unavailable"
+const _ErrorCodeName = "bad_requestvalidation_failedbad_paramsinternal_errorservice_unavailabletoo_many_requests"
 
-var _ErrorCodeIndex = [...]uint8{0, 11, 28, 38, 52, 71}
+var _ErrorCodeIndex = [...]uint8{0, 11, 28, 38, 52, 71, 88}
 
-const _ErrorCodeLowerName = "bad_requestvalidation_failedbad_paramsinternal_errorservice_unavailable"
+const _ErrorCodeLowerName = "bad_requestvalidation_failedbad_paramsinternal_errorservice_unavailabletoo_many_requests"
 
 func (i ErrorCode) String() string {
 	i -= 1
@@ -35,9 +35,10 @@ func _ErrorCodeNoOp() {
 	_ = x[ErrorCodeBadParams-(3)]
 	_ = x[ErrorCodeInternalError-(4)]
 	_ = x[ErrorCodeServiceUnavailable-(5)]
+	_ = x[ErrorCodeTooManyRequests-(6)]
 }
 
-var _ErrorCodeValues = []ErrorCode{ErrorCodeBadRequest, ErrorCodeValidationFailed, ErrorCodeBadParams, ErrorCodeInternalError, ErrorCodeServiceUnavailable}
+var _ErrorCodeValues = []ErrorCode{ErrorCodeBadRequest, ErrorCodeValidationFailed, ErrorCodeBadParams, ErrorCodeInternalError, ErrorCodeServiceUnavailable, ErrorCodeTooManyRequests}
 
 var _ErrorCodeNameToValueMap = map[string]ErrorCode{
 	_ErrorCodeName[0:11]:       ErrorCodeBadRequest,
@@ -50,6 +51,8 @@ var _ErrorCodeNameToValueMap = map[string]ErrorCode{
 	_ErrorCodeLowerName[38:52]: ErrorCodeInternalError,
 	_ErrorCodeName[52:71]:      ErrorCodeServiceUnavailable,
 	_ErrorCodeLowerName[52:71]: ErrorCodeServiceUnavailable,
+	_ErrorCodeName[71:88]:      ErrorCodeTooManyRequests,
+	_ErrorCodeLowerName[71:88]: ErrorCodeTooManyRequests,
 }
 
 var _ErrorCodeNames = []string{
@@ -58,6 +61,7 @@ var _ErrorCodeNames = []string{
 	_ErrorCodeName[28:38],
 	_ErrorCodeName[38:52],
 	_ErrorCodeName[52:71],
+	_ErrorCodeName[71:88],
 }
 
 // ErrorCodeString retrieves an enum value from the enum constants string name.
@@ -1,8 +1,12 @@
 package nta
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/contract/l1"
 	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 )
 
@@ -12,7 +16,9 @@ type GetBridgeTransactionsRequest struct {
 	Receiver *common.Address               `query:"receiver"`
 	Address  *common.Address               `query:"address"`
 	Type     *schema.BridgeTransactionType `query:"type"`
-	Limit    int                           `query:"limit" default:"50" min:"1" max:"100"`
+	// Status filters the withdrawals by their status.
+	Status *schema.BridgeWithdrawalStatus `query:"status" validate:"omitempty,oneof=initiated ready_to_prove proven challenge_period ready_to_finalize finalized"`
+	Limit  int                            `query:"limit" default:"50" min:"1" max:"100"`
 }
 
 type GetBridgeTransactionRequest struct {
@@ -46,6 +52,12 @@ type BridgeTransactionEventTypeWithdraw struct {
 	Initialized *BridgeTransactionEvent `json:"initialized,omitempty"`
 	Proved      *BridgeTransactionEvent `json:"proved,omitempty"`
 	Finalized   *BridgeTransactionEvent `json:"finalized,omitempty"`
+
+	Status schema.BridgeWithdrawalStatus `json:"status"`
+	// ProvableAt is the estimated time the withdrawal can be proven.
+	ProvableAt int64 `json:"provable_at"`
+	// FinalizableAt is the time the withdrawal can be finalized, set once it is proven.
+	FinalizableAt *int64 `json:"finalizable_at,omitempty"`
 }
 
 type BridgeTransactionEvent struct {
@@ -63,7 +75,7 @@ type BridgeTokenAddress struct {
 	L2 *common.Address `json:"l2,omitempty"`
 }
 
-func NewBridgeTransaction(transaction *schema.BridgeTransaction, events []*schema.BridgeEvent) *BridgeTransaction {
+func NewBridgeTransaction(transaction *schema.BridgeTransaction, events []*schema.BridgeEvent, withdrawalPeriod l1.WithdrawalPeriod, now time.Time) *BridgeTransaction {
 	transactionModel := BridgeTransaction{
 		ID:       transaction.ID,
 		Sender:   transaction.Sender,
@@ -122,5 +134,43 @@ func NewBridgeTransaction(transaction *schema.BridgeTransaction, events []*schem
 		}
 	}
 
+	if transactionModel.Event.Withdraw != nil {
+		transactionModel.Event.Withdraw.updateStatus(transaction, events, withdrawalPeriod, now)
+	}
+
 	return &transactionModel
 }
+
+// updateStatus computes the status of the withdrawal and the times of its next steps.
+func (w *BridgeTransactionEventTypeWithdraw) updateStatus(transaction *schema.BridgeTransaction, events []*schema.BridgeEvent, withdrawalPeriod l1.WithdrawalPeriod, now time.Time) {
+	provableAt := transaction.BlockTimestamp.Add(withdrawalPeriod.ProposalInterval)
+	w.ProvableAt = provableAt.Unix()
+
+	var proved *schema.BridgeEvent
+
+	for _, event := range events {
+		if event.ID == transaction.ID && event.Type == schema.BridgeEventTypeWithdrawalProved {
+			proved = event
+		}
+	}
+
+	if proved != nil {
+		finalizableAt := proved.BlockTimestamp.Add(withdrawalPeriod.FinalizationPeriod)
+		w.FinalizableAt = lo.ToPtr(finalizableAt.Unix())
+	}
+
+	switch {
+	case w.Finalized != nil:
+		w.Status = schema.BridgeWithdrawalStatusFinalized
+	case proved != nil && !proved.Finalized:
+		w.Status = schema.BridgeWithdrawalStatusProven
+	case proved != nil && now.Unix() < *w.FinalizableAt:
+		w.Status = schema.BridgeWithdrawalStatusChallengePeriod
+	case proved != nil:
+		w.Status = schema.BridgeWithdrawalStatusReadyToFinalize
+	case now.Before(provableAt):
+		w.Status = schema.BridgeWithdrawalStatusInitiated
+	default:
+		w.Status = schema.BridgeWithdrawalStatusReadyToProve
+	}
+}
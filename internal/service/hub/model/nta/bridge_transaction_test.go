@@ -0,0 +1,124 @@
+package nta_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/contract/l1"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBridgeTransactionWithdrawalStatus(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id        = common.HexToHash("0x1")
+		initiated = time.Unix(1700000000, 0)
+		period    = l1.WithdrawalPeriod{ProposalInterval: time.Hour, FinalizationPeriod: 7 * 24 * time.Hour}
+	)
+
+	transaction := schema.BridgeTransaction{
+		ID:             id,
+		Type:           schema.BridgeTransactionTypeWithdraw,
+		TokenValue:     big.NewInt(1),
+		BlockTimestamp: initiated,
+	}
+
+	event := func(eventType schema.BridgeEventType, timestamp time.Time, finalized bool) *schema.BridgeEvent {
+		return &schema.BridgeEvent{
+			ID:             id,
+			Type:           eventType,
+			BlockNumber:    big.NewInt(1),
+			BlockTimestamp: timestamp,
+			Finalized:      finalized,
+		}
+	}
+
+	var (
+		initialized = event(schema.BridgeEventTypeWithdrawalInitialized, initiated, true)
+		proved      = event(schema.BridgeEventTypeWithdrawalProved, initiated.Add(2*time.Hour), true)
+	)
+
+	testcases := []struct {
+		name     string
+		events   []*schema.BridgeEvent
+		now      time.Time
+		expected schema.BridgeWithdrawalStatus
+	}{
+		{
+			name:     "initiated",
+			events:   []*schema.BridgeEvent{initialized},
+			now:      initiated.Add(time.Minute),
+			expected: schema.BridgeWithdrawalStatusInitiated,
+		},
+		{
+			name:     "ready to prove",
+			events:   []*schema.BridgeEvent{initialized},
+			now:      initiated.Add(time.Hour),
+			expected: schema.BridgeWithdrawalStatusReadyToProve,
+		},
+		{
+			name:     "proven",
+			events:   []*schema.BridgeEvent{event(schema.BridgeEventTypeWithdrawalProved, initiated.Add(2*time.Hour), false), initialized},
+			now:      initiated.Add(2 * time.Hour),
+			expected: schema.BridgeWithdrawalStatusProven,
+		},
+		{
+			name:     "challenge period",
+			events:   []*schema.BridgeEvent{proved, initialized},
+			now:      initiated.Add(3 * time.Hour),
+			expected: schema.BridgeWithdrawalStatusChallengePeriod,
+		},
+		{
+			name:     "ready to finalize",
+			events:   []*schema.BridgeEvent{proved, initialized},
+			now:      initiated.Add(2*time.Hour + period.FinalizationPeriod),
+			expected: schema.BridgeWithdrawalStatusReadyToFinalize,
+		},
+		{
+			name:     "finalized",
+			events:   []*schema.BridgeEvent{event(schema.BridgeEventTypeWithdrawalFinalized, initiated.Add(200*time.Hour), false), proved, initialized},
+			now:      initiated.Add(200 * time.Hour),
+			expected: schema.BridgeWithdrawalStatusFinalized,
+		},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			withdraw := nta.NewBridgeTransaction(&transaction, testcase.events, period, testcase.now).Event.Withdraw
+
+			require.NotNil(t, withdraw)
+			require.Equal(t, testcase.expected, withdraw.Status)
+			require.Equal(t, initiated.Add(time.Hour).Unix(), withdraw.ProvableAt)
+
+			if withdraw.Proved != nil {
+				require.NotNil(t, withdraw.FinalizableAt)
+				require.Equal(t, initiated.Add(2*time.Hour+period.FinalizationPeriod).Unix(), *withdraw.FinalizableAt)
+			} else {
+				require.Nil(t, withdraw.FinalizableAt)
+			}
+		})
+	}
+}
+
+func TestNewBridgeTransactionDeposit(t *testing.T) {
+	t.Parallel()
+
+	transaction := schema.BridgeTransaction{
+		Type:       schema.BridgeTransactionTypeDeposit,
+		TokenValue: big.NewInt(1),
+	}
+
+	model := nta.NewBridgeTransaction(&transaction, nil, l1.WithdrawalPeriod{}, time.Now())
+
+	require.NotNil(t, model.Event.Deposit)
+	require.Nil(t, model.Event.Withdraw)
+}
@@ -31,6 +31,11 @@ type GetEpochNodeRewardsRequest struct {
 	Cursor      *string        `query:"cursor"`
 }
 
+type GetEpochNodeScoreBreakdownRequest struct {
+	EpochID     uint64         `param:"epoch_id" validate:"required"`
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+}
+
 type GetEpochsResponseData []*Epoch
 
 type GetEpochResponseData *Epoch
@@ -39,6 +44,8 @@ type GetEpochDistributionResponseData *EpochTransaction
 
 type GetEpochNodeRewardsResponseData *Epoch
 
+type GetEpochNodeScoreBreakdownResponseData *schema.NodeScoreBreakdown
+
 type Epoch struct {
 	ID                    uint64          `json:"id"`
 	StartTimestamp        int64           `json:"start_timestamp"`
@@ -0,0 +1,338 @@
+package nta
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+type ExportCategory string
+
+const (
+	ExportCategoryReward ExportCategory = "reward"
+	ExportCategoryStake  ExportCategory = "stake"
+	ExportCategoryBridge ExportCategory = "bridge"
+)
+
+const (
+	ExportTypeOperationRewards = "operation_rewards"
+	ExportTypeStakingRewards   = "staking_rewards"
+	ExportTypeTaxCollected     = "tax_collected"
+)
+
+// tokenDecimals is the number of decimals of the RSS3 token.
+const tokenDecimals = 18
+
+type ExportRequest struct {
+	SinceTimestamp *uint64      `query:"since_timestamp"`
+	UntilTimestamp *uint64      `query:"until_timestamp"`
+	Format         ExportFormat `query:"format" default:"csv" validate:"oneof=csv json"`
+}
+
+// Since returns the beginning of the range, nil if unbounded.
+func (r ExportRequest) Since() *time.Time {
+	if r.SinceTimestamp == nil {
+		return nil
+	}
+
+	return lo.ToPtr(time.Unix(int64(*r.SinceTimestamp), 0))
+}
+
+// Until returns the end of the range, nil if unbounded.
+func (r ExportRequest) Until() *time.Time {
+	if r.UntilTimestamp == nil {
+		return nil
+	}
+
+	return lo.ToPtr(time.Unix(int64(*r.UntilTimestamp), 0))
+}
+
+type GetStakerExportRequest struct {
+	StakerAddress common.Address `param:"staker_address" validate:"required"`
+	ExportRequest
+}
+
+type GetNodeExportRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	ExportRequest
+}
+
+// ExportRecord is a row of an export, the amount is given both in wei and in tokens.
+type ExportRecord struct {
+	Timestamp int64           `json:"timestamp"`
+	Category  ExportCategory  `json:"category"`
+	Type      string          `json:"type"`
+	EpochID   *uint64         `json:"epoch_id,omitempty"`
+	Node      *common.Address `json:"node,omitempty"`
+	// Token is the L1 address of the token bridged.
+	Token *common.Address `json:"token,omitempty"`
+	// ID is the ID of the stake or bridge transaction, or the hash of the epoch transaction.
+	ID        *common.Hash    `json:"id,omitempty"`
+	AmountWei decimal.Decimal `json:"amount_wei"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+// exportHeader is the header of the CSV exports.
+var exportHeader = []string{"date", "timestamp", "category", "type", "epoch_id", "node", "token", "id", "amount_wei", "amount"}
+
+// exportErrorTrailer is the last row of a failed CSV export.
+var exportErrorTrailer = []string{"error", "the export is incomplete"}
+
+func (r *ExportRecord) setAmount(amountWei decimal.Decimal) {
+	r.AmountWei = amountWei
+	r.Amount = amountWei.Shift(-tokenDecimals)
+}
+
+func (r *ExportRecord) csv() []string {
+	var epochID string
+	if r.EpochID != nil {
+		epochID = strconv.FormatUint(*r.EpochID, 10)
+	}
+
+	return []string{
+		time.Unix(r.Timestamp, 0).UTC().Format(time.RFC3339),
+		strconv.FormatInt(r.Timestamp, 10),
+		string(r.Category),
+		r.Type,
+		epochID,
+		optionalString(r.Node),
+		optionalString(r.Token),
+		optionalString(r.ID),
+		r.AmountWei.String(),
+		r.Amount.String(),
+	}
+}
+
+// optionalString returns the string of the value, empty if nil.
+func optionalString[T fmt.Stringer](value *T) string {
+	if value == nil {
+		return ""
+	}
+
+	return (*value).String()
+}
+
+func NewStakeTransactionExportRecord(transaction *schema.StakeTransaction) *ExportRecord {
+	record := ExportRecord{
+		Timestamp: transaction.BlockTimestamp.Unix(),
+		Category:  ExportCategoryStake,
+		Type:      string(transaction.Type),
+		Node:      lo.ToPtr(transaction.Node),
+		ID:        lo.ToPtr(transaction.ID),
+	}
+
+	record.setAmount(decimal.NewFromBigInt(lo.Ternary(transaction.Value != nil, transaction.Value, big.NewInt(0)), 0))
+
+	return &record
+}
+
+func NewBridgeTransactionExportRecord(transaction *schema.BridgeTransaction) *ExportRecord {
+	record := ExportRecord{
+		Timestamp: transaction.BlockTimestamp.Unix(),
+		Category:  ExportCategoryBridge,
+		Type:      string(transaction.Type),
+		Token:     transaction.TokenAddressL1,
+		ID:        lo.ToPtr(transaction.ID),
+	}
+
+	record.setAmount(decimal.NewFromBigInt(lo.Ternary(transaction.TokenValue != nil, transaction.TokenValue, big.NewInt(0)), 0))
+
+	return &record
+}
+
+// NewEpochNodeRewardExportRecords returns a record for each kind of rewards the Nodes received in the epoch.
+func NewEpochNodeRewardExportRecords(epoch *schema.Epoch) []*ExportRecord {
+	records := make([]*ExportRecord, 0, len(epoch.RewardedNodes)*3)
+
+	for _, rewardedNode := range epoch.RewardedNodes {
+		for _, reward := range []struct {
+			Type   string
+			Amount decimal.Decimal
+		}{
+			{ExportTypeOperationRewards, rewardedNode.OperationRewards},
+			{ExportTypeStakingRewards, rewardedNode.StakingRewards},
+			{ExportTypeTaxCollected, rewardedNode.TaxCollected},
+		} {
+			record := ExportRecord{
+				Timestamp: epoch.BlockTimestamp,
+				Category:  ExportCategoryReward,
+				Type:      reward.Type,
+				EpochID:   lo.ToPtr(epoch.ID),
+				Node:      lo.ToPtr(rewardedNode.NodeAddress),
+				ID:        lo.ToPtr(epoch.TransactionHash),
+			}
+
+			record.setAmount(reward.Amount)
+
+			records = append(records, &record)
+		}
+	}
+
+	return records
+}
+
+// StakerRewards derives the rewards of a staker in each epoch from its profit snapshots,
+// which are the change in value of its chips less the value staked and unstaked in between.
+type StakerRewards struct {
+	flows    []stakerRewardsFlow
+	previous decimal.Decimal
+}
+
+type stakerRewardsFlow struct {
+	timestamp time.Time
+	value     decimal.Decimal
+}
+
+// AddStakeTransaction records the value staked or unstaked by the transaction,
+// all transactions must be added in chronological order before the snapshots.
+func (r *StakerRewards) AddStakeTransaction(transaction *schema.StakeTransaction) {
+	if transaction.Value == nil {
+		return
+	}
+
+	value := decimal.NewFromBigInt(transaction.Value, 0)
+
+	switch transaction.Type {
+	case schema.StakeTransactionTypeStake:
+	case schema.StakeTransactionTypeUnstake:
+		value = value.Neg()
+	default:
+		return
+	}
+
+	r.flows = append(r.flows, stakerRewardsFlow{
+		timestamp: transaction.BlockTimestamp,
+		value:     value,
+	})
+}
+
+// Next returns the rewards of the epoch of the snapshot, the snapshots must be passed in ascending order of epochs.
+func (r *StakerRewards) Next(snapshot *schema.StakerProfitSnapshot) *ExportRecord {
+	rewards := snapshot.TotalChipValue.Sub(r.previous)
+
+	for len(r.flows) > 0 && !r.flows[0].timestamp.After(snapshot.Date) {
+		rewards = rewards.Sub(r.flows[0].value)
+		r.flows = r.flows[1:]
+	}
+
+	r.previous = snapshot.TotalChipValue
+
+	record := ExportRecord{
+		Timestamp: snapshot.Date.Unix(),
+		Category:  ExportCategoryReward,
+		Type:      ExportTypeStakingRewards,
+		EpochID:   lo.ToPtr(snapshot.EpochID),
+	}
+
+	record.setAmount(rewards)
+
+	return &record
+}
+
+// ExportWriter writes the records of an export in a format.
+type ExportWriter interface {
+	Write(record *ExportRecord) error
+	// Close completes the export, it must be called even if no record is written.
+	Close() error
+	// Abort ends an export failed after some records are written, so it is not mistaken for a complete one.
+	Abort() error
+}
+
+func NewExportWriter(writer io.Writer, format ExportFormat) ExportWriter {
+	if format == ExportFormatJSON {
+		return &jsonExportWriter{writer: writer}
+	}
+
+	return &csvExportWriter{writer: csv.NewWriter(writer)}
+}
+
+type csvExportWriter struct {
+	writer  *csv.Writer
+	started bool
+}
+
+func (w *csvExportWriter) start() error {
+	if w.started {
+		return nil
+	}
+
+	w.started = true
+
+	return w.writer.Write(exportHeader)
+}
+
+func (w *csvExportWriter) Write(record *ExportRecord) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	return w.writer.Write(record.csv())
+}
+
+func (w *csvExportWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// Abort writes an error row as the trailer of the CSV.
+func (w *csvExportWriter) Abort() error {
+	if err := w.writer.Write(exportErrorTrailer); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// jsonExportWriter writes the records as a JSON array, one record at a time.
+type jsonExportWriter struct {
+	writer  io.Writer
+	started bool
+}
+
+func (w *jsonExportWriter) Write(record *ExportRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal export record: %w", err)
+	}
+
+	delimiter := lo.Ternary(w.started, ",\n", "[\n")
+	w.started = true
+
+	_, err = w.writer.Write(append([]byte(delimiter), data...))
+
+	return err
+}
+
+func (w *jsonExportWriter) Close() error {
+	_, err := io.WriteString(w.writer, lo.Ternary(w.started, "\n]\n", "[]\n"))
+
+	return err
+}
+
+// Abort leaves the array unterminated, so the JSON fails to parse.
+func (w *jsonExportWriter) Abort() error {
+	return nil
+}
@@ -0,0 +1,152 @@
+package nta_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStakerRewards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		staker = common.HexToAddress("0x1")
+		epoch  = time.Unix(1700000000, 0)
+		ether  = decimal.New(1, 18)
+	)
+
+	var rewards nta.StakerRewards
+
+	for _, transaction := range []*schema.StakeTransaction{
+		{Type: schema.StakeTransactionTypeStake, User: staker, Value: ether.Mul(decimal.NewFromInt(100)).BigInt(), BlockTimestamp: epoch.Add(-time.Hour)},
+		{Type: schema.StakeTransactionTypeMergeChips, User: staker, Value: ether.BigInt(), BlockTimestamp: epoch.Add(time.Hour)},
+		{Type: schema.StakeTransactionTypeStake, User: staker, Value: ether.Mul(decimal.NewFromInt(50)).BigInt(), BlockTimestamp: epoch.Add(2 * time.Hour)},
+		{Type: schema.StakeTransactionTypeUnstake, User: staker, Value: ether.Mul(decimal.NewFromInt(30)).BigInt(), BlockTimestamp: epoch.Add(3 * time.Hour)},
+	} {
+		rewards.AddStakeTransaction(transaction)
+	}
+
+	// The rewards of the first snapshot are all the rewards accrued before it.
+	first := rewards.Next(&schema.StakerProfitSnapshot{EpochID: 1, Date: epoch, OwnerAddress: staker, TotalChipValue: ether.Mul(decimal.NewFromInt(102))})
+	require.Equal(t, nta.ExportCategoryReward, first.Category)
+	require.Equal(t, uint64(1), *first.EpochID)
+	require.Equal(t, "2000000000000000000", first.AmountWei.String())
+	require.Equal(t, "2", first.Amount.String())
+
+	// 102 + 50 - 30 staked and unstaked in between.
+	second := rewards.Next(&schema.StakerProfitSnapshot{EpochID: 2, Date: epoch.Add(24 * time.Hour), OwnerAddress: staker, TotalChipValue: decimal.RequireFromString("125500000000000000000")})
+	require.Equal(t, "3.5", second.Amount.String())
+	require.Equal(t, epoch.Add(24*time.Hour).Unix(), second.Timestamp)
+}
+
+func TestNewEpochNodeRewardExportRecords(t *testing.T) {
+	t.Parallel()
+
+	epoch := schema.Epoch{
+		ID:              7,
+		TransactionHash: common.HexToHash("0x7"),
+		BlockTimestamp:  1700000000,
+		RewardedNodes: []*schema.RewardedNode{
+			{
+				EpochID:          7,
+				NodeAddress:      common.HexToAddress("0x2"),
+				OperationRewards: decimal.RequireFromString("1500000000000000000"),
+				StakingRewards:   decimal.RequireFromString("250000000000000000"),
+				TaxCollected:     decimal.Zero,
+			},
+		},
+	}
+
+	records := nta.NewEpochNodeRewardExportRecords(&epoch)
+	require.Len(t, records, 3)
+	require.Equal(t, nta.ExportTypeOperationRewards, records[0].Type)
+	require.Equal(t, "1.5", records[0].Amount.String())
+	require.Equal(t, nta.ExportTypeStakingRewards, records[1].Type)
+	require.Equal(t, "0.25", records[1].Amount.String())
+	require.Equal(t, nta.ExportTypeTaxCollected, records[2].Type)
+	require.Equal(t, "0", records[2].AmountWei.String())
+}
+
+func TestExportWriter(t *testing.T) {
+	t.Parallel()
+
+	record := nta.NewStakeTransactionExportRecord(&schema.StakeTransaction{
+		ID:             common.HexToHash("0x10"),
+		Type:           schema.StakeTransactionTypeStake,
+		Node:           common.HexToAddress("0x2"),
+		Value:          big.NewInt(1234500000000000000),
+		BlockTimestamp: time.Unix(1700000000, 0),
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		var buffer bytes.Buffer
+
+		writer := nta.NewExportWriter(&buffer, nta.ExportFormatCSV)
+		require.NoError(t, writer.Write(record))
+		require.NoError(t, writer.Close())
+
+		require.Equal(t,
+			"date,timestamp,category,type,epoch_id,node,token,id,amount_wei,amount\n"+
+				"2023-11-14T22:13:20Z,1700000000,stake,stake,,0x0000000000000000000000000000000000000002,,0x0000000000000000000000000000000000000000000000000000000000000010,1234500000000000000,1.2345\n",
+			buffer.String(),
+		)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buffer bytes.Buffer
+
+		writer := nta.NewExportWriter(&buffer, nta.ExportFormatJSON)
+		require.NoError(t, writer.Write(record))
+		require.NoError(t, writer.Write(record))
+		require.NoError(t, writer.Close())
+
+		var records []map[string]any
+
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &records))
+		require.Len(t, records, 2)
+		require.Equal(t, "1234500000000000000", records[0]["amount_wei"])
+		require.Equal(t, "1.2345", records[0]["amount"])
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		t.Parallel()
+
+		var csvBuffer, jsonBuffer bytes.Buffer
+
+		csvWriter := nta.NewExportWriter(&csvBuffer, nta.ExportFormatCSV)
+		require.NoError(t, csvWriter.Write(record))
+		require.NoError(t, csvWriter.Abort())
+
+		jsonWriter := nta.NewExportWriter(&jsonBuffer, nta.ExportFormatJSON)
+		require.NoError(t, jsonWriter.Write(record))
+		require.NoError(t, jsonWriter.Abort())
+
+		require.True(t, strings.HasSuffix(csvBuffer.String(), "\nerror,the export is incomplete\n"))
+		require.False(t, json.Valid(jsonBuffer.Bytes()))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		var csvBuffer, jsonBuffer bytes.Buffer
+
+		require.NoError(t, nta.NewExportWriter(&csvBuffer, nta.ExportFormatCSV).Close())
+		require.NoError(t, nta.NewExportWriter(&jsonBuffer, nta.ExportFormatJSON).Close())
+
+		require.Equal(t, "date,timestamp,category,type,epoch_id,node,token,id,amount_wei,amount\n", csvBuffer.String())
+		require.JSONEq(t, "[]", jsonBuffer.String())
+	})
+}
@@ -1,5 +1,13 @@
 package nta
 
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+)
+
 type NetworkRequest struct {
 	NetworkName string `param:"network_name" validate:"required"`
 }
@@ -23,3 +31,201 @@ type Asset struct {
 	Platform string `json:"platform,omitempty"`
 	IconURL  string `json:"icon_url"`
 }
+
+type GetNetworkDecentralizationRequest struct {
+	EpochID *uint64 `query:"epoch_id"`
+}
+
+type GetDecentralizationSnapshotsRequest struct {
+	Cursor *uint64 `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"50"`
+}
+
+// NetworkCoverage is the coverage of the networks, platforms and workers by the Nodes.
+type NetworkCoverage struct {
+	// RequiredNodes is the number of online Nodes required to serve a request.
+	RequiredNodes int `json:"required_nodes"`
+	// FullNodes serve every network, platform and worker.
+	FullNodes []*CoverageNode `json:"full_nodes"`
+	Networks  []*Coverage     `json:"networks"`
+	Platforms []*Coverage     `json:"platforms"`
+	Workers   []*Coverage     `json:"workers"`
+	// Gaps are the networks, platforms and workers served by less than the required online Nodes.
+	Gaps []*CoverageGap `json:"gaps"`
+}
+
+type Coverage struct {
+	Name     string   `json:"name"`
+	Workers  []string `json:"workers,omitempty"`
+	Networks []string `json:"networks,omitempty"`
+	// Nodes are the light Nodes serving it, excluding the full Nodes.
+	Nodes []*CoverageNode `json:"nodes"`
+	// OnlineNodes is the number of online light and full Nodes serving it.
+	OnlineNodes int  `json:"online_nodes"`
+	Covered     bool `json:"covered"`
+}
+
+type CoverageNode struct {
+	Address common.Address    `json:"address"`
+	Status  schema.NodeStatus `json:"status"`
+}
+
+type CoverageGap struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	OnlineNodes int    `json:"online_nodes"`
+}
+
+const (
+	CoverageTypeNetwork  = "network"
+	CoverageTypePlatform = "platform"
+	CoverageTypeWorker   = "worker"
+)
+
+// NewNetworkCoverage matches the Nodes in the same way as the distributor,
+// a light Node serves a network if it runs all workers of the network,
+// a worker if it runs the worker on all networks, and a platform if it serves all workers of the platform.
+func NewNetworkCoverage(workerToNetworks, networkToWorkers, platformToWorkers map[string][]string, nodes []*schema.Node, fullNodes []*schema.Stat, workers []*schema.Worker, requiredNodes int) *NetworkCoverage {
+	statuses := lo.SliceToMap(nodes, func(node *schema.Node) (common.Address, schema.NodeStatus) {
+		return node.Address, node.Status
+	})
+
+	coverage := NetworkCoverage{
+		RequiredNodes: requiredNodes,
+		FullNodes:     make([]*CoverageNode, 0, len(fullNodes)),
+		Networks:      make([]*Coverage, 0, len(networkToWorkers)),
+		Platforms:     make([]*Coverage, 0, len(platformToWorkers)),
+		Workers:       make([]*Coverage, 0, len(workerToNetworks)),
+		Gaps:          make([]*CoverageGap, 0),
+	}
+
+	var onlineFullNodes int
+
+	for _, stat := range fullNodes {
+		status, exists := statuses[stat.Address]
+		if !exists {
+			continue
+		}
+
+		coverage.FullNodes = append(coverage.FullNodes, &CoverageNode{Address: stat.Address, Status: status})
+
+		if status == schema.NodeStatusOnline {
+			onlineFullNodes++
+		}
+	}
+
+	// nodeWorkers maps the light Nodes to their workers and the networks of the workers.
+	nodeWorkers := make(map[common.Address]map[string]map[string]struct{})
+
+	for _, worker := range workers {
+		if _, exists := statuses[worker.Address]; !exists || !worker.IsActive {
+			continue
+		}
+
+		if nodeWorkers[worker.Address] == nil {
+			nodeWorkers[worker.Address] = make(map[string]map[string]struct{})
+		}
+
+		if nodeWorkers[worker.Address][worker.Name] == nil {
+			nodeWorkers[worker.Address][worker.Name] = make(map[string]struct{})
+		}
+
+		nodeWorkers[worker.Address][worker.Name][worker.Network] = struct{}{}
+	}
+
+	runs := func(address common.Address, worker, network string) bool {
+		_, exists := nodeWorkers[address][worker][network]
+
+		return exists
+	}
+
+	servesWorker := func(address common.Address, worker string) bool {
+		return len(workerToNetworks[worker]) > 0 && lo.EveryBy(workerToNetworks[worker], func(network string) bool {
+			return runs(address, worker, network)
+		})
+	}
+
+	build := func(coverageType, name string, serves func(address common.Address) bool) *Coverage {
+		item := Coverage{
+			Name:        name,
+			Nodes:       make([]*CoverageNode, 0),
+			OnlineNodes: onlineFullNodes,
+		}
+
+		for address := range nodeWorkers {
+			if !serves(address) {
+				continue
+			}
+
+			item.Nodes = append(item.Nodes, &CoverageNode{Address: address, Status: statuses[address]})
+
+			if statuses[address] == schema.NodeStatusOnline {
+				item.OnlineNodes++
+			}
+		}
+
+		sort.Slice(item.Nodes, func(i, j int) bool {
+			return item.Nodes[i].Address.Cmp(item.Nodes[j].Address) < 0
+		})
+
+		item.Covered = item.OnlineNodes >= requiredNodes
+
+		if !item.Covered {
+			coverage.Gaps = append(coverage.Gaps, &CoverageGap{Type: coverageType, Name: name, OnlineNodes: item.OnlineNodes})
+		}
+
+		return &item
+	}
+
+	for _, network := range sortedKeys(networkToWorkers) {
+		required := networkToWorkers[network]
+
+		item := build(CoverageTypeNetwork, network, func(address common.Address) bool {
+			return len(required) > 0 && lo.EveryBy(required, func(worker string) bool {
+				return runs(address, worker, network)
+			})
+		})
+		item.Workers = lo.Uniq(required)
+		sort.Strings(item.Workers)
+
+		coverage.Networks = append(coverage.Networks, item)
+	}
+
+	for _, platform := range sortedKeys(platformToWorkers) {
+		required := platformToWorkers[platform]
+
+		item := build(CoverageTypePlatform, platform, func(address common.Address) bool {
+			return len(required) > 0 && lo.EveryBy(required, func(worker string) bool {
+				return servesWorker(address, worker)
+			})
+		})
+		item.Workers = lo.Uniq(required)
+		sort.Strings(item.Workers)
+
+		coverage.Platforms = append(coverage.Platforms, item)
+	}
+
+	for _, worker := range sortedKeys(workerToNetworks) {
+		item := build(CoverageTypeWorker, worker, func(address common.Address) bool {
+			return servesWorker(address, worker)
+		})
+		item.Networks = lo.Uniq(workerToNetworks[worker])
+		sort.Strings(item.Networks)
+
+		coverage.Workers = append(coverage.Workers, item)
+	}
+
+	// The least served come first.
+	sort.SliceStable(coverage.Gaps, func(i, j int) bool {
+		return coverage.Gaps[i].OnlineNodes < coverage.Gaps[j].OnlineNodes
+	})
+
+	return &coverage
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+
+	return keys
+}
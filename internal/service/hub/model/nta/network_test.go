@@ -0,0 +1,103 @@
+package nta_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetworkCoverage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addressFull    = common.HexToAddress("0x1")
+		addressLight   = common.HexToAddress("0x2")
+		addressPartial = common.HexToAddress("0x3")
+		addressOffline = common.HexToAddress("0x4")
+	)
+
+	var (
+		workerToNetworks = map[string][]string{
+			"core":      {"ethereum", "farcaster"},
+			"uniswap":   {"ethereum"},
+			"mirror":    {"arweave"},
+			"paragraph": {"arweave"},
+		}
+		networkToWorkers = map[string][]string{
+			"ethereum":  {"core", "uniswap"},
+			"farcaster": {"core"},
+			"arweave":   {"mirror", "paragraph"},
+		}
+		platformToWorkers = map[string][]string{
+			"Uniswap": {"uniswap"},
+			"Mirror":  {"mirror"},
+		}
+	)
+
+	nodes := []*schema.Node{
+		{Address: addressFull, Status: schema.NodeStatusOnline},
+		{Address: addressLight, Status: schema.NodeStatusOnline},
+		{Address: addressPartial, Status: schema.NodeStatusOnline},
+		{Address: addressOffline, Status: schema.NodeStatusOffline},
+	}
+
+	fullNodes := []*schema.Stat{{Address: addressFull, IsFullNode: true}}
+
+	workers := []*schema.Worker{
+		{Address: addressLight, Network: "ethereum", Name: "core", IsActive: true},
+		{Address: addressLight, Network: "ethereum", Name: "uniswap", IsActive: true},
+		{Address: addressLight, Network: "farcaster", Name: "core", IsActive: true},
+		// The partial Node runs the core worker on ethereum only, so it serves neither the core worker nor ethereum.
+		{Address: addressPartial, Network: "ethereum", Name: "core", IsActive: true},
+		{Address: addressPartial, Network: "arweave", Name: "mirror", IsActive: true},
+		{Address: addressOffline, Network: "farcaster", Name: "core", IsActive: true},
+	}
+
+	coverage := nta.NewNetworkCoverage(workerToNetworks, networkToWorkers, platformToWorkers, nodes, fullNodes, workers, 2)
+
+	require.Len(t, coverage.FullNodes, 1)
+	assert.Equal(t, 2, coverage.RequiredNodes)
+
+	find := func(items []*nta.Coverage, name string) *nta.Coverage {
+		for _, item := range items {
+			if item.Name == name {
+				return item
+			}
+		}
+
+		require.FailNow(t, "coverage not found", name)
+
+		return nil
+	}
+
+	ethereum := find(coverage.Networks, "ethereum")
+	assert.Equal(t, []*nta.CoverageNode{{Address: addressLight, Status: schema.NodeStatusOnline}}, ethereum.Nodes)
+	assert.Equal(t, 2, ethereum.OnlineNodes)
+	assert.True(t, ethereum.Covered)
+	assert.Equal(t, []string{"core", "uniswap"}, ethereum.Workers)
+
+	farcaster := find(coverage.Networks, "farcaster")
+	assert.Len(t, farcaster.Nodes, 2)
+	assert.Equal(t, 2, farcaster.OnlineNodes)
+
+	// The arweave network requires the paragraph worker which no light Node runs.
+	arweave := find(coverage.Networks, "arweave")
+	assert.Empty(t, arweave.Nodes)
+	assert.False(t, arweave.Covered)
+
+	core := find(coverage.Workers, "core")
+	assert.Len(t, core.Nodes, 1)
+	assert.Equal(t, 2, core.OnlineNodes)
+
+	mirror := find(coverage.Platforms, "Mirror")
+	assert.Equal(t, 2, mirror.OnlineNodes)
+
+	assert.Equal(t, []*nta.CoverageGap{
+		{Type: nta.CoverageTypeNetwork, Name: "arweave", OnlineNodes: 1},
+		{Type: nta.CoverageTypeWorker, Name: "paragraph", OnlineNodes: 1},
+	}, coverage.Gaps)
+}
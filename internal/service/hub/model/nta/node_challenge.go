@@ -5,6 +5,14 @@ import "github.com/ethereum/go-ethereum/common"
 type NodeChallengeRequest struct {
 	NodeAddress common.Address `param:"node_address" validate:"required"`
 	Type        string         `query:"type"`
+	// InvalidResponseID is required when Type is appeal.
+	InvalidResponseID *uint64 `query:"invalid_response_id"`
+	// URL is required when Type is webhook.
+	URL *string `query:"url"`
+	// Timestamp is required when Type is webhook or webhookRead, the signature expires after webhookChallengeExpiration.
+	Timestamp *int64 `query:"timestamp"`
+	// WebhookID is required when Type is webhookRemoval.
+	WebhookID *uint64 `query:"webhook_id"`
 }
 
 type NodeChallengeResponseData string
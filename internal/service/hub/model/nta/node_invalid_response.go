@@ -0,0 +1,26 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+type NodeInvalidResponsesRequest struct {
+	NodeAddress  common.Address `param:"node_address" validate:"required"`
+	EpochID      *uint64        `query:"epoch_id"`
+	Type         *string        `query:"type" validate:"omitempty,oneof=inconsistent error offline"`
+	AppealStatus *string        `query:"appeal_status" validate:"omitempty,oneof=pending upheld rejected"`
+	Cursor       *uint64        `query:"cursor"`
+	Limit        int            `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type NodeInvalidResponseAppealRequest struct {
+	NodeAddress       common.Address `param:"node_address" validate:"required"`
+	InvalidResponseID uint64         `param:"id" validate:"required"`
+	Signature         string         `json:"signature" validate:"required"`
+	Reason            string         `json:"reason" validate:"max=1024"`
+}
+
+type NodeInvalidResponseResponseData *schema.NodeInvalidResponse
+
+type NodeInvalidResponsesResponseData []*schema.NodeInvalidResponse
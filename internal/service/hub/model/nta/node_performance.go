@@ -0,0 +1,44 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/performance"
+)
+
+type NodePerformanceRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	// EpochID is the latest epoch returned, the current epoch of the Node by default.
+	EpochID *int64 `query:"epoch_id" validate:"omitempty,min=0"`
+	Limit   int    `query:"limit" validate:"min=1,max=50" default:"10"`
+}
+
+type NodePerformance struct {
+	EpochID      int64   `json:"epoch_id"`
+	RequestCount int64   `json:"request_count"`
+	ErrorCount   int64   `json:"error_count"`
+	ErrorRate    float64 `json:"error_rate"`
+	// Latencies are in milliseconds.
+	LatencyP50 int64 `json:"latency_p50"`
+	LatencyP95 int64 `json:"latency_p95"`
+	LatencyP99 int64 `json:"latency_p99"`
+}
+
+type NodePerformanceResponseData []*NodePerformance
+
+func NewNodePerformances(performances []*performance.Performance) NodePerformanceResponseData {
+	data := make(NodePerformanceResponseData, len(performances))
+
+	for i, nodePerformance := range performances {
+		data[i] = &NodePerformance{
+			EpochID:      nodePerformance.EpochID,
+			RequestCount: nodePerformance.RequestCount,
+			ErrorCount:   nodePerformance.ErrorCount,
+			ErrorRate:    nodePerformance.ErrorRate(),
+			LatencyP50:   nodePerformance.Percentile(50).Milliseconds(),
+			LatencyP95:   nodePerformance.Percentile(95).Milliseconds(),
+			LatencyP99:   nodePerformance.Percentile(99).Milliseconds(),
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,49 @@
+package nta
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+// NodeWebhookReadRequest is signed by the Node to read its webhooks, which contain the secret tokens of their URLs.
+type NodeWebhookReadRequest struct {
+	// Timestamp is the unix timestamp of the webhookRead challenge signed.
+	Timestamp int64  `query:"timestamp" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
+
+type NodeWebhooksRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	NodeWebhookReadRequest
+}
+
+type NodeWebhookRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	URL         string         `json:"url" validate:"required,url,max=2048"`
+	// Events are the event types to subscribe, all event types if empty.
+	Events []string `json:"events" validate:"dive,oneof=node.status_changed node.demoted node.heartbeat_missed node.invalid_response node.slashed epoch.rewarded"`
+	// Timestamp is the unix timestamp of the challenge signed.
+	Timestamp int64  `json:"timestamp" validate:"required"`
+	Signature string `json:"signature" validate:"required"`
+}
+
+type NodeWebhookRemovalRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	WebhookID   uint64         `param:"id" validate:"required"`
+	Signature   string         `json:"signature" validate:"required"`
+}
+
+type NodeWebhookDeliveriesRequest struct {
+	NodeAddress common.Address `param:"node_address" validate:"required"`
+	WebhookID   uint64         `param:"id" validate:"required"`
+	Status      *string        `query:"status" validate:"omitempty,oneof=pending delivered failed"`
+	Cursor      *uint64        `query:"cursor"`
+	Limit       int            `query:"limit" validate:"min=1,max=100" default:"20"`
+	NodeWebhookReadRequest
+}
+
+type NodeWebhookResponseData *schema.NodeWebhook
+
+type NodeWebhooksResponseData []*schema.NodeWebhook
+
+type NodeWebhookDeliveriesResponseData []*schema.NodeWebhookDelivery
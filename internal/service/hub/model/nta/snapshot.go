@@ -24,6 +24,11 @@ type GetNodeOperationProfitSnapshotsRequest struct {
 	AfterDate   *time.Time     `query:"after_date"`
 }
 
+type GetTVLSnapshotsRequest struct {
+	Cursor *uint64 `query:"cursor"`
+	Limit  int     `query:"limit" validate:"min=1,max=100" default:"50"`
+}
+
 type GetNodeCountSnapshotsResponseData []*CountSnapshot
 
 type GetStakerCountSnapshotsResponseData []*CountSnapshot
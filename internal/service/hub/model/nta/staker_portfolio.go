@@ -0,0 +1,122 @@
+package nta
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type GetStakerPortfolioRequest struct {
+	StakerAddress common.Address `param:"staker_address" validate:"required"`
+}
+
+type GetStakerPortfolioResponseData *StakerPortfolio
+
+type StakerPortfolio struct {
+	Staker common.Address `json:"staker"`
+	// StakedValue is the value of the chips held when they were minted.
+	StakedValue decimal.Decimal `json:"staked_value"`
+	// CurrentValue is the latest value of the chips held.
+	CurrentValue decimal.Decimal `json:"current_value"`
+	// RealizedProfit is the profit of the chips unstaked, which is locked once the unstaking is requested.
+	RealizedProfit decimal.Decimal `json:"realized_profit"`
+	// UnrealizedProfit is the profit of the chips held.
+	UnrealizedProfit decimal.Decimal              `json:"unrealized_profit"`
+	Chips            []*StakerPortfolioChip       `json:"chips"`
+	PendingUnstakes  []*StakerPortfolioUnstake    `json:"pending_unstakes"`
+	Profit           *GetStakerProfitResponseData `json:"profit"`
+}
+
+type StakerPortfolioChip struct {
+	ID          *big.Int        `json:"id"`
+	Node        common.Address  `json:"node"`
+	Value       decimal.Decimal `json:"value"`
+	LatestValue decimal.Decimal `json:"latest_value"`
+	// Rewards are the rewards accrued by the chip since it was minted.
+	Rewards   decimal.Decimal `json:"rewards"`
+	StakedAt  int64           `json:"staked_at"`
+	Finalized bool            `json:"finalized"`
+}
+
+type StakerPortfolioUnstake struct {
+	ID          common.Hash     `json:"id"`
+	Node        common.Address  `json:"node"`
+	Value       decimal.Decimal `json:"value"`
+	ChipIDs     []*big.Int      `json:"chip_ids"`
+	RequestedAt int64           `json:"requested_at"`
+	ClaimableAt int64           `json:"claimable_at"`
+	Claimable   bool            `json:"claimable"`
+}
+
+// NewStakerPortfolio returns the portfolio of the staker from the chips held with their latest values,
+// the unstake transactions with their events, and the chips burned by the unstake transactions.
+func NewStakerPortfolio(staker common.Address, chips []*schema.StakeChip, unstakeTransactions []*schema.StakeTransaction, unstakeEvents []*schema.StakeEvent, unstakedChips []*schema.StakeChip, unbondingPeriod time.Duration, now time.Time) *StakerPortfolio {
+	portfolio := StakerPortfolio{
+		Staker:          staker,
+		Chips:           make([]*StakerPortfolioChip, 0, len(chips)),
+		PendingUnstakes: make([]*StakerPortfolioUnstake, 0),
+	}
+
+	for _, chip := range chips {
+		portfolioChip := StakerPortfolioChip{
+			ID:          chip.ID,
+			Node:        chip.Node,
+			Value:       chip.Value,
+			LatestValue: chip.LatestValue,
+			Rewards:     chip.LatestValue.Sub(chip.Value),
+			StakedAt:    int64(chip.BlockTimestamp),
+			Finalized:   chip.Finalized,
+		}
+
+		portfolio.StakedValue = portfolio.StakedValue.Add(portfolioChip.Value)
+		portfolio.CurrentValue = portfolio.CurrentValue.Add(portfolioChip.LatestValue)
+		portfolio.UnrealizedProfit = portfolio.UnrealizedProfit.Add(portfolioChip.Rewards)
+		portfolio.Chips = append(portfolio.Chips, &portfolioChip)
+	}
+
+	unstakedChipValues := lo.SliceToMap(unstakedChips, func(chip *schema.StakeChip) (string, decimal.Decimal) {
+		return chip.ID.String(), chip.Value
+	})
+
+	claimed := lo.SliceToMap(lo.Filter(unstakeEvents, func(event *schema.StakeEvent, _ int) bool {
+		return event.Type == schema.StakeEventTypeUnstakeClaimed
+	}), func(event *schema.StakeEvent) (common.Hash, struct{}) {
+		return event.ID, struct{}{}
+	})
+
+	for _, transaction := range unstakeTransactions {
+		if transaction.Type != schema.StakeTransactionTypeUnstake {
+			continue
+		}
+
+		value := decimal.NewFromBigInt(transaction.Value, 0)
+
+		for _, chipID := range transaction.ChipIDs {
+			value = value.Sub(unstakedChipValues[chipID.String()])
+		}
+
+		portfolio.RealizedProfit = portfolio.RealizedProfit.Add(value)
+
+		if _, exists := claimed[transaction.ID]; exists {
+			continue
+		}
+
+		claimableAt := transaction.BlockTimestamp.Add(unbondingPeriod)
+
+		portfolio.PendingUnstakes = append(portfolio.PendingUnstakes, &StakerPortfolioUnstake{
+			ID:          transaction.ID,
+			Node:        transaction.Node,
+			Value:       decimal.NewFromBigInt(transaction.Value, 0),
+			ChipIDs:     transaction.ChipIDs,
+			RequestedAt: transaction.BlockTimestamp.Unix(),
+			ClaimableAt: claimableAt.Unix(),
+			Claimable:   !now.Before(claimableAt),
+		})
+	}
+
+	return &portfolio
+}
@@ -0,0 +1,74 @@
+package nta_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/service/hub/model/nta"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStakerPortfolio(t *testing.T) {
+	t.Parallel()
+
+	var (
+		staker          = common.HexToAddress("0x1")
+		node            = common.HexToAddress("0x2")
+		now             = time.Unix(1700000000, 0)
+		unbondingPeriod = 7 * 24 * time.Hour
+	)
+
+	chips := []*schema.StakeChip{
+		{ID: big.NewInt(3), Owner: staker, Node: node, Value: decimal.NewFromInt(100), LatestValue: decimal.NewFromInt(110), BlockTimestamp: 1690000000},
+		{ID: big.NewInt(4), Owner: staker, Node: node, Value: decimal.NewFromInt(100), LatestValue: decimal.NewFromInt(105), BlockTimestamp: 1690000000},
+	}
+
+	unstakeTransactions := []*schema.StakeTransaction{
+		{ID: common.HexToHash("0x10"), Type: schema.StakeTransactionTypeUnstake, User: staker, Node: node, Value: big.NewInt(120), ChipIDs: []*big.Int{big.NewInt(1)}, BlockTimestamp: now.Add(-time.Hour)},
+		{ID: common.HexToHash("0x11"), Type: schema.StakeTransactionTypeUnstake, User: staker, Node: node, Value: big.NewInt(101), ChipIDs: []*big.Int{big.NewInt(2)}, BlockTimestamp: now.Add(-unbondingPeriod)},
+		{ID: common.HexToHash("0x12"), Type: schema.StakeTransactionTypeUnstake, User: staker, Node: node, Value: big.NewInt(100), ChipIDs: []*big.Int{big.NewInt(5)}, BlockTimestamp: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	unstakeEvents := []*schema.StakeEvent{
+		{ID: common.HexToHash("0x12"), Type: schema.StakeEventTypeUnstakeClaimed},
+		{ID: common.HexToHash("0x12"), Type: schema.StakeEventTypeUnstakeRequested},
+	}
+
+	unstakedChips := []*schema.StakeChip{
+		{ID: big.NewInt(1), Value: decimal.NewFromInt(100)},
+		{ID: big.NewInt(2), Value: decimal.NewFromInt(100)},
+		{ID: big.NewInt(5), Value: decimal.NewFromInt(90)},
+	}
+
+	portfolio := nta.NewStakerPortfolio(staker, chips, unstakeTransactions, unstakeEvents, unstakedChips, unbondingPeriod, now)
+
+	require.Equal(t, "200", portfolio.StakedValue.String())
+	require.Equal(t, "215", portfolio.CurrentValue.String())
+	require.Equal(t, "15", portfolio.UnrealizedProfit.String())
+	require.Equal(t, "31", portfolio.RealizedProfit.String())
+
+	require.Len(t, portfolio.Chips, 2)
+	require.Equal(t, "10", portfolio.Chips[0].Rewards.String())
+	require.Equal(t, int64(1690000000), portfolio.Chips[0].StakedAt)
+
+	require.Len(t, portfolio.PendingUnstakes, 2)
+	require.Equal(t, common.HexToHash("0x10"), portfolio.PendingUnstakes[0].ID)
+	require.False(t, portfolio.PendingUnstakes[0].Claimable)
+	require.Equal(t, now.Add(-time.Hour).Add(unbondingPeriod).Unix(), portfolio.PendingUnstakes[0].ClaimableAt)
+	require.Equal(t, common.HexToHash("0x11"), portfolio.PendingUnstakes[1].ID)
+	require.True(t, portfolio.PendingUnstakes[1].Claimable)
+}
+
+func TestNewStakerPortfolioEmpty(t *testing.T) {
+	t.Parallel()
+
+	portfolio := nta.NewStakerPortfolio(common.HexToAddress("0x1"), nil, nil, nil, nil, time.Hour, time.Now())
+
+	require.NotNil(t, portfolio.Chips)
+	require.NotNil(t, portfolio.PendingUnstakes)
+	require.True(t, portfolio.RealizedProfit.IsZero())
+}
@@ -105,6 +105,7 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 			epochs.GET("", instance.hub.nta.GetEpochs)
 			epochs.GET("/:epoch_id", instance.hub.nta.GetEpoch)
 			epochs.GET("/:node_address/rewards", instance.hub.nta.GetEpochNodeRewards)
+			epochs.GET("/:epoch_id/nodes/:node_address/score_breakdown", instance.hub.nta.GetEpochNodeScoreBreakdown)
 			epochs.GET("/distributions/:transaction_hash", instance.hub.nta.GetEpochDistribution)
 			epochs.GET("/apy", instance.hub.nta.GetEpochsAPY)
 		}
@@ -113,6 +114,8 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 		{
 			networks.GET("/config", instance.hub.nta.GetNetworkConfig)
 			networks.GET("/assets", instance.hub.nta.GetAssets)
+			networks.GET("/coverage", instance.hub.nta.GetNetworkCoverage)
+			networks.GET("/decentralization", instance.hub.nta.GetNetworkDecentralization)
 		}
 
 		nodes := nta.Group("/nodes")
@@ -122,9 +125,17 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 			nodes.GET("/:node_address/avatar.svg", instance.hub.nta.GetNodeAvatar)
 			nodes.GET("/:node_address/challenge", instance.hub.nta.GetNodeChallenge)
 			nodes.GET("/:node_address/events", instance.hub.nta.GetNodeEvents)
+			nodes.GET("/:node_address/export", instance.hub.nta.GetNodeExport)
+			nodes.GET("/:node_address/invalid_responses", instance.hub.nta.GetNodeInvalidResponses)
 			nodes.GET("/:node_address/operation/profit", instance.hub.nta.GetNodeOperationProfit)
+			nodes.GET("/:node_address/performance", instance.hub.nta.GetNodePerformance)
+			nodes.GET("/:node_address/webhooks", instance.hub.nta.GetNodeWebhooks)
+			nodes.GET("/:node_address/webhooks/:id/deliveries", instance.hub.nta.GetNodeWebhookDeliveries)
 
 			nodes.POST("/:node_address/hide_tax_rate", instance.hub.nta.PostNodeHideTaxRate)
+			nodes.POST("/:node_address/invalid_responses/:id/appeal", instance.hub.nta.PostNodeInvalidResponseAppeal)
+			nodes.POST("/:node_address/webhooks", instance.hub.nta.PostNodeWebhook)
+			nodes.DELETE("/:node_address/webhooks/:id", instance.hub.nta.DeleteNodeWebhook)
 		}
 
 		snapshots := nta.Group("/snapshots")
@@ -134,6 +145,14 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 			snapshots.GET("/stakers/count", instance.hub.nta.GetStakerCountSnapshots)
 			snapshots.GET("/stakers/profit", instance.hub.nta.GetStakerProfitSnapshots)
 			snapshots.GET("/epochs/apy", instance.hub.nta.GetEpochsAPYSnapshots)
+			snapshots.GET("/networks/decentralization", instance.hub.nta.GetDecentralizationSnapshots)
+			snapshots.GET("/tvl", instance.hub.nta.GetTVLSnapshots)
+		}
+
+		stakers := nta.Group("/stakers")
+		{
+			stakers.GET("/:staker_address/export", instance.hub.nta.GetStakerExport)
+			stakers.GET("/:staker_address/portfolio", instance.hub.nta.GetStakerPortfolio)
 		}
 
 		stake := nta.Group("/stakings")
@@ -159,7 +178,7 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 		}
 	}
 
-	dsl := instance.httpServer.Group("")
+	dsl := instance.httpServer.Group("", instance.hub.dsl.CacheBypass)
 	{
 		rss := dsl.Group("/rss")
 		{
@@ -170,6 +189,7 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, geoLit
 		{
 			decentralized.GET("/tx/:id", instance.hub.dsl.GetDecentralizedActivity)
 			decentralized.GET("/:account", instance.hub.dsl.GetDecentralizedAccountActivities)
+			decentralized.GET("/:account/stream", instance.hub.dsl.GetDecentralizedAccountActivitiesStream)
 			decentralized.GET("/network/:network", instance.hub.dsl.GetDecentralizedNetworkActivities)
 			decentralized.GET("/platform/:platform", instance.hub.dsl.GetDecentralizedPlatformActivities)
 			decentralized.POST("/accounts", instance.hub.dsl.BatchGetDecentralizedAccountsActivities)
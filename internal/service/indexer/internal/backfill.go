@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+// Backfiller indexes a range of finalized blocks, the blocks and receipts of each range are fetched from the Source,
+// while the previous range is processed by the Handler in order.
+type Backfiller struct {
+	ethereumClient *ethclient.Client
+	databaseClient database.Client
+	handler        Handler
+	source         Source
+	checkpointLock *CheckpointLock
+	chainID        uint64
+	// rangeSize is the number of blocks processed in a database transaction, the checkpoint is committed per range.
+	rangeSize uint64
+}
+
+type blockRange struct {
+	blocks   []*types.Block
+	receipts []types.Receipts
+}
+
+// Run indexes the blocks from `from` to `to`, to is the latest finalized block if it is 0.
+// The checkpoint is moved forward to the end of each range processed, but never backward,
+// and never over the blocks not indexed yet, so `from` must not be greater than the block next to the checkpoint.
+func (b *Backfiller) Run(ctx context.Context, from, to uint64) error {
+	if to == 0 {
+		block, err := b.ethereumClient.BlockByNumber(ctx, big.NewInt(rpc.FinalizedBlockNumber.Int64()))
+		if err != nil {
+			return fmt.Errorf("get finalized block number: %w", err)
+		}
+
+		to = block.NumberU64()
+	}
+
+	if from > to {
+		return fmt.Errorf("invalid block range from %d to %d", from, to)
+	}
+
+	// The finalized indexer of the chain holds the lock while it runs, and it would overwrite the checkpoint moved by the backfill.
+	if b.checkpointLock != nil {
+		unlock, err := b.checkpointLock.TryLock(ctx)
+		if err != nil {
+			return fmt.Errorf("the checkpoint of chain %d is locked, stop the finalized indexer before backfilling: %w", b.chainID, err)
+		}
+
+		defer unlock()
+	}
+
+	checkpoint, err := b.databaseClient.FindCheckpoint(ctx, b.chainID)
+	if err != nil {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	// Moving the checkpoint to the end of the range would skip the blocks between the checkpoint and the range for good.
+	if from > checkpoint.BlockNumber+1 {
+		return fmt.Errorf("the backfill from block %d skips the blocks from %d, it must start from %d at most", from, checkpoint.BlockNumber+1, checkpoint.BlockNumber+1)
+	}
+
+	zap.L().Info("start backfill", zap.Uint64("chain.id", b.chainID), zap.Uint64("from", from), zap.Uint64("to", to), zap.Uint64("checkpoint", checkpoint.BlockNumber))
+
+	fetchedRanges := make(chan *blockRange, 1)
+	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
+
+	// Fetch the next range while the current range is being processed.
+	errorPool.Go(func(ctx context.Context) error {
+		defer close(fetchedRanges)
+
+		for start := from; start <= to; start += b.rangeSize {
+			end := min(start+b.rangeSize-1, to)
+
+			blocks, receipts, err := b.source.Fetch(ctx, start, end)
+			if err != nil {
+				return fmt.Errorf("fetch blocks from %d to %d: %w", start, end, err)
+			}
+
+			select {
+			case fetchedRanges <- &blockRange{blocks: blocks, receipts: receipts}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		return nil
+	})
+
+	errorPool.Go(func(ctx context.Context) error {
+		for fetchedRange := range fetchedRanges {
+			if err := b.processRange(ctx, fetchedRange, checkpoint); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err := errorPool.Wait(); err != nil {
+		return err
+	}
+
+	zap.L().Info("backfill finished", zap.Uint64("chain.id", b.chainID), zap.Uint64("from", from), zap.Uint64("to", to))
+
+	return nil
+}
+
+// processRange processes the blocks of the range in order and commits the checkpoint in a database transaction.
+func (b *Backfiller) processRange(ctx context.Context, fetchedRange *blockRange, checkpoint *schema.Checkpoint) error {
+	databaseTransaction, err := b.databaseClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin database transaction: %w", err)
+	}
+
+	defer lo.Try(databaseTransaction.Rollback)
+
+	if err := processBlocks(ctx, b.handler, fetchedRange.blocks, fetchedRange.receipts, databaseTransaction); err != nil {
+		return err
+	}
+
+	lastBlock := fetchedRange.blocks[len(fetchedRange.blocks)-1]
+
+	if lastBlock.NumberU64() > checkpoint.BlockNumber {
+		checkpoint.BlockNumber = lastBlock.NumberU64()
+		checkpoint.BlockHash = lastBlock.Hash()
+
+		if err := databaseTransaction.SaveCheckpoint(ctx, checkpoint); err != nil {
+			return fmt.Errorf("save checkpoint: %w", err)
+		}
+	}
+
+	if err := databaseTransaction.Commit(); err != nil {
+		return fmt.Errorf("commit database transaction: %w", err)
+	}
+
+	zap.L().Info(
+		"backfilled block range",
+		zap.Uint64("chain.id", b.chainID),
+		zap.Uint64("block.number.start", fetchedRange.blocks[0].NumberU64()),
+		zap.Uint64("block.number.end", lastBlock.NumberU64()),
+	)
+
+	return nil
+}
+
+func NewBackfiller(chainID uint64, ethereumClient *ethclient.Client, databaseClient database.Client, handler Handler, source Source, checkpointLock *CheckpointLock, rangeSize uint64) (*Backfiller, error) {
+	if rangeSize == 0 {
+		return nil, fmt.Errorf("invalid range size %d", rangeSize)
+	}
+
+	instance := Backfiller{
+		ethereumClient: ethereumClient,
+		databaseClient: databaseClient,
+		handler:        handler,
+		source:         source,
+		checkpointLock: checkpointLock,
+		chainID:        chainID,
+		rangeSize:      rangeSize,
+	}
+
+	return &instance, nil
+}
@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackfillerGap checks that a backfill never moves the checkpoint over the blocks not indexed yet.
+func TestBackfillerGap(t *testing.T) {
+	t.Parallel()
+
+	ethereumClient, _ := newTestChain(t)
+
+	source, err := NewBlockSource(ethereumClient, 1)
+	require.NoError(t, err)
+
+	databaseClient := checkpointDatabase{checkpoint: &schema.Checkpoint{ChainID: 1, BlockNumber: 2}}
+
+	backfiller, err := NewBackfiller(1, ethereumClient, &databaseClient, &recordingHandler{address: addressContract}, source, nil, 2)
+	require.NoError(t, err)
+
+	require.Error(t, backfiller.Run(context.Background(), 4, 6))
+	assert.Equal(t, uint64(2), databaseClient.checkpoint.BlockNumber)
+
+	require.NoError(t, backfiller.Run(context.Background(), 3, 6))
+	assert.Equal(t, uint64(6), databaseClient.checkpoint.BlockNumber)
+}
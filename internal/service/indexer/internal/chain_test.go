@@ -0,0 +1,388 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addressContract = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	addressOther    = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+type processedLog struct {
+	Header      *types.Header
+	Transaction []byte
+	Receipt     *types.Receipt
+	LogIndex    int
+}
+
+// recordingHandler records the logs of the contract in the same way as the handlers of the indexer.
+type recordingHandler struct {
+	address common.Address
+	logs    []*processedLog
+}
+
+func (h *recordingHandler) Process(_ context.Context, block *types.Block, receipts types.Receipts, _ database.Client) error {
+	for _, receipt := range receipts {
+		transaction := block.Transaction(receipt.TxHash)
+
+		if transaction.To() == nil || receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+
+		for logIndex, log := range receipt.Logs {
+			if log.Removed || len(log.Topics) == 0 || log.Address != h.address {
+				continue
+			}
+
+			data, err := transaction.MarshalBinary()
+			if err != nil {
+				return err
+			}
+
+			h.logs = append(h.logs, &processedLog{
+				Header:      block.Header(),
+				Transaction: data,
+				Receipt:     receipt,
+				LogIndex:    logIndex,
+			})
+		}
+	}
+
+	return nil
+}
+
+type checkpointDatabase struct {
+	database.Client
+
+	checkpoint *schema.Checkpoint
+}
+
+func (c *checkpointDatabase) Begin(_ context.Context, _ ...*sql.TxOptions) (database.Client, error) {
+	return c, nil
+}
+
+func (c *checkpointDatabase) Commit() error {
+	return nil
+}
+
+func (c *checkpointDatabase) Rollback() error {
+	return nil
+}
+
+func (c *checkpointDatabase) FindCheckpoint(_ context.Context, _ uint64) (*schema.Checkpoint, error) {
+	checkpoint := *c.checkpoint
+
+	return &checkpoint, nil
+}
+
+func (c *checkpointDatabase) SaveCheckpoint(_ context.Context, checkpoint *schema.Checkpoint) error {
+	*c.checkpoint = *checkpoint
+
+	return nil
+}
+
+type testTransaction struct {
+	to     *common.Address
+	status uint64
+	// logs are the addresses of the logs emitted, with the index of the log as the topic.
+	logs []common.Address
+	// events are the logs emitted, the fields of the block and the transaction are filled by the chain.
+	events []*types.Log
+}
+
+// newTestChain returns a client of a chain of 6 blocks with the logs of the contract and other addresses.
+func newTestChain(t *testing.T) (*ethclient.Client, *testChain) {
+	t.Helper()
+
+	blocks := [][]testTransaction{
+		{},
+		{{to: &addressContract, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressContract}}},
+		{},
+		{
+			{to: &addressOther, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressOther}},
+			{to: &addressOther, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressOther, addressContract, addressOther}},
+			{to: &addressContract, status: types.ReceiptStatusFailed},
+		},
+		{{to: nil, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressContract}}},
+		{
+			{to: &addressContract, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressContract, addressContract}},
+			{to: &addressOther, status: types.ReceiptStatusSuccessful},
+			{to: &addressContract, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressContract, addressOther}},
+		},
+		{{to: &addressOther, status: types.ReceiptStatusSuccessful, logs: []common.Address{addressOther, addressContract}}},
+	}
+
+	return newTestChainWithBlocks(t, 1, blocks)
+}
+
+// newTestChainWithBlocks returns a client of a chain of the blocks, the block number is the index of the block.
+func newTestChainWithBlocks(t *testing.T, chainID uint64, blocks [][]testTransaction) (*ethclient.Client, *testChain) {
+	t.Helper()
+
+	chain := testChain{
+		blocks:   make(map[uint64]*types.Block),
+		receipts: make(map[common.Hash]types.Receipts),
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+
+	var (
+		nonce      uint64
+		parentHash common.Hash
+	)
+
+	for number, testTransactions := range blocks {
+		var (
+			transactions = make([]*types.Transaction, 0, len(testTransactions))
+			receipts     = make(types.Receipts, 0, len(testTransactions))
+		)
+
+		for _, testTransaction := range testTransactions {
+			transaction, err := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				To:       testTransaction.to,
+				Gas:      21000,
+				GasPrice: big.NewInt(1),
+			}), signer, key)
+			require.NoError(t, err)
+
+			nonce++
+
+			receipt := types.Receipt{
+				Type:              types.LegacyTxType,
+				Status:            testTransaction.status,
+				CumulativeGasUsed: uint64(len(receipts)+1) * 21000,
+				GasUsed:           21000,
+				TxHash:            transaction.Hash(),
+				Logs:              make([]*types.Log, 0, len(testTransaction.logs)+len(testTransaction.events)),
+			}
+
+			for index, address := range testTransaction.logs {
+				receipt.Logs = append(receipt.Logs, &types.Log{
+					Address: address,
+					Topics:  []common.Hash{common.BigToHash(big.NewInt(int64(index)))},
+					Data:    transaction.Hash().Bytes(),
+				})
+			}
+
+			for _, event := range testTransaction.events {
+				log := *event
+				receipt.Logs = append(receipt.Logs, &log)
+			}
+
+			receipt.Bloom = types.CreateBloom(types.Receipts{&receipt})
+
+			transactions = append(transactions, transaction)
+			receipts = append(receipts, &receipt)
+		}
+
+		header := types.Header{
+			ParentHash: parentHash,
+			Number:     big.NewInt(int64(number)),
+			Difficulty: big.NewInt(1),
+			GasLimit:   30_000_000,
+			Time:       uint64(number),
+		}
+
+		block := types.NewBlock(&header, transactions, nil, receipts, trie.NewStackTrie(nil))
+
+		var logIndex uint
+
+		for transactionIndex, receipt := range receipts {
+			receipt.BlockHash, receipt.BlockNumber, receipt.TransactionIndex = block.Hash(), block.Number(), uint(transactionIndex)
+
+			for _, log := range receipt.Logs {
+				log.BlockHash, log.BlockNumber, log.TxHash, log.TxIndex, log.Index = block.Hash(), block.NumberU64(), receipt.TxHash, uint(transactionIndex), logIndex
+				logIndex++
+			}
+		}
+
+		chain.blocks[block.NumberU64()] = block
+		chain.receipts[block.Hash()] = receipts
+		parentHash = block.Hash()
+	}
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &chain))
+
+	rpcClient := rpc.DialInProc(server)
+
+	t.Cleanup(func() {
+		rpcClient.Close()
+		server.Stop()
+	})
+
+	return ethclient.NewClient(rpcClient), &chain
+}
+
+// testChain serves the JSON-RPC methods used by the sources.
+type testChain struct {
+	blocks   map[uint64]*types.Block
+	receipts map[common.Hash]types.Receipts
+	// call returns the result of a call to the contract, the calls are unsupported if it is nil.
+	call func(to common.Address, input []byte) ([]byte, error)
+}
+
+func (c *testChain) blockByHash(hash common.Hash) *types.Block {
+	for _, block := range c.blocks {
+		if block.Hash() == hash {
+			return block
+		}
+	}
+
+	return nil
+}
+
+func (c *testChain) marshalBlock(block *types.Block, full bool) (json.RawMessage, error) {
+	if block == nil {
+		return json.RawMessage("null"), nil
+	}
+
+	data, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	transactions := make([]any, 0, len(block.Transactions()))
+
+	for index := range block.Transactions() {
+		if !full {
+			transactions = append(transactions, block.Transactions()[index].Hash())
+
+			continue
+		}
+
+		transaction, err := c.marshalTransaction(block, uint64(index))
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	fields["transactions"], fields["uncles"] = transactions, []common.Hash{}
+
+	return json.Marshal(fields)
+}
+
+func (c *testChain) marshalTransaction(block *types.Block, index uint64) (json.RawMessage, error) {
+	if block == nil || index >= uint64(len(block.Transactions())) {
+		return json.RawMessage("null"), nil
+	}
+
+	data, err := json.Marshal(block.Transactions()[index])
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["blockHash"], fields["blockNumber"], fields["transactionIndex"] = block.Hash(), hexutil.Uint64(block.NumberU64()), hexutil.Uint64(index)
+
+	return json.Marshal(fields)
+}
+
+func (c *testChain) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(len(c.blocks) - 1)
+}
+
+func (c *testChain) GetBlockByNumber(number rpc.BlockNumber, full bool) (json.RawMessage, error) {
+	return c.marshalBlock(c.blocks[uint64(number.Int64())], full)
+}
+
+func (c *testChain) GetBlockByHash(hash common.Hash, full bool) (json.RawMessage, error) {
+	return c.marshalBlock(c.blockByHash(hash), full)
+}
+
+func (c *testChain) GetBlockReceipts(blockNumberOrHash rpc.BlockNumberOrHash) (types.Receipts, error) {
+	hash, _ := blockNumberOrHash.Hash()
+
+	return c.receipts[hash], nil
+}
+
+func (c *testChain) GetTransactionByBlockHashAndIndex(hash common.Hash, index hexutil.Uint64) (json.RawMessage, error) {
+	return c.marshalTransaction(c.blockByHash(hash), uint64(index))
+}
+
+func (c *testChain) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	for _, receipts := range c.receipts {
+		for _, receipt := range receipts {
+			if receipt.TxHash == hash {
+				return receipt, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+type testFilter struct {
+	Address   []common.Address `json:"address"`
+	FromBlock hexutil.Uint64   `json:"fromBlock"`
+	ToBlock   hexutil.Uint64   `json:"toBlock"`
+}
+
+func (c *testChain) GetLogs(filter testFilter) ([]*types.Log, error) {
+	logs := make([]*types.Log, 0)
+
+	for number := uint64(filter.FromBlock); number <= uint64(filter.ToBlock); number++ {
+		for _, receipt := range c.receipts[c.blocks[number].Hash()] {
+			for _, log := range receipt.Logs {
+				for _, address := range filter.Address {
+					if log.Address == address {
+						logs = append(logs, log)
+					}
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+type testCallArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+	Data  hexutil.Bytes   `json:"data"`
+}
+
+func (c *testChain) Call(args testCallArgs, _ rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if c.call == nil || args.To == nil {
+		return nil, errors.New("call is unsupported")
+	}
+
+	input := args.Input
+	if len(input) == 0 {
+		input = args.Data
+	}
+
+	return c.call(*args.To, input)
+}
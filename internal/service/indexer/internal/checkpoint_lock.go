@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/go-redsync/redsync/v4/redis/goredis/v9"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// checkpointLockKey is the key of the lock of the checkpoint of a chain.
+const checkpointLockKey = "indexer:checkpoint:%d"
+
+const checkpointLockExpiry = 30 * time.Second
+
+// CheckpointLock is held by the only writer of the checkpoint of a chain,
+// which is either the finalized indexer or a backfill of the chain.
+type CheckpointLock struct {
+	mutex *redsync.Mutex
+}
+
+// Lock waits until the lock is acquired, it is used by the finalized indexer to wait for a running backfill.
+func (l *CheckpointLock) Lock(ctx context.Context) (unlock func(), err error) {
+	for {
+		if unlock, err = l.TryLock(ctx); err == nil {
+			return unlock, nil
+		}
+
+		zap.L().Info("waiting for the checkpoint lock", zap.String("key", l.mutex.Name()), zap.Error(err))
+
+		timer := time.NewTimer(checkpointLockExpiry / 2)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// TryLock acquires the lock once, and keeps renewing it until the returned unlock function is called.
+func (l *CheckpointLock) TryLock(ctx context.Context) (unlock func(), err error) {
+	if err := l.mutex.TryLockContext(ctx); err != nil {
+		return nil, fmt.Errorf("lock %s: %w", l.mutex.Name(), err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	go l.renewal(ctx)
+
+	unlock = func() {
+		cancel()
+
+		if _, err := l.mutex.Unlock(); err != nil {
+			zap.L().Error("release lock error", zap.String("key", l.mutex.Name()), zap.Error(err))
+		}
+	}
+
+	return unlock, nil
+}
+
+func (l *CheckpointLock) renewal(ctx context.Context) {
+	// Renewal lock every half of expiry.
+	ticker := time.NewTicker(checkpointLockExpiry / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := l.mutex.ExtendContext(ctx)
+			if err != nil {
+				zap.L().Error("extend lock error", zap.String("key", l.mutex.Name()), zap.Error(err))
+
+				continue
+			}
+
+			if !result {
+				zap.L().Error("extend lock failed", zap.String("key", l.mutex.Name()))
+			}
+		}
+	}
+}
+
+func NewCheckpointLock(redisClient *redis.Client, chainID uint64) *CheckpointLock {
+	rs := redsync.New(goredis.NewPool(redisClient))
+
+	return &CheckpointLock{
+		mutex: rs.NewMutex(fmt.Sprintf(checkpointLockKey, chainID), redsync.WithExpiry(checkpointLockExpiry), redsync.WithTries(1)),
+	}
+}
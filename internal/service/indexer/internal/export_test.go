@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/samber/lo"
+)
+
+// TestTransaction is a transaction of a test chain, the logs are emitted by the transaction in order.
+type TestTransaction struct {
+	To     *common.Address
+	Status uint64
+	Logs   []*types.Log
+}
+
+// TestCall returns the result of a call to the contract of a test chain.
+type TestCall func(to common.Address, input []byte) ([]byte, error)
+
+// NewTestChain returns a client of a test chain of the blocks for the tests of the handlers,
+// the calls to the contracts are served by call if it is not nil.
+func NewTestChain(t *testing.T, chainID uint64, blocks [][]TestTransaction, call TestCall) *ethclient.Client {
+	t.Helper()
+
+	ethereumClient, chain := newTestChainWithBlocks(t, chainID, lo.Map(blocks, func(transactions []TestTransaction, _ int) []testTransaction {
+		return lo.Map(transactions, func(transaction TestTransaction, _ int) testTransaction {
+			return testTransaction{to: transaction.To, status: transaction.Status, events: transaction.Logs}
+		})
+	}))
+
+	chain.call = call
+
+	return ethereumClient
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rss3-network/global-indexer/contract/l1"
@@ -119,6 +120,19 @@ func (h *handler) confirmPreviousBlocks(ctx context.Context, blockNumber uint64,
 	return err
 }
 
+// Addresses returns the addresses of the contracts indexed by the handler.
+func Addresses(chainID uint64) []common.Address {
+	contractAddresses := l1.ContractMap[chainID]
+	if contractAddresses == nil {
+		return nil
+	}
+
+	return []common.Address{
+		contractAddresses.AddressL1StandardBridgeProxy,
+		contractAddresses.AddressOptimismPortalProxy,
+	}
+}
+
 func NewHandler(chainID uint64, ethereumClient *ethclient.Client, finalized bool) (internal.Handler, error) {
 	contractAddresses := l1.ContractMap[chainID]
 	if contractAddresses == nil {
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rss3-network/global-indexer/contract/l2"
@@ -50,7 +51,7 @@ func (h *handler) Process(ctx context.Context, block *types.Block, receipts type
 
 	header := block.Header()
 
-	for transactionIndex, receipt := range receipts {
+	for _, receipt := range receipts {
 		// Discard all contract creation transactions.
 		if block.Transaction(receipt.TxHash).To() == nil {
 			continue
@@ -81,7 +82,7 @@ func (h *handler) Process(ctx context.Context, block *types.Block, receipts type
 				transaction := block.Transaction(log.TxHash)
 
 				switch {
-				case l2.IsStakingV2Deployed(big.NewInt(int64(h.chainID)), header.Number, uint(transactionIndex)): // Staking V2
+				case l2.IsStakingV2Deployed(big.NewInt(int64(h.chainID)), header.Number, receipt.TransactionIndex): // Staking V2
 					if err := h.indexStakingV2Log(ctx, header, transaction, receipt, log, databaseTransaction); err != nil {
 						return fmt.Errorf("index staking v2 log: %w", err)
 					}
@@ -189,6 +190,20 @@ func (h *handler) confirmPreviousBlocks(ctx context.Context, blockNumber uint64,
 	return err
 }
 
+// Addresses returns the addresses of the contracts indexed by the handler.
+func Addresses(chainID uint64) []common.Address {
+	contractAddresses := l2.ContractMap[chainID]
+	if contractAddresses == nil {
+		return nil
+	}
+
+	return []common.Address{
+		l2.AddressL2StandardBridgeProxy,
+		contractAddresses.AddressStakingProxy,
+		contractAddresses.AddressChipsProxy,
+	}
+}
+
 func NewHandler(chainID uint64, ethereumClient *ethclient.Client, cacheClient cache.Client, finalized bool) (internal.Handler, error) {
 	contractAddresses := l2.ContractMap[chainID]
 	if contractAddresses == nil {
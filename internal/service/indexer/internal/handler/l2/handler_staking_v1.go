@@ -17,6 +17,7 @@ import (
 	"github.com/rss3-network/global-indexer/common/ethereum"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
@@ -488,6 +489,28 @@ func (h *handler) indexStakingV1RewardDistributedLog(ctx context.Context, header
 		return fmt.Errorf("save epoch related nodes: %w", err)
 	}
 
+	// Only notify the operators once the rewards are finalized.
+	if !h.finalized {
+		return nil
+	}
+
+	for _, rewardedNode := range epoch.RewardedNodes {
+		data := webhook.EpochRewardedData{
+			EpochID:          rewardedNode.EpochID,
+			TransactionHash:  rewardedNode.TransactionHash,
+			OperationRewards: rewardedNode.OperationRewards,
+			StakingRewards:   rewardedNode.StakingRewards,
+			TaxCollected:     rewardedNode.TaxCollected,
+			RequestCount:     rewardedNode.RequestCount,
+		}
+
+		key := fmt.Sprintf("%s:%d", schema.NodeWebhookEventTypeEpochRewarded, rewardedNode.EpochID)
+
+		if err := webhook.EmitOnce(ctx, databaseTransaction, rewardedNode.NodeAddress, schema.NodeWebhookEventTypeEpochRewarded, key, data); err != nil {
+			return fmt.Errorf("emit epoch rewarded event: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/rss3-network/global-indexer/contract/l2"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
+	"github.com/rss3-network/global-indexer/internal/webhook"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/shopspring/decimal"
 	"github.com/sourcegraph/conc/pool"
@@ -331,7 +332,28 @@ func (h *handler) indexNodeStatusChangedLog(ctx context.Context, header *types.H
 
 	switch nodeNewStatus {
 	case uint8(schema.NodeStatusSlashing):
-		return h.handleNodeSlashing(ctx, nodeAddress, nodeCurrentStatus, databaseTransaction)
+		if err := h.handleNodeSlashing(ctx, nodeAddress, nodeCurrentStatus, databaseTransaction); err != nil {
+			return err
+		}
+
+		// Only notify the operators once the slashing is finalized.
+		if !h.finalized {
+			return nil
+		}
+
+		data := webhook.SlashedData{
+			PreviousStatus:  schema.NodeStatus(nodeCurrentStatus),
+			TransactionHash: transaction.Hash(),
+			BlockNumber:     header.Number.Uint64(),
+		}
+
+		key := fmt.Sprintf("%s:%s:%d", schema.NodeWebhookEventTypeSlashed, transaction.Hash(), log.Index)
+
+		if err := webhook.EmitOnce(ctx, databaseTransaction, nodeAddress, schema.NodeWebhookEventTypeSlashed, key, data); err != nil {
+			return fmt.Errorf("emit node slashed event: %w", err)
+		}
+
+		return nil
 	// TODO: node status reverted to online from slashing
 	// case uint8(schema.NodeStatusOnline):
 	//	 return h.handleNodeOnline(ctx, nodeAddress, nodeCurrentStatus, databaseTransaction)
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -19,6 +21,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// maxReorganizationDepth is the max number of blocks rolled back in a reorganization,
+// and the hashes of the recent blocks are kept for this depth.
+const maxReorganizationDepth uint64 = 1024
+
 // Handler uses to process blocks and receipts.
 type Handler interface {
 	Process(ctx context.Context, block *types.Block, receipts types.Receipts, databaseTransaction database.Client) error
@@ -30,16 +36,28 @@ type Indexer interface {
 }
 
 type indexer struct {
-	ethereumClient    *ethclient.Client
-	databaseClient    database.Client
-	handler           Handler
-	chainID           uint64
-	finalized         bool
+	ethereumClient *ethclient.Client
+	databaseClient database.Client
+	handler        Handler
+	source         Source
+	chainID        uint64
+	finalized      bool
+	// checkpointLock is held by the finalized indexer while it runs, so a backfill never writes the checkpoint at the same time.
+	checkpointLock    *CheckpointLock
 	checkpoint        *schema.Checkpoint
 	blockNumberLatest uint64
 }
 
 func (i *indexer) Run(ctx context.Context) (err error) {
+	if i.checkpointLock != nil {
+		unlock, err := i.checkpointLock.Lock(ctx)
+		if err != nil {
+			return fmt.Errorf("lock checkpoint: %w", err)
+		}
+
+		defer unlock()
+	}
+
 	// Load checkpoint from database.
 	if i.checkpoint, err = i.databaseClient.FindCheckpoint(ctx, i.chainID); err != nil {
 		return fmt.Errorf("load checkpoint: %w", err)
@@ -108,23 +126,35 @@ func (i *indexer) index(ctx context.Context) (err error) {
 		return nil
 	}
 
-	blockNumber := i.checkpoint.BlockNumber + 1
+	blockNumberStart := i.checkpoint.BlockNumber + 1
+	blockNumberEnd := min(i.checkpoint.BlockNumber+i.source.RangeSize(), i.blockNumberLatest)
 
 	zap.L().Info(
 		"handing block",
 		zap.Uint64("block.number.local", i.checkpoint.BlockNumber),
 		zap.Uint64("block.number.latest", i.blockNumberLatest),
+		zap.Uint64("block.number.end", blockNumberEnd),
 		zap.Bool("finalized", i.finalized),
 	)
 
-	block, err := i.ethereumClient.BlockByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	blocks, receipts, err := i.source.Fetch(ctx, blockNumberStart, blockNumberEnd)
 	if err != nil {
-		return fmt.Errorf("get block by number %d: %w", blockNumber, err)
+		return fmt.Errorf("fetch blocks from %d to %d: %w", blockNumberStart, blockNumberEnd, err)
 	}
 
-	receipts, err := i.ethereumClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
-	if err != nil {
-		return err
+	for index := 1; index < len(blocks); index++ {
+		if blocks[index].NumberU64() == blocks[index-1].NumberU64()+1 && blocks[index].ParentHash() != blocks[index-1].Hash() {
+			return fmt.Errorf("block %d is reorganized while fetching", blocks[index].NumberU64())
+		}
+	}
+
+	// The parent of the first block must be the block of the checkpoint, otherwise the chain is reorganized.
+	if i.checkpoint.BlockHash != (common.Hash{}) && blocks[0].ParentHash() != i.checkpoint.BlockHash {
+		if i.finalized {
+			return fmt.Errorf("finalized block %d is reorganized", i.checkpoint.BlockNumber)
+		}
+
+		return i.rollback(ctx)
 	}
 
 	// Begin a database transaction for the block.
@@ -135,8 +165,16 @@ func (i *indexer) index(ctx context.Context) (err error) {
 
 	defer lo.Try(databaseTransaction.Rollback)
 
-	if err := i.handler.Process(ctx, block, receipts, databaseTransaction); err != nil {
-		return fmt.Errorf("process block %d: %w", block.NumberU64(), err)
+	if err := processBlocks(ctx, i.handler, blocks, receipts, databaseTransaction); err != nil {
+		return err
+	}
+
+	block := blocks[len(blocks)-1]
+
+	if !i.finalized {
+		if err := i.saveBlocks(ctx, blocks, databaseTransaction); err != nil {
+			return fmt.Errorf("save blocks: %w", err)
+		}
 	}
 
 	// Update and save checkpoint to memory and database.
@@ -156,6 +194,112 @@ func (i *indexer) index(ctx context.Context) (err error) {
 	return nil
 }
 
+// saveBlocks saves the hashes of the blocks to detect the reorganizations, and deletes the ones deeper than maxReorganizationDepth.
+func (i *indexer) saveBlocks(ctx context.Context, blocks []*types.Block, databaseTransaction database.Client) error {
+	for _, block := range blocks {
+		value := schema.Block{
+			ChainID:     i.chainID,
+			BlockNumber: block.NumberU64(),
+			BlockHash:   block.Hash(),
+			ParentHash:  block.ParentHash(),
+		}
+
+		if err := databaseTransaction.SaveBlock(ctx, &value); err != nil {
+			return fmt.Errorf("save block %d: %w", block.NumberU64(), err)
+		}
+	}
+
+	if blockNumber := blocks[len(blocks)-1].NumberU64(); blockNumber > maxReorganizationDepth {
+		if err := databaseTransaction.DeleteBlocksBeforeBlockNumber(ctx, i.chainID, blockNumber-maxReorganizationDepth); err != nil {
+			return fmt.Errorf("delete blocks before %d: %w", blockNumber-maxReorganizationDepth, err)
+		}
+	}
+
+	return nil
+}
+
+// rollback walks back from the checkpoint to the common ancestor with the chain, rolls back the blocks after it,
+// and resets the checkpoint to it.
+func (i *indexer) rollback(ctx context.Context) error {
+	finalizedCheckpoint, err := i.databaseClient.FindCheckpoint(ctx, i.chainID)
+	if err != nil {
+		return fmt.Errorf("load finalized checkpoint: %w", err)
+	}
+
+	databaseTransaction, err := i.databaseClient.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin database transaction: %w", err)
+	}
+
+	defer lo.Try(databaseTransaction.Rollback)
+
+	var (
+		blockNumber = i.checkpoint.BlockNumber
+		ancestor    *types.Header
+	)
+
+	for ancestor == nil {
+		header, err := i.ethereumClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		if err != nil {
+			return fmt.Errorf("get header of block %d: %w", blockNumber, err)
+		}
+
+		// The finalized blocks are never reorganized.
+		if blockNumber <= finalizedCheckpoint.BlockNumber {
+			ancestor = header
+
+			break
+		}
+
+		// The blocks skipped by the source are not saved, and there is nothing indexed from them.
+		block, err := databaseTransaction.FindBlock(ctx, i.chainID, blockNumber)
+		if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+			return fmt.Errorf("find block %d: %w", blockNumber, err)
+		}
+
+		if block != nil && block.BlockHash == header.Hash() {
+			ancestor = header
+
+			break
+		}
+
+		if i.checkpoint.BlockNumber-blockNumber >= maxReorganizationDepth {
+			return fmt.Errorf("reorganization is deeper than %d blocks", maxReorganizationDepth)
+		}
+
+		if err := databaseTransaction.RollbackBlock(ctx, i.chainID, blockNumber); err != nil {
+			return fmt.Errorf("rollback block %d: %w", blockNumber, err)
+		}
+
+		blockNumber--
+	}
+
+	if err := databaseTransaction.Commit(); err != nil {
+		return fmt.Errorf("commit database transaction: %w", err)
+	}
+
+	depth := i.checkpoint.BlockNumber - blockNumber
+
+	zap.L().Warn(
+		"chain reorganized",
+		zap.Uint64("chain.id", i.chainID),
+		zap.Uint64("block.number.local", i.checkpoint.BlockNumber),
+		zap.Stringer("block.hash.local", i.checkpoint.BlockHash),
+		zap.Uint64("block.number.ancestor", blockNumber),
+		zap.Stringer("block.hash.ancestor", ancestor.Hash()),
+		zap.Uint64("depth", depth),
+	)
+
+	chainID := strconv.FormatUint(i.chainID, 10)
+	reorganizationCounter.WithLabelValues(chainID).Inc()
+	reorganizationDepth.WithLabelValues(chainID).Observe(float64(depth))
+
+	i.checkpoint.BlockNumber = blockNumber
+	i.checkpoint.BlockHash = ancestor.Hash()
+
+	return nil
+}
+
 func (i *indexer) refreshLatestBlockNumber(ctx context.Context) (err error) {
 	ctx, span := otel.Tracer("").Start(ctx, "refreshLatestBlockNumber")
 	defer span.End()
@@ -178,14 +322,20 @@ func (i *indexer) refreshLatestBlockNumber(ctx context.Context) (err error) {
 	return nil
 }
 
-func NewIndexer(chainID uint64, ethereumClient *ethclient.Client, databaseClient database.Client, handler Handler, finalized bool) (Indexer, error) {
+func NewIndexer(chainID uint64, ethereumClient *ethclient.Client, databaseClient database.Client, handler Handler, source Source, checkpointLock *CheckpointLock, finalized bool) (Indexer, error) {
 	instance := indexer{
 		ethereumClient: ethereumClient,
 		databaseClient: databaseClient,
 		handler:        handler,
+		source:         source,
 		chainID:        chainID,
 		finalized:      finalized,
 	}
 
+	// Only the finalized indexer writes the checkpoint.
+	if finalized {
+		instance.checkpointLock = checkpointLock
+	}
+
 	return &instance, nil
 }
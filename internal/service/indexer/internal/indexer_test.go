@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexerReorganization(t *testing.T) {
+	t.Parallel()
+
+	ethereumClient, chain := newTestChain(t)
+
+	source, err := NewBlockSource(ethereumClient, 1)
+	require.NoError(t, err)
+
+	// The blocks 4 and 5 indexed are reorganized out of the chain, and the block 1 is finalized.
+	databaseClient := blockDatabase{
+		checkpoint: &schema.Checkpoint{ChainID: 1, BlockNumber: 1, BlockHash: chain.blocks[1].Hash()},
+		blocks:     make(map[uint64]*schema.Block),
+	}
+
+	for blockNumber := uint64(1); blockNumber <= 5; blockNumber++ {
+		blockHash := chain.blocks[blockNumber].Hash()
+		if blockNumber >= 4 {
+			blockHash = common.BigToHash(chain.blocks[blockNumber].Number())
+		}
+
+		databaseClient.blocks[blockNumber] = &schema.Block{ChainID: 1, BlockNumber: blockNumber, BlockHash: blockHash}
+	}
+
+	instance := indexer{
+		ethereumClient: ethereumClient,
+		databaseClient: &databaseClient,
+		handler:        &recordingHandler{address: addressContract},
+		source:         source,
+		chainID:        1,
+		checkpoint:     &schema.Checkpoint{ChainID: 1, BlockNumber: 5, BlockHash: databaseClient.blocks[5].BlockHash},
+	}
+
+	// The parent of the block 6 diverges from the checkpoint, so the indexer rolls back to the block 3.
+	require.NoError(t, instance.index(context.Background()))
+	assert.Equal(t, []uint64{5, 4}, databaseClient.rolledBack)
+	assert.Equal(t, uint64(3), instance.checkpoint.BlockNumber)
+	assert.Equal(t, chain.blocks[3].Hash(), instance.checkpoint.BlockHash)
+
+	require.NoError(t, instance.index(context.Background()))
+	assert.Equal(t, uint64(4), instance.checkpoint.BlockNumber)
+	assert.Equal(t, chain.blocks[4].Hash(), databaseClient.blocks[4].BlockHash)
+}
+
+type blockDatabase struct {
+	database.Client
+
+	checkpoint *schema.Checkpoint
+	blocks     map[uint64]*schema.Block
+	rolledBack []uint64
+}
+
+func (c *blockDatabase) Begin(_ context.Context, _ ...*sql.TxOptions) (database.Client, error) {
+	return c, nil
+}
+
+func (c *blockDatabase) Commit() error {
+	return nil
+}
+
+func (c *blockDatabase) Rollback() error {
+	return nil
+}
+
+func (c *blockDatabase) FindCheckpoint(_ context.Context, _ uint64) (*schema.Checkpoint, error) {
+	checkpoint := *c.checkpoint
+
+	return &checkpoint, nil
+}
+
+func (c *blockDatabase) FindBlock(_ context.Context, _, blockNumber uint64) (*schema.Block, error) {
+	block, exists := c.blocks[blockNumber]
+	if !exists {
+		return nil, database.ErrorRowNotFound
+	}
+
+	return block, nil
+}
+
+func (c *blockDatabase) SaveBlock(_ context.Context, block *schema.Block) error {
+	c.blocks[block.BlockNumber] = block
+
+	return nil
+}
+
+func (c *blockDatabase) DeleteBlocksBeforeBlockNumber(_ context.Context, _, blockNumber uint64) error {
+	for number := range c.blocks {
+		if number < blockNumber {
+			delete(c.blocks, number)
+		}
+	}
+
+	return nil
+}
+
+func (c *blockDatabase) RollbackBlock(_ context.Context, _, blockNumber uint64) error {
+	c.rolledBack = append(c.rolledBack, blockNumber)
+	delete(c.blocks, blockNumber)
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	reorganizationCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "indexer_reorganizations_total",
+			Help: "Total number of the reorganizations of each chain detected by the indexer",
+		},
+		[]string{"chain_id"},
+	)
+	reorganizationDepth = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "indexer_reorganization_depth",
+			Help:    "Number of the blocks rolled back in the reorganizations of each chain",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 11),
+		},
+		[]string{"chain_id"},
+	)
+)
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rss3-network/global-indexer/internal/database"
+)
+
+// The sources of the blocks and receipts processed by the Handler.
+const (
+	// SourceBlock fetches each block with all its receipts.
+	SourceBlock = "block"
+	// SourceLogs filters the logs of the contracts indexed, and fetches only the blocks and receipts containing them.
+	SourceLogs = "logs"
+)
+
+// Source uses to fetch the blocks and receipts processed by the Handler.
+type Source interface {
+	// Fetch returns the blocks from `from` to `to` in order with their receipts,
+	// the blocks can be skipped if there is nothing to index in them, but the first block is always `from` and the last block is always `to`.
+	// The blocks skipped are still processed by the Handler as empty blocks.
+	Fetch(ctx context.Context, from, to uint64) ([]*types.Block, []types.Receipts, error)
+	// RangeSize returns the max number of blocks fetched at a time by the indexer.
+	RangeSize() uint64
+}
+
+// processBlocks processes the blocks fetched from a Source in order. The blocks skipped by the Source are processed as empty blocks,
+// which contain only the block number, so the Handler still deletes the unfinalized rows of them.
+func processBlocks(ctx context.Context, handler Handler, blocks []*types.Block, receipts []types.Receipts, databaseTransaction database.Client) error {
+	for index, block := range blocks {
+		if index > 0 {
+			for blockNumber := blocks[index-1].NumberU64() + 1; blockNumber < block.NumberU64(); blockNumber++ {
+				emptyBlock := types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(blockNumber)})
+
+				if err := handler.Process(ctx, emptyBlock, nil, databaseTransaction); err != nil {
+					return fmt.Errorf("process skipped block %d: %w", blockNumber, err)
+				}
+			}
+		}
+
+		if err := handler.Process(ctx, block, receipts[index], databaseTransaction); err != nil {
+			return fmt.Errorf("process block %d: %w", block.NumberU64(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sourcegraph/conc/pool"
+)
+
+var _ Source = (*blockSource)(nil)
+
+type blockSource struct {
+	ethereumClient *ethclient.Client
+	// threads is the number of blocks fetched concurrently.
+	threads int
+}
+
+func (s *blockSource) Fetch(ctx context.Context, from, to uint64) ([]*types.Block, []types.Receipts, error) {
+	var (
+		blocks   = make([]*types.Block, to-from+1)
+		receipts = make([]types.Receipts, to-from+1)
+	)
+
+	fetchPool := pool.New().WithContext(ctx).WithMaxGoroutines(s.threads).WithCancelOnError().WithFirstError()
+
+	for blockNumber := from; blockNumber <= to; blockNumber++ {
+		blockNumber, index := blockNumber, blockNumber-from
+
+		fetchPool.Go(func(ctx context.Context) (err error) {
+			blocks[index], receipts[index], err = s.fetchBlock(ctx, blockNumber)
+
+			return err
+		})
+	}
+
+	if err := fetchPool.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return blocks, receipts, nil
+}
+
+func (s *blockSource) fetchBlock(ctx context.Context, blockNumber uint64) (*types.Block, types.Receipts, error) {
+	var (
+		block    *types.Block
+		receipts types.Receipts
+	)
+
+	retryableFunc := func() (err error) {
+		if block, err = s.ethereumClient.BlockByNumber(ctx, new(big.Int).SetUint64(blockNumber)); err != nil {
+			return fmt.Errorf("get block by number %d: %w", blockNumber, err)
+		}
+
+		if receipts, err = s.ethereumClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false)); err != nil {
+			return fmt.Errorf("get receipts of block %d: %w", blockNumber, err)
+		}
+
+		return nil
+	}
+
+	if err := retry.Do(retryableFunc, retry.Context(ctx), retry.Delay(time.Second), retry.Attempts(5)); err != nil {
+		return nil, nil, err
+	}
+
+	return block, receipts, nil
+}
+
+// RangeSize returns 1, so the indexer processes a block at a time.
+func (s *blockSource) RangeSize() uint64 {
+	return 1
+}
+
+// NewBlockSource returns a Source that fetches each block with all its receipts.
+func NewBlockSource(ethereumClient *ethclient.Client, threads int) (Source, error) {
+	if threads <= 0 {
+		return nil, fmt.Errorf("invalid threads %d", threads)
+	}
+
+	instance := blockSource{
+		ethereumClient: ethereumClient,
+		threads:        threads,
+	}
+
+	return &instance, nil
+}
@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/sourcegraph/conc/pool"
+)
+
+var _ Source = (*logsSource)(nil)
+
+type logsSource struct {
+	ethereumClient *ethclient.Client
+	// addresses are the contracts indexed by the Handler.
+	addresses []common.Address
+	// threads is the number of blocks fetched concurrently.
+	threads int
+	// rangeSize is the max number of blocks of an eth_getLogs request.
+	rangeSize uint64
+}
+
+// logsBlock is a block containing the logs of the contracts indexed.
+type logsBlock struct {
+	number uint64
+	// hash is empty if the block contains no log.
+	hash common.Hash
+	// transactions are the indexes of the transactions emitted the logs in order.
+	transactions []uint
+}
+
+// Fetch filters the logs of the contracts by eth_getLogs, and fetches the headers, transactions and receipts of the logs.
+// The blocks returned contain only the transactions emitted the logs, and the receipts are the ones of these transactions.
+func (s *logsSource) Fetch(ctx context.Context, from, to uint64) ([]*types.Block, []types.Receipts, error) {
+	logsBlocks, err := s.filterLogs(ctx, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		blocks   = make([]*types.Block, len(logsBlocks))
+		receipts = make([]types.Receipts, len(logsBlocks))
+	)
+
+	fetchPool := pool.New().WithContext(ctx).WithMaxGoroutines(s.threads).WithCancelOnError().WithFirstError()
+
+	for index, logsBlock := range logsBlocks {
+		index, logsBlock := index, logsBlock
+
+		fetchPool.Go(func(ctx context.Context) error {
+			retryableFunc := func() (err error) {
+				blocks[index], receipts[index], err = s.fetchBlock(ctx, logsBlock)
+
+				return err
+			}
+
+			return retry.Do(retryableFunc, retry.Context(ctx), retry.Delay(time.Second), retry.Attempts(5))
+		})
+	}
+
+	if err := fetchPool.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return blocks, receipts, nil
+}
+
+// filterLogs returns the blocks containing the logs from `from` to `to` in order, and the blocks `from` and `to` are always included.
+func (s *logsSource) filterLogs(ctx context.Context, from, to uint64) ([]*logsBlock, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: s.addresses,
+	}
+
+	var logs []types.Log
+
+	retryableFunc := func() (err error) {
+		if logs, err = s.ethereumClient.FilterLogs(ctx, query); err != nil {
+			return fmt.Errorf("filter logs from %d to %d: %w", from, to, err)
+		}
+
+		return nil
+	}
+
+	if err := retry.Do(retryableFunc, retry.Context(ctx), retry.Delay(time.Second), retry.Attempts(5)); err != nil {
+		return nil, err
+	}
+
+	logsBlockMap := make(map[uint64]*logsBlock)
+
+	for _, log := range logs {
+		// Discard all removed logs.
+		if log.Removed {
+			continue
+		}
+
+		block, exists := logsBlockMap[log.BlockNumber]
+		if !exists {
+			block = &logsBlock{
+				number: log.BlockNumber,
+				hash:   log.BlockHash,
+			}
+
+			logsBlockMap[log.BlockNumber] = block
+		}
+
+		if block.hash != log.BlockHash {
+			return nil, fmt.Errorf("block %d is reorganized while filtering logs", log.BlockNumber)
+		}
+
+		if index := len(block.transactions); index == 0 || block.transactions[index-1] != log.TxIndex {
+			block.transactions = append(block.transactions, log.TxIndex)
+		}
+	}
+
+	for _, number := range []uint64{from, to} {
+		if _, exists := logsBlockMap[number]; !exists {
+			logsBlockMap[number] = &logsBlock{number: number}
+		}
+	}
+
+	logsBlocks := make([]*logsBlock, 0, len(logsBlockMap))
+	for _, block := range logsBlockMap {
+		logsBlocks = append(logsBlocks, block)
+	}
+
+	sort.Slice(logsBlocks, func(i, j int) bool {
+		return logsBlocks[i].number < logsBlocks[j].number
+	})
+
+	return logsBlocks, nil
+}
+
+func (s *logsSource) fetchBlock(ctx context.Context, logsBlock *logsBlock) (*types.Block, types.Receipts, error) {
+	var (
+		header *types.Header
+		err    error
+	)
+
+	if logsBlock.hash == (common.Hash{}) {
+		header, err = s.ethereumClient.HeaderByNumber(ctx, new(big.Int).SetUint64(logsBlock.number))
+	} else {
+		header, err = s.ethereumClient.HeaderByHash(ctx, logsBlock.hash)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("get header of block %d: %w", logsBlock.number, err)
+	}
+
+	var (
+		transactions = make([]*types.Transaction, 0, len(logsBlock.transactions))
+		receipts     = make(types.Receipts, 0, len(logsBlock.transactions))
+	)
+
+	for _, transactionIndex := range logsBlock.transactions {
+		transaction, err := s.ethereumClient.TransactionInBlock(ctx, header.Hash(), transactionIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get transaction %d of block %d: %w", transactionIndex, logsBlock.number, err)
+		}
+
+		receipt, err := s.ethereumClient.TransactionReceipt(ctx, transaction.Hash())
+		if err != nil {
+			return nil, nil, fmt.Errorf("get receipt of transaction %s: %w", transaction.Hash(), err)
+		}
+
+		if receipt.BlockHash != header.Hash() {
+			return nil, nil, fmt.Errorf("block %d is reorganized while fetching receipts", logsBlock.number)
+		}
+
+		transactions = append(transactions, transaction)
+		receipts = append(receipts, receipt)
+	}
+
+	return types.NewBlockWithHeader(header).WithBody(transactions, nil), receipts, nil
+}
+
+func (s *logsSource) RangeSize() uint64 {
+	return s.rangeSize
+}
+
+// NewLogsSource returns a Source that fetches only the blocks and receipts containing the logs of the addresses.
+func NewLogsSource(ethereumClient *ethclient.Client, addresses []common.Address, threads int, rangeSize uint64) (Source, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no address to filter logs")
+	}
+
+	if threads <= 0 {
+		return nil, fmt.Errorf("invalid threads %d", threads)
+	}
+
+	if rangeSize == 0 {
+		return nil, fmt.Errorf("invalid range size %d", rangeSize)
+	}
+
+	instance := logsSource{
+		ethereumClient: ethereumClient,
+		addresses:      addresses,
+		threads:        threads,
+		rangeSize:      rangeSize,
+	}
+
+	return &instance, nil
+}
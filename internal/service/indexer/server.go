@@ -5,25 +5,45 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/redis/go-redis/v9"
 	"github.com/rss3-network/global-indexer/internal/cache"
 	"github.com/rss3-network/global-indexer/internal/client/ethereum"
+	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/config/flag"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/indexer/internal"
 	"github.com/rss3-network/global-indexer/internal/service/indexer/internal/handler/l1"
 	"github.com/rss3-network/global-indexer/internal/service/indexer/internal/handler/l2"
+	"github.com/samber/lo"
 	"github.com/sourcegraph/conc/pool"
 	"github.com/spf13/viper"
 )
 
 const Name = "indexer"
 
+const (
+	ChainL1 = "l1"
+	ChainL2 = "l2"
+)
+
 type Server struct {
 	databaseClient           database.Client
 	cacheClient              cache.Client
+	redisClient              *redis.Client
 	ethereumMultiChainClient *ethereum.MultiChainClient
+	config                   *config.File
+	backfill                 *backfill
+}
+
+// backfill indexes a range of finalized blocks of a chain instead of following the chain.
+type backfill struct {
+	chain     string
+	from      uint64
+	to        uint64
+	rangeSize uint64
 }
 
 func (s *Server) Name() string {
@@ -31,6 +51,10 @@ func (s *Server) Name() string {
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	if s.backfill != nil {
+		return s.runBackfill(ctx)
+	}
+
 	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
 
 	// Run L1 indexers.
@@ -88,6 +112,48 @@ func (s *Server) Run(ctx context.Context) error {
 	return nil
 }
 
+func (s *Server) runBackfill(ctx context.Context) error {
+	var (
+		chainID uint64
+		handler internal.Handler
+	)
+
+	switch s.backfill.chain {
+	case ChainL1:
+		chainID = viper.GetUint64(flag.KeyChainIDL1)
+	case ChainL2:
+		chainID = viper.GetUint64(flag.KeyChainIDL2)
+	}
+
+	ethereumClient, err := s.ethereumMultiChainClient.Get(chainID)
+	if err != nil {
+		return fmt.Errorf("load ethereum client: %w", err)
+	}
+
+	// The blocks backfilled are finalized.
+	if s.backfill.chain == ChainL1 {
+		handler, err = l1.NewHandler(chainID, ethereumClient, true)
+	} else {
+		handler, err = l2.NewHandler(chainID, ethereumClient, s.cacheClient, true)
+	}
+
+	if err != nil {
+		return fmt.Errorf("new %s handler: %w", s.backfill.chain, err)
+	}
+
+	source, err := s.newSource(s.backfill.chain, chainID, ethereumClient)
+	if err != nil {
+		return fmt.Errorf("new %s source: %w", s.backfill.chain, err)
+	}
+
+	backfiller, err := internal.NewBackfiller(chainID, ethereumClient, s.databaseClient, handler, source, internal.NewCheckpointLock(s.redisClient, chainID), s.backfill.rangeSize)
+	if err != nil {
+		return fmt.Errorf("new backfiller: %w", err)
+	}
+
+	return backfiller.Run(ctx, s.backfill.from, s.backfill.to)
+}
+
 func (s *Server) newL1Indexer(finalized bool) (internal.Indexer, error) {
 	chainID := viper.GetUint64(flag.KeyChainIDL1)
 
@@ -101,7 +167,12 @@ func (s *Server) newL1Indexer(finalized bool) (internal.Indexer, error) {
 		return nil, fmt.Errorf("new l1 handler: %w", err)
 	}
 
-	indexer, err := internal.NewIndexer(chainID, ethereumClient, s.databaseClient, handler, finalized)
+	source, err := s.newSource(ChainL1, chainID, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("new l1 source: %w", err)
+	}
+
+	indexer, err := internal.NewIndexer(chainID, ethereumClient, s.databaseClient, handler, source, internal.NewCheckpointLock(s.redisClient, chainID), finalized)
 	if err != nil {
 		return nil, fmt.Errorf("new l1 indexer: %w", err)
 	}
@@ -122,7 +193,12 @@ func (s *Server) newL2Indexer(finalized bool) (internal.Indexer, error) {
 		return nil, fmt.Errorf("new l2 handler: %w", err)
 	}
 
-	indexer, err := internal.NewIndexer(chainID, ethereumClient, s.databaseClient, handler, finalized)
+	source, err := s.newSource(ChainL2, chainID, ethereumClient)
+	if err != nil {
+		return nil, fmt.Errorf("new l2 source: %w", err)
+	}
+
+	indexer, err := internal.NewIndexer(chainID, ethereumClient, s.databaseClient, handler, source, internal.NewCheckpointLock(s.redisClient, chainID), finalized)
 	if err != nil {
 		return nil, fmt.Errorf("new l2 indexer: %w", err)
 	}
@@ -130,11 +206,59 @@ func (s *Server) newL2Indexer(finalized bool) (internal.Indexer, error) {
 	return indexer, nil
 }
 
-func NewServer(databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient) (service.Server, error) {
+// newSource returns the source of the chain configured.
+func (s *Server) newSource(chain string, chainID uint64, ethereumClient *ethclient.Client) (internal.Source, error) {
+	var (
+		source    string
+		threads   uint64
+		logsRange uint64
+		addresses []common.Address
+	)
+
+	switch chain {
+	case ChainL1:
+		source, threads, logsRange, addresses = s.config.RSS3Chain.SourceL1, s.config.RSS3Chain.BlockThreadsL1, s.config.RSS3Chain.LogsRangeL1, l1.Addresses(chainID)
+	case ChainL2:
+		source, threads, logsRange, addresses = s.config.RSS3Chain.SourceL2, s.config.RSS3Chain.BlockThreadsL2, s.config.RSS3Chain.LogsRangeL2, l2.Addresses(chainID)
+	default:
+		return nil, fmt.Errorf("unsupported chain: %s", chain)
+	}
+
+	switch source {
+	case internal.SourceBlock:
+		return internal.NewBlockSource(ethereumClient, int(threads))
+	case internal.SourceLogs:
+		return internal.NewLogsSource(ethereumClient, addresses, int(threads), logsRange)
+	default:
+		return nil, fmt.Errorf("unsupported source: %s", source)
+	}
+}
+
+// TryLockCheckpoint acquires the checkpoint lock of the chain once, it fails while an indexer or a backfill of the chain holds it.
+func TryLockCheckpoint(ctx context.Context, redisClient *redis.Client, chainID uint64) (unlock func(), err error) {
+	return internal.NewCheckpointLock(redisClient, chainID).TryLock(ctx)
+}
+
+func NewServer(databaseClient database.Client, redisClient *redis.Client, ethereumMultiChainClient *ethereum.MultiChainClient, config *config.File) (service.Server, error) {
 	instance := Server{
 		databaseClient:           databaseClient,
 		cacheClient:              cache.New(redisClient),
+		redisClient:              redisClient,
 		ethereumMultiChainClient: ethereumMultiChainClient,
+		config:                   config,
+	}
+
+	if viper.GetBool(flag.KeyBackfill) {
+		instance.backfill = &backfill{
+			chain:     viper.GetString(flag.KeyChain),
+			from:      viper.GetUint64(flag.KeyFrom),
+			to:        viper.GetUint64(flag.KeyTo),
+			rangeSize: viper.GetUint64(flag.KeyRangeSize),
+		}
+
+		if !lo.Contains([]string{ChainL1, ChainL2}, instance.backfill.chain) {
+			return nil, fmt.Errorf("unsupported chain: %s", instance.backfill.chain)
+		}
 	}
 
 	return &instance, nil
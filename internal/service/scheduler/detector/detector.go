@@ -12,6 +12,9 @@ import (
 	"github.com/rss3-network/global-indexer/internal/cronjob"
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
@@ -57,12 +60,30 @@ func (s *server) Run(ctx context.Context) error {
 func (s *server) updateNodeActivity(ctx context.Context) error {
 	timeout := time.Now().Add(-5 * time.Minute)
 
+	nodes, err := s.databaseClient.FindNodes(ctx, schema.FindNodesQuery{Status: lo.ToPtr(schema.NodeStatusOnline)})
+	if err != nil {
+		return fmt.Errorf("find online nodes: %w", err)
+	}
+
 	if err := s.databaseClient.UpdateNodesStatusOffline(ctx, timeout.Unix()); err != nil {
 		zap.L().Error("update node activity error", zap.Error(err), zap.String("timeout", timeout.String()))
 
 		return fmt.Errorf("update node activity: %w", err)
 	}
 
+	// Notify the operators of the Nodes set offline for missing their heartbeats.
+	for _, node := range nodes {
+		if node.LastHeartbeatTimestamp >= timeout.Unix() {
+			continue
+		}
+
+		data := webhook.HeartbeatMissedData{LastHeartbeat: node.LastHeartbeatTimestamp}
+
+		if err := webhook.Emit(ctx, s.databaseClient, node.Address, schema.NodeWebhookEventTypeHeartbeatMissed, data); err != nil {
+			zap.L().Error("emit node heartbeat missed event", zap.Error(err), zap.Stringer("node", node.Address))
+		}
+	}
+
 	return nil
 }
 
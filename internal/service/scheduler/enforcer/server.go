@@ -14,6 +14,7 @@ import (
 	"github.com/rss3-network/global-indexer/internal/database"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/enforcer"
+	challengestates "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/challenge_states"
 	epochfresher "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/epoch_fresher"
 	federatedhandles "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/federated_handles"
 	nodestatus "github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer/node_status"
@@ -27,6 +28,7 @@ var _ service.Server = (*server)(nil)
 
 type server struct {
 	enforcers []service.Server
+	txManager txmgr.TxManager
 }
 
 func (s *server) Name() string {
@@ -34,6 +36,11 @@ func (s *server) Name() string {
 }
 
 func (s *server) Run(ctx context.Context) error {
+	// Resume the transactions left pending by the last run before sending new ones.
+	if err := s.txManager.Recover(ctx, nil); err != nil {
+		return fmt.Errorf("recover pending transactions: %w", err)
+	}
+
 	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
 
 	for _, e := range s.enforcers {
@@ -93,8 +100,10 @@ func New(databaseClient database.Client, redis *redis.Client, ethereumClient *et
 		enforcers: []service.Server{
 			nodestatus.New(redis, simpleEnforcer),
 			reliabilityscore.New(redis, simpleEnforcer),
+			challengestates.New(redis, simpleEnforcer),
 			epochfresher.New(redis, ethereumClient, checkpoint.BlockNumber, simpleEnforcer, contractStakingEvents, settlementContract, contractAddresses.AddressStakingProxy),
 			federatedhandles.New(redis, databaseClient, httpClient),
 		},
+		txManager: txManager.WithService(txmgr.ServiceEnforcer),
 	}, nil
 }
@@ -0,0 +1,205 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/zap"
+)
+
+var _ service.Server = (*server)(nil)
+
+var Name = "notifier"
+
+const (
+	deliveryLimit       = 100
+	deliveryConcurrency = 10
+	deliveryTimeout     = 10 * time.Second
+)
+
+// server delivers the events of the Nodes to the webhooks registered by their operators.
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	httpClient     *http.Client
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "*/5 * * * * *"
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
+		if err := s.deliver(ctx); err != nil {
+			zap.L().Error("deliver node webhook events error", zap.Error(err))
+			return
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("add notifier cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+// deliver attempts the pending deliveries that are due.
+func (s *server) deliver(ctx context.Context) error {
+	deliveries, err := s.databaseClient.FindNodeWebhookDeliveries(ctx, schema.NodeWebhookDeliveryQuery{
+		Status:    lo.ToPtr(schema.NodeWebhookDeliveryStatusPending),
+		DueBefore: lo.ToPtr(time.Now()),
+		Limit:     lo.ToPtr(deliveryLimit),
+	})
+	if err != nil {
+		return fmt.Errorf("find node webhook deliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	webhooks := make(map[uint64]*schema.NodeWebhook)
+
+	for _, delivery := range deliveries {
+		if _, exists := webhooks[delivery.WebhookID]; exists {
+			continue
+		}
+
+		result, err := s.databaseClient.FindNodeWebhooks(ctx, schema.NodeWebhookQuery{ID: lo.ToPtr(delivery.WebhookID)})
+		if err != nil {
+			return fmt.Errorf("find node webhook %d: %w", delivery.WebhookID, err)
+		}
+
+		webhooks[delivery.WebhookID] = lo.FirstOrEmpty(result)
+	}
+
+	errorPool := pool.New().WithContext(ctx).WithMaxGoroutines(deliveryConcurrency)
+
+	for _, delivery := range deliveries {
+		delivery := delivery
+
+		errorPool.Go(func(ctx context.Context) error {
+			s.attempt(ctx, webhooks[delivery.WebhookID], delivery)
+
+			if err := s.databaseClient.UpdateNodeWebhookDelivery(ctx, delivery); err != nil {
+				return fmt.Errorf("update node webhook delivery %d: %w", delivery.ID, err)
+			}
+
+			return nil
+		})
+	}
+
+	return errorPool.Wait()
+}
+
+// attempt posts the event to the webhook and records the result on the delivery.
+func (s *server) attempt(ctx context.Context, nodeWebhook *schema.NodeWebhook, delivery *schema.NodeWebhookDelivery) {
+	delivery.Attempts++
+
+	if nodeWebhook == nil {
+		delivery.Status = schema.NodeWebhookDeliveryStatusFailed
+		delivery.Error = lo.ToPtr("webhook not found")
+
+		return
+	}
+
+	statusCode, err := s.post(ctx, nodeWebhook, delivery)
+
+	delivery.ResponseStatus = lo.Ternary(statusCode == 0, nil, &statusCode)
+
+	if err == nil {
+		delivery.Status = schema.NodeWebhookDeliveryStatusDelivered
+		delivery.Error = nil
+		delivery.DeliveredAt = lo.ToPtr(time.Now())
+
+		return
+	}
+
+	zap.L().Warn("attempt node webhook delivery", zap.Error(err), zap.Uint64("delivery", delivery.ID), zap.Uint("attempts", delivery.Attempts))
+
+	delivery.Error = lo.ToPtr(err.Error())
+
+	if delivery.Attempts >= webhook.MaxAttempts {
+		delivery.Status = schema.NodeWebhookDeliveryStatusFailed
+
+		return
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(webhook.Backoff(delivery.Attempts))
+}
+
+// post returns the status code of the response, any status code other than 2xx is an error.
+func (s *server) post(ctx context.Context, nodeWebhook *schema.NodeWebhook, delivery *schema.NodeWebhookDelivery) (int, error) {
+	body, err := json.Marshal(webhook.NewEvent(delivery))
+	if err != nil {
+		return 0, fmt.Errorf("marshal event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, nodeWebhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("new request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.HeaderEvent, string(delivery.EventType))
+	request.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(webhook.HeaderSignature, webhook.Sign(nodeWebhook.Secret, timestamp, body))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("post event: %w", err)
+	}
+
+	defer lo.Try(response.Body.Close)
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("unexpected status: %s", response.Status)
+	}
+
+	return response.StatusCode, nil
+}
+
+func New(databaseClient database.Client, redis *redis.Client) (service.Server, error) {
+	instance := server{
+		databaseClient: databaseClient,
+		cronJob:        cronjob.New(redis, Name, 10*time.Second),
+		httpClient:     webhook.NewHTTPClient(),
+	}
+
+	return &instance, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/detector"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/enforcer"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/notifier"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/taxer"
 	"github.com/spf13/viper"
@@ -30,8 +31,15 @@ func NewServer(databaseClient database.Client, redis *redis.Client, ethereumMult
 		return detector.New(databaseClient, redis)
 	case enforcer.Name:
 		return enforcer.New(databaseClient, redis, ethereumClient, httpClient, config, txManager)
+	case notifier.Name:
+		return notifier.New(databaseClient, redis)
 	case snapshot.Name:
-		return snapshot.New(databaseClient, redis, ethereumClient)
+		ethereumL1Client, err := ethereumMultiChainClient.Get(viper.GetUint64(flag.KeyChainIDL1))
+		if err != nil {
+			return nil, fmt.Errorf("get ethereum l1 client: %w", err)
+		}
+
+		return snapshot.New(databaseClient, redis, ethereumClient, ethereumL1Client, httpClient, config)
 	case taxer.Name:
 		return taxer.New(databaseClient, redis, ethereumClient, config, txManager)
 	default:
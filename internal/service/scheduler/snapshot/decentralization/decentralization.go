@@ -0,0 +1,110 @@
+package decentralization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cache"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	decentralizationcollector "github.com/rss3-network/global-indexer/internal/decentralization"
+	"github.com/rss3-network/global-indexer/internal/service"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+var (
+	Name    = "decentralization"
+	Timeout = time.Minute
+)
+
+var _ service.Server = (*server)(nil)
+
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	cacheClient    cache.Client
+	redisClient    *redis.Client
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 */1 * * * *" // every minute
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
+		if err := s.saveDecentralizationSnapshot(ctx); err != nil {
+			zap.L().Error("save decentralization snapshot", zap.Error(err))
+
+			return
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("add decentralization cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+// saveDecentralizationSnapshot caches the current concentration of the Network for the Hub,
+// and saves it once a new epoch has been distributed.
+// The Nodes are not versioned, so the missed epochs cannot be snapshotted afterward.
+func (s *server) saveDecentralizationSnapshot(ctx context.Context) error {
+	snapshot, err := decentralizationcollector.Collect(ctx, s.databaseClient)
+	if err != nil {
+		return fmt.Errorf("collect decentralization: %w", err)
+	}
+
+	if err := s.cacheClient.Set(ctx, decentralizationcollector.CacheKey, snapshot, decentralizationcollector.CacheExpiration); err != nil {
+		return fmt.Errorf("cache decentralization: %w", err)
+	}
+
+	snapshots, err := s.databaseClient.FindDecentralizationSnapshots(ctx, schema.DecentralizationSnapshotQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find decentralization snapshots: %w", err)
+	}
+
+	epochs, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epochs: %w", err)
+	}
+
+	if len(epochs) == 0 || (len(snapshots) > 0 && snapshots[0].EpochID >= epochs[0].ID) {
+		return nil
+	}
+
+	snapshot.EpochID = epochs[0].ID
+	snapshot.Date = time.Unix(epochs[0].EndTimestamp, 0)
+
+	zap.L().Info("save decentralization snapshot", zap.Uint64("epochID", snapshot.EpochID), zap.Uint64("nakamotoCoefficient", snapshot.NakamotoCoefficient), zap.Stringer("gini", snapshot.Gini))
+
+	return s.databaseClient.SaveDecentralizationSnapshot(ctx, snapshot)
+}
+
+func New(databaseClient database.Client, redis *redis.Client) service.Server {
+	return &server{
+		cronJob:        cronjob.New(redis, Name, Timeout),
+		databaseClient: databaseClient,
+		cacheClient:    cache.New(redis),
+		redisClient:    redis,
+	}
+}
@@ -6,15 +6,21 @@ import (
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/common/httputil"
 	"github.com/rss3-network/global-indexer/contract/l2"
 	stakingv2 "github.com/rss3-network/global-indexer/contract/l2/staking/v2"
+	"github.com/rss3-network/global-indexer/internal/config"
 	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/price"
 	"github.com/rss3-network/global-indexer/internal/service"
 	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/apy"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/decentralization"
 	nodecount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/node_count"
 	operatorprofit "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/operator_profit"
 	stakercount "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/staker_count"
 	stakerprofit "github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/staker_profit"
+	"github.com/rss3-network/global-indexer/internal/service/scheduler/snapshot/tvl"
+	tvlcalculator "github.com/rss3-network/global-indexer/internal/tvl"
 	"github.com/sourcegraph/conc/pool"
 )
 
@@ -44,7 +50,7 @@ func (s *server) Run(ctx context.Context) error {
 	return errorPool.Wait()
 }
 
-func New(databaseClient database.Client, redis *redis.Client, ethereumClient *ethclient.Client) (service.Server, error) {
+func New(databaseClient database.Client, redis *redis.Client, ethereumClient, ethereumL1Client *ethclient.Client, httpClient httputil.Client, config *config.File) (service.Server, error) {
 	chainID, err := ethereumClient.ChainID(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("get chain id: %w", err)
@@ -60,13 +66,30 @@ func New(databaseClient database.Client, redis *redis.Client, ethereumClient *et
 		return nil, fmt.Errorf("new staking contract: %w", err)
 	}
 
+	chainL1ID, err := ethereumL1Client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("get l1 chain id: %w", err)
+	}
+
+	priceProvider, err := price.NewProvider(config.TokenPriceAPI, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("new price provider: %w", err)
+	}
+
+	tvlCalculator, err := tvlcalculator.NewCalculator(ethereumL1Client, ethereumClient, priceProvider, chainL1ID.Uint64(), chainID.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("new tvl calculator: %w", err)
+	}
+
 	return &server{
 		snapshots: []service.Server{
 			nodecount.New(databaseClient, redis),
 			stakercount.New(databaseClient, redis),
+			decentralization.New(databaseClient, redis),
 			stakerprofit.New(databaseClient, redis, stakingContract),
 			operatorprofit.New(databaseClient, redis, stakingContract),
 			apy.New(databaseClient, redis, stakingContract),
+			tvl.New(databaseClient, redis, tvlCalculator),
 		},
 	}, nil
 }
@@ -0,0 +1,111 @@
+package tvl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rss3-network/global-indexer/internal/cronjob"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/internal/service"
+	tvlcalculator "github.com/rss3-network/global-indexer/internal/tvl"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+var (
+	Name    = "tvl"
+	Timeout = time.Minute
+)
+
+var _ service.Server = (*server)(nil)
+
+type server struct {
+	cronJob        *cronjob.CronJob
+	databaseClient database.Client
+	redisClient    *redis.Client
+	calculator     *tvlcalculator.Calculator
+}
+
+func (s *server) Name() string {
+	return Name
+}
+
+func (s *server) Spec() string {
+	return "0 */1 * * * *" // every minute
+}
+
+func (s *server) Run(ctx context.Context) error {
+	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
+		if err := s.saveTVLSnapshot(ctx); err != nil {
+			zap.L().Error("save tvl snapshot", zap.Error(err))
+
+			return
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("add tvl cron job: %w", err)
+	}
+
+	s.cronJob.Start()
+	defer s.cronJob.Stop()
+
+	stopchan := make(chan os.Signal, 1)
+
+	signal.Notify(stopchan, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	<-stopchan
+
+	return nil
+}
+
+// saveTVLSnapshot saves the balances and the prices of the tokens once a new epoch has been distributed.
+// The snapshots are kept per epoch only, as an epoch lasts about 18 hours there is no separate daily snapshot.
+// The balances are read at the latest block, so the missed epochs cannot be snapshotted afterward,
+// and the snapshot is retried until the price providers are available and price all tokens, rather than recording stale or zero prices.
+func (s *server) saveTVLSnapshot(ctx context.Context) error {
+	snapshots, err := s.databaseClient.FindTVLSnapshots(ctx, schema.TVLSnapshotQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find tvl snapshots: %w", err)
+	}
+
+	epochs, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("find epochs: %w", err)
+	}
+
+	if len(epochs) == 0 || (len(snapshots) > 0 && snapshots[0].EpochID >= epochs[0].ID) {
+		return nil
+	}
+
+	prices, err := s.calculator.FetchPrices(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch token prices: %w", err)
+	}
+
+	snapshot, err := s.calculator.Calculate(ctx, prices)
+	if err != nil {
+		return fmt.Errorf("calculate tvl: %w", err)
+	}
+
+	snapshot.EpochID = epochs[0].ID
+	snapshot.Date = time.Unix(epochs[0].EndTimestamp, 0)
+
+	zap.L().Info("save tvl snapshot", zap.Uint64("epochID", snapshot.EpochID), zap.Stringer("tvl", snapshot.TVL))
+
+	return s.databaseClient.SaveTVLSnapshot(ctx, snapshot)
+}
+
+func New(databaseClient database.Client, redis *redis.Client, calculator *tvlcalculator.Calculator) service.Server {
+	return &server{
+		cronJob:        cronjob.New(redis, Name, Timeout),
+		databaseClient: databaseClient,
+		redisClient:    redis,
+		calculator:     calculator,
+	}
+}
@@ -46,6 +46,11 @@ func (s *Server) Spec() string {
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	// Resume the transactions left pending by the last run before sending new ones.
+	if err := s.txManager.Recover(ctx, nil); err != nil {
+		return fmt.Errorf("recover pending transactions: %w", err)
+	}
+
 	err := s.cronJob.AddFunc(ctx, s.Spec(), func() {
 		if err := s.checkAndSubmitAverageTaxRate(ctx); err != nil {
 			zap.L().Error("submit average tax rate error", zap.Error(err))
@@ -91,7 +96,7 @@ func New(databaseClient database.Client, redisClient *redis.Client, ethereumClie
 		chainID:         chainID,
 		stakingContract: stakingContract,
 		settlerConfig:   config.Settler,
-		txManager:       txManager,
+		txManager:       txManager.WithService(txmgr.ServiceTaxer),
 	}
 
 	return server, nil
@@ -19,22 +19,23 @@ import (
 	"github.com/rss3-network/global-indexer/internal/service/hub/handler/dsl/model"
 	"github.com/rss3-network/global-indexer/schema"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"github.com/sourcegraph/conc/pool"
 )
 
-func (s *Server) calculateOperationRewards(ctx context.Context, operationStats []*schema.Stat, rewards *config.Rewards) ([]*big.Int, error) {
+func (s *Server) calculateOperationRewards(ctx context.Context, operationStats []*schema.Stat, rewards *config.Rewards) ([]*big.Int, []*schema.NodeScoreBreakdown, error) {
 	// If there are no nodes, return nil
 	if len(operationStats) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	operationRewards, err := s.calculateFinalRewards(ctx, operationStats, rewards)
+	operationRewards, breakdowns, err := s.calculateFinalRewards(ctx, operationStats, rewards)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate operation rewards: %w", err)
+		return nil, nil, fmt.Errorf("failed to calculate operation rewards: %w", err)
 	}
 
-	return operationRewards, nil
+	return operationRewards, breakdowns, nil
 }
 
 type StatValue struct {
@@ -42,8 +43,17 @@ type StatValue struct {
 	isLatestVersion                                                             bool
 }
 
-// calculateFinalRewards calculates the final rewards for each node based on the operation stats.
-func (s *Server) calculateFinalRewards(ctx context.Context, operationStats []*schema.Stat, rewards *config.Rewards) ([]*big.Int, error) {
+// Score is the operation score of a Node and its weighted sub-scores.
+type Score struct {
+	valid, invalid, network, indexer, activity, upTime, version *big.Float
+	distribution, data, stability                               *big.Float
+	// total is the operation score, it is never less than 0.
+	total *big.Float
+}
+
+// calculateFinalRewards calculates the final rewards for each node based on the operation stats,
+// and returns the breakdown of the scores and rewards of each node.
+func (s *Server) calculateFinalRewards(ctx context.Context, operationStats []*schema.Stat, rewards *config.Rewards) ([]*big.Int, []*schema.NodeScoreBreakdown, error) {
 	operationRewards := make([]*big.Int, len(operationStats))
 	maxStatValue := StatValue{
 		validCount:    big.NewFloat(0),
@@ -62,22 +72,57 @@ func (s *Server) calculateFinalRewards(ctx context.Context, operationStats []*sc
 	scores, totalScore := calculateScores(ctx, operationStats, statValues, maxStatValue, rewards, &mu)
 
 	for i, score := range scores {
-		if score.Cmp(big.NewFloat(0)) == 0 {
+		if score.total.Cmp(big.NewFloat(0)) == 0 {
 			operationRewards[i] = big.NewInt(0)
 			continue
 		}
 
-		reward := new(big.Float).Mul(new(big.Float).Quo(score, totalScore), big.NewFloat(rewards.OperationRewards))
+		reward := new(big.Float).Mul(new(big.Float).Quo(score.total, totalScore), big.NewFloat(rewards.OperationRewards))
 		rewardFinal, _ := reward.Int(nil)
 		scaleGwei(rewardFinal)
 		operationRewards[i] = rewardFinal
 	}
 
 	if err := checkRewardsCeiling(operationRewards, rewards.OperationRewards); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	breakdowns := make([]*schema.NodeScoreBreakdown, len(operationStats))
+	for i := range operationStats {
+		breakdowns[i] = newScoreBreakdown(operationStats[i], statValues[i], scores[i], operationRewards[i])
+	}
+
+	return operationRewards, breakdowns, nil
+}
+
+// newScoreBreakdown returns the breakdown of the scores and rewards of a node, the epoch and address are set by the caller.
+func newScoreBreakdown(stat *schema.Stat, statValue StatValue, score *Score, operationRewards *big.Int) *schema.NodeScoreBreakdown {
+	breakdown := schema.NodeScoreBreakdown{
+		ActivityCount:     floatToInt64(statValue.activityCount),
+		Uptime:            floatToInt64(statValue.upTime),
+		IsLatestVersion:   statValue.isLatestVersion,
+		ValidScore:        floatToFloat64(score.valid),
+		InvalidScore:      floatToFloat64(score.invalid),
+		NetworkScore:      floatToFloat64(score.network),
+		IndexerScore:      floatToFloat64(score.indexer),
+		ActivityScore:     floatToFloat64(score.activity),
+		UptimeScore:       floatToFloat64(score.upTime),
+		VersionScore:      floatToFloat64(score.version),
+		DistributionScore: floatToFloat64(score.distribution),
+		DataScore:         floatToFloat64(score.data),
+		StabilityScore:    floatToFloat64(score.stability),
+		Score:             floatToFloat64(score.total),
+		OperationRewards:  decimal.NewFromBigInt(operationRewards, 0),
+	}
+
+	if stat != nil {
+		breakdown.ValidRequestCount = stat.EpochRequest
+		breakdown.InvalidRequestCount = stat.EpochInvalidRequest
+		breakdown.NetworkCount = int64(stat.DecentralizedNetwork + stat.FederatedNetwork)
+		breakdown.IndexerCount = int64(stat.Indexer)
 	}
 
-	return operationRewards, nil
+	return &breakdown
 }
 
 // processStat processes the stat for the operation rewards calculation.
@@ -128,8 +173,8 @@ func (s *Server) processStat(ctx context.Context, operationStats []*schema.Stat,
 }
 
 // calculateScores calculates the scores for the operation rewards calculation.
-func calculateScores(ctx context.Context, operationStats []*schema.Stat, statsData []StatValue, maxValues StatValue, rewards *config.Rewards, mu *sync.Mutex) ([]*big.Float, *big.Float) {
-	scores := make([]*big.Float, len(operationStats))
+func calculateScores(ctx context.Context, operationStats []*schema.Stat, statsData []StatValue, maxValues StatValue, rewards *config.Rewards, mu *sync.Mutex) ([]*Score, *big.Float) {
+	scores := make([]*Score, len(operationStats))
 	totalScore := big.NewFloat(0)
 
 	errorPool := pool.New().WithContext(ctx).WithMaxGoroutines(30).WithCancelOnError().WithFirstError()
@@ -139,39 +184,35 @@ func calculateScores(ctx context.Context, operationStats []*schema.Stat, statsDa
 
 		errorPool.Go(func(_ context.Context) error {
 			if operationStats[i] == nil || operationStats[i].EpochInvalidRequest >= int64(model.DemotionCountBeforeSlashing) {
-				scores[i] = big.NewFloat(0)
+				scores[i] = &Score{total: big.NewFloat(0)}
 
 				return nil
 			}
 
-			distributionScore := new(big.Float).
-				Sub(
-					calculateScore(statsData[i].validCount, maxValues.validCount, rewards.OperationScore.Distribution.Weight, 1),
-					calculateScore(statsData[i].invalidCount, maxValues.invalidCount, rewards.OperationScore.Distribution.Weight, rewards.OperationScore.Distribution.WeightInvalid),
-				)
-
-			dataScore := new(big.Float).Add(calculateScore(statsData[i].networkCount, maxValues.networkCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightNetwork),
-				new(big.Float).Add(
-					calculateScore(statsData[i].indexerCount, maxValues.indexerCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightIndexer),
-					calculateScore(statsData[i].activityCount, maxValues.activityCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightActivity),
-				),
-			)
+			score := Score{
+				valid:    calculateScore(statsData[i].validCount, maxValues.validCount, rewards.OperationScore.Distribution.Weight, 1),
+				invalid:  calculateScore(statsData[i].invalidCount, maxValues.invalidCount, rewards.OperationScore.Distribution.Weight, rewards.OperationScore.Distribution.WeightInvalid),
+				network:  calculateScore(statsData[i].networkCount, maxValues.networkCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightNetwork),
+				indexer:  calculateScore(statsData[i].indexerCount, maxValues.indexerCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightIndexer),
+				activity: calculateScore(statsData[i].activityCount, maxValues.activityCount, rewards.OperationScore.Data.Weight, rewards.OperationScore.Data.WeightActivity),
+				upTime:   calculateScore(statsData[i].upTime, maxValues.upTime, rewards.OperationScore.Stability.Weight, rewards.OperationScore.Stability.WeightUptime),
+				version:  calculateScore(big.NewFloat(float64(lo.Ternary(statsData[i].isLatestVersion, 1, 0))), big.NewFloat(1), rewards.OperationScore.Stability.Weight, rewards.OperationScore.Stability.WeightVersion),
+			}
 
-			stabilityScore := new(big.Float).
-				Add(
-					calculateScore(statsData[i].upTime, maxValues.upTime, rewards.OperationScore.Stability.Weight, rewards.OperationScore.Stability.WeightUptime),
-					calculateScore(big.NewFloat(float64(lo.Ternary(statsData[i].isLatestVersion, 1, 0))), big.NewFloat(1), rewards.OperationScore.Stability.Weight, rewards.OperationScore.Stability.WeightVersion),
-				)
+			score.distribution = new(big.Float).Sub(score.valid, score.invalid)
+			score.data = new(big.Float).Add(score.network, new(big.Float).Add(score.indexer, score.activity))
+			score.stability = new(big.Float).Add(score.upTime, score.version)
+			score.total = new(big.Float).Add(score.distribution, new(big.Float).Add(score.data, score.stability))
 
-			scores[i] = new(big.Float).Add(distributionScore, new(big.Float).Add(dataScore, stabilityScore))
+			scores[i] = &score
 
 			mu.Lock()
 			// If the score is less than 0, set it to 0
-			if scores[i].Cmp(big.NewFloat(0)) < 0 {
-				scores[i].Set(big.NewFloat(0))
+			if score.total.Cmp(big.NewFloat(0)) < 0 {
+				score.total.Set(big.NewFloat(0))
 			}
 
-			totalScore = totalScore.Add(totalScore, scores[i])
+			totalScore = totalScore.Add(totalScore, score.total)
 			mu.Unlock()
 
 			return nil
@@ -192,6 +233,28 @@ func maxFloat(a, b *big.Float) *big.Float {
 	return b
 }
 
+// floatToFloat64 returns the float64 value of f, or 0 if f is nil.
+func floatToFloat64(f *big.Float) float64 {
+	if f == nil {
+		return 0
+	}
+
+	value, _ := f.Float64()
+
+	return value
+}
+
+// floatToInt64 returns the int64 value of f, or 0 if f is nil.
+func floatToInt64(f *big.Float) int64 {
+	if f == nil {
+		return 0
+	}
+
+	value, _ := f.Int64()
+
+	return value
+}
+
 // calculateScore calculates the score for the operation rewards calculation.
 func calculateScore(value, maxValue *big.Float, weight, factor float64) *big.Float {
 	radio := new(big.Float).Quo(value, maxValue)
@@ -151,8 +151,8 @@ func TestCalculateScores(t *testing.T) {
 			scores, totalScore := calculateScores(context.Background(), tt.operationStats, tt.statsData, tt.maxValue, tt.rewards, &mu)
 
 			for i := range scores {
-				if scores[i].Cmp(tt.expectedScores[i]) != 0 {
-					t.Errorf("[%d] got = %v, want %v ", i, scores[i], tt.expectedScores[i])
+				if scores[i].total.Cmp(tt.expectedScores[i]) != 0 {
+					t.Errorf("[%d] got = %v, want %v ", i, scores[i].total, tt.expectedScores[i])
 				}
 			}
 
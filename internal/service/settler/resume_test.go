@@ -0,0 +1,230 @@
+package settler
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rss3-network/global-indexer/common/txmgr"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResumeSettlement restarts the settler with the transaction of a Settlement left pending in the journal,
+// the Settlement must be sent once and saved once it is confirmed.
+func TestResumeSettlement(t *testing.T) {
+	t.Parallel()
+
+	settlerAddress := common.HexToAddress("0x1")
+
+	data := schema.SettlementData{
+		Epoch:            big.NewInt(5),
+		NodeAddress:      []common.Address{common.HexToAddress("0x2")},
+		OperationRewards: []*big.Int{big.NewInt(100)},
+		RequestCount:     []*big.Int{big.NewInt(10)},
+		IsFinal:          true,
+	}
+
+	metadata, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &settlerAddress,
+		Value:     big.NewInt(0),
+	})
+
+	rawTransaction, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name string
+		// mined is true if the transaction was mined before the restart.
+		mined bool
+		sent  int
+	}{
+		{name: "pending", mined: false, sent: 1},
+		{name: "mined", mined: true, sent: 0},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			chain := testChain{mined: make(map[common.Hash]bool)}
+			chain.mined[tx.Hash()] = testcase.mined
+
+			journal := testJournal{
+				journals: []*schema.TxJournal{
+					{
+						ID:                1,
+						Service:           txmgr.ServiceSettler,
+						ChainID:           1,
+						From:              settlerAddress,
+						Nonce:             tx.Nonce(),
+						TransactionHashes: []common.Hash{tx.Hash()},
+						RawTransaction:    rawTransaction,
+						Metadata:          metadata,
+						Status:            schema.TxJournalStatusPending,
+					},
+				},
+			}
+
+			databaseClient := testDatabase{}
+
+			server := Server{
+				txManager:      newTestTxManager(t, &chain, &journal, settlerAddress),
+				databaseClient: &databaseClient,
+			}
+
+			require.NoError(t, server.txManager.Recover(context.Background(), server.resumeSettlement))
+
+			// The settler starts listening for the next epoch only after the Settlement is saved.
+			assert.Len(t, chain.sent, testcase.sent)
+			assert.Equal(t, schema.TxJournalStatusConfirmed, journal.journals[0].Status)
+
+			require.Len(t, databaseClient.epochTriggers, 1)
+			assert.Equal(t, tx.Hash(), databaseClient.epochTriggers[0].TransactionHash)
+			assert.Equal(t, uint64(5), databaseClient.epochTriggers[0].EpochID)
+		})
+	}
+}
+
+func newTestTxManager(t *testing.T, chain *testChain, journal txmgr.Journal, address common.Address) txmgr.TxManager {
+	t.Helper()
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", chain))
+
+	rpcClient := rpc.DialInProc(server)
+
+	t.Cleanup(func() {
+		rpcClient.Close()
+		server.Stop()
+	})
+
+	signerPool, err := txmgr.NewSignerPool([]txmgr.Signer{
+		{
+			Address: address,
+			SignerFn: func(_ context.Context, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+			Services: []string{txmgr.ServiceSettler},
+		},
+	})
+	require.NoError(t, err)
+
+	txManager, err := txmgr.NewSimpleTxManager(txmgr.Config{
+		ResubmissionTimeout:       time.Minute,
+		FeeLimitMultiplier:        5,
+		TxNotInMempoolTimeout:     time.Minute,
+		NetworkTimeout:            time.Second,
+		ReceiptQueryInterval:      10 * time.Millisecond,
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+	}, big.NewInt(1), ethclient.NewClient(rpcClient), signerPool, journal)
+	require.NoError(t, err)
+
+	return txManager.WithService(txmgr.ServiceSettler)
+}
+
+// testChain serves the JSON-RPC methods used by the tx manager, a transaction is mined once it is sent.
+type testChain struct {
+	mu    sync.Mutex
+	mined map[common.Hash]bool
+	sent  []common.Hash
+}
+
+func (c *testChain) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func (c *testChain) GetTransactionCount(_ common.Address, _ string) hexutil.Uint64 {
+	return 0
+}
+
+func (c *testChain) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	var tx types.Transaction
+
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sent = append(c.sent, tx.Hash())
+	c.mined[tx.Hash()] = true
+
+	return tx.Hash(), nil
+}
+
+func (c *testChain) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.mined[hash] {
+		return nil, nil
+	}
+
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      hash,
+		BlockNumber: big.NewInt(1),
+		Logs:        []*types.Log{},
+	}, nil
+}
+
+type testJournal struct {
+	journals []*schema.TxJournal
+}
+
+func (j *testJournal) SaveTxJournal(_ context.Context, journal *schema.TxJournal) error {
+	for index := range j.journals {
+		if j.journals[index].ID == journal.ID {
+			j.journals[index] = journal
+		}
+	}
+
+	return nil
+}
+
+func (j *testJournal) FindTxJournals(_ context.Context, query schema.TxJournalsQuery) ([]*schema.TxJournal, error) {
+	var journals []*schema.TxJournal
+
+	for _, journal := range j.journals {
+		if query.Status == nil || journal.Status == *query.Status {
+			journals = append(journals, journal)
+		}
+	}
+
+	return journals, nil
+}
+
+type testDatabase struct {
+	database.Client
+
+	epochTriggers []*schema.EpochTrigger
+}
+
+func (d *testDatabase) SaveEpochTrigger(_ context.Context, epochTrigger *schema.EpochTrigger) error {
+	d.epochTriggers = append(d.epochTriggers, epochTrigger)
+
+	return nil
+}
@@ -44,6 +44,7 @@ type Server struct {
 	networkParamsContract *l2.NetworkParams
 	config                *config.File
 	httpClient            httputil.Client
+	simulation            *simulation
 }
 
 func (s *Server) Name() string {
@@ -51,6 +52,16 @@ func (s *Server) Name() string {
 }
 
 func (s *Server) Run(ctx context.Context) error {
+	if s.simulation != nil {
+		return s.simulate(ctx)
+	}
+
+	// Resume the transactions left pending by the last run before sending new ones,
+	// the Settlement of a recovered transaction is saved and continued, so it is not sent again.
+	if err := s.txManager.Recover(ctx, s.resumeSettlement); err != nil {
+		return fmt.Errorf("recover pending transactions: %w", err)
+	}
+
 	errorPool := pool.New().WithContext(ctx).WithCancelOnError().WithFirstError()
 
 	// Listen epoch event
@@ -281,7 +292,7 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, ethere
 		mutex:                 rs.NewMutex(Name, redsync.WithExpiry(5*time.Minute)),
 		ethereumClient:        ethereumClient,
 		databaseClient:        databaseClient,
-		txManager:             txManager,
+		txManager:             txManager.WithService(txmgr.ServiceSettler),
 		stakingContract:       stakingContract,
 		settlementContract:    settlementContract,
 		config:                config,
@@ -289,5 +300,17 @@ func NewServer(databaseClient database.Client, redisClient *redis.Client, ethere
 		networkParamsContract: networkParamsContract,
 	}
 
+	if viper.GetBool(flag.KeyDryRun) {
+		server.simulation = &simulation{
+			epoch:  viper.GetUint64(flag.KeyEpoch),
+			output: viper.GetString(flag.KeyOutput),
+			format: viper.GetString(flag.KeyFormat),
+		}
+
+		if !lo.Contains([]string{FormatJSON, FormatCSV}, server.simulation.format) {
+			return nil, fmt.Errorf("unsupported format: %s", server.simulation.format)
+		}
+	}
+
 	return server, nil
 }
@@ -2,6 +2,7 @@ package settler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -25,6 +26,11 @@ import (
 // formats the data and invokes the contract
 // a retry logic is implemented to handle possible failures
 func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
+	return s.submitEpochProofFrom(ctx, epoch, nil)
+}
+
+// submitEpochProofFrom submits the batches of the epoch after the Node of the cursor, from the first batch if the cursor is nil.
+func (s *Server) submitEpochProofFrom(ctx context.Context, epoch uint64, cursor *string) error {
 	if err := s.mutex.Lock(); err != nil {
 		zap.L().Error("lock error", zap.String("key", s.mutex.Name()), zap.Error(err))
 
@@ -37,15 +43,13 @@ func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 		}
 	}()
 
-	var (
-		cursor      *string
-		firstInvoke = true
-	)
+	// The first batch is submitted even if there is no Node, to settle the epoch.
+	firstInvoke := cursor == nil
 
 	for {
 		msg := "construct Settlement data"
 		// Construct transactionData as required by the Settlement contract
-		transactionData, err := s.constructSettlementData(ctx, epoch, cursor)
+		transactionData, breakdowns, err := s.constructSettlementData(ctx, epoch, cursor)
 		if err != nil {
 			zap.L().Error(msg, zap.Error(err))
 
@@ -64,7 +68,7 @@ func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 		// Invoke the Settlement contract
 		receipt, err := retry.DoWithData(
 			func() (*types.Receipt, error) {
-				return s.invokeSettlementContract(ctx, *transactionData)
+				return s.invokeSettlementContract(ctx, *transactionData, true)
 			},
 			retry.Delay(time.Second),
 			retry.Attempts(5),
@@ -80,6 +84,11 @@ func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 			return err
 		}
 
+		// The breakdown only explains the rewards, failing to save it must not interrupt the Settlement
+		if err := s.databaseClient.SaveNodeScoreBreakdowns(ctx, breakdowns); err != nil {
+			zap.L().Error("save node score breakdowns", zap.Error(err), zap.Uint64("epoch", epoch))
+		}
+
 		zap.L().Info("Settlement contracted invoked successfully", zap.String("tx", receipt.TxHash.String()), zap.Any("data", *transactionData))
 
 		firstInvoke = false
@@ -94,6 +103,39 @@ func (s *Server) submitEpochProof(ctx context.Context, epoch uint64) error {
 	return nil
 }
 
+// resumeSettlement saves the Settlement of a transaction confirmed after a restart, and submits the rest of its epoch.
+func (s *Server) resumeSettlement(ctx context.Context, journal *schema.TxJournal, receipt *types.Receipt) error {
+	// The transactions retrying the epoch proof are not journaled with the Settlement.
+	if len(journal.Metadata) == 0 {
+		return nil
+	}
+
+	var data schema.SettlementData
+
+	if err := json.Unmarshal(journal.Metadata, &data); err != nil {
+		return fmt.Errorf("unmarshal settlement data: %w", err)
+	}
+
+	checkReceipt(receipt)
+
+	if err := s.saveSettlement(ctx, receipt, data); err != nil {
+		return err
+	}
+
+	zap.L().Info("Settlement recovered", zap.String("tx", receipt.TxHash.String()), zap.Any("data", data))
+
+	if data.IsFinal {
+		return nil
+	}
+
+	var cursor *string
+	if len(data.NodeAddress) > 0 {
+		cursor = lo.ToPtr(data.NodeAddress[len(data.NodeAddress)-1].String())
+	}
+
+	return s.submitEpochProofFrom(ctx, data.Epoch.Uint64(), cursor)
+}
+
 // retryEpochProof retries the epoch proof submission.
 // When a block reorganization occurs, the original epoch proof needs to be resubmitted.
 func (s *Server) retryEpochProof(ctx context.Context, epochID uint64) error {
@@ -120,7 +162,7 @@ func (s *Server) retryEpochProof(ctx context.Context, epochID uint64) error {
 	for _, trigger := range epochTriggers {
 		// Invoke the Settlement contract
 		receipt, err := retry.DoWithData(func() (*types.Receipt, error) {
-			return s.invokeSettlementContract(ctx, trigger.Data)
+			return s.invokeSettlementContract(ctx, trigger.Data, false)
 		}, retry.Delay(time.Second), retry.Attempts(5))
 
 		if err != nil {
@@ -136,8 +178,9 @@ func (s *Server) retryEpochProof(ctx context.Context, epochID uint64) error {
 	return nil
 }
 
-// constructSettlementData constructs Settlement data as required by the Settlement contract
-func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, cursor *string) (*schema.SettlementData, error) {
+// constructSettlementData constructs Settlement data as required by the Settlement contract,
+// along with the breakdown of the scores and rewards of each Node
+func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, cursor *string) (*schema.SettlementData, []*schema.NodeScoreBreakdown, error) {
 	// batchSize is the number of Nodes to process in each batch.
 	// This is to prevent the contract call from running out of gas.
 	// TODO: This method needs to be refactored when the number of nodes exceeds the batch size value.
@@ -160,12 +203,12 @@ func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, curs
 	if err != nil {
 		// No qualified Nodes found in the database
 		if errors.Is(err, database.ErrorRowNotFound) {
-			return nil, nil
+			return nil, nil, nil
 		}
 
 		zap.L().Error("No qualified Nodes found", zap.Error(err), zap.Any("cursor", cursor))
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	// isFinal is true if it's the last batch of Nodes
@@ -176,19 +219,24 @@ func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, curs
 
 	filterNodeAddresses, filterNodes, err := s.filter(nodes)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Calculate the number of requests for the Nodes
 	requestCount, operationStats, err := s.prepareRequestCounts(ctx, filterNodeAddresses, filterNodes)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Calculate the Operation rewards for the Nodes
-	operationRewards, err := s.calculateOperationRewards(ctx, operationStats, s.config.Rewards)
+	operationRewards, breakdowns, err := s.calculateOperationRewards(ctx, operationStats, s.config.Rewards)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	for i, breakdown := range breakdowns {
+		breakdown.EpochID = epoch
+		breakdown.NodeAddress = filterNodeAddresses[i]
 	}
 
 	return &schema.SettlementData{
@@ -197,7 +245,7 @@ func (s *Server) constructSettlementData(ctx context.Context, epoch uint64, curs
 		OperationRewards: operationRewards,
 		RequestCount:     requestCount,
 		IsFinal:          isFinal,
-	}, nil
+	}, breakdowns, nil
 }
 
 // filter retrieves Node information from a staking contract.
@@ -236,15 +284,23 @@ func isValidStatus(status uint8) bool {
 		status == uint8(schema.NodeStatusSlashing)
 }
 
-// invokeSettlementContract invokes the Settlement contract with prepared data
-// and saves the Settlement to the database
-func (s *Server) invokeSettlementContract(ctx context.Context, data schema.SettlementData) (*types.Receipt, error) {
+// invokeSettlementContract invokes the Settlement contract with prepared data,
+// the data is journaled with the transaction if resumable, so the Settlement is saved by resumeSettlement after a restart
+func (s *Server) invokeSettlementContract(ctx context.Context, data schema.SettlementData, resumable bool) (*types.Receipt, error) {
 	input, err := s.prepareInputData(data)
 	if err != nil {
 		return nil, err
 	}
 
-	receipt, err := s.sendTransaction(ctx, input)
+	var metadata json.RawMessage
+
+	if resumable {
+		if metadata, err = json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("marshal settlement data: %w", err)
+		}
+	}
+
+	receipt, err := s.sendTransaction(ctx, input, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -253,12 +309,13 @@ func (s *Server) invokeSettlementContract(ctx context.Context, data schema.Settl
 }
 
 // sendTransaction sends the transaction and returns the receipt if successful
-func (s *Server) sendTransaction(ctx context.Context, input []byte) (*types.Receipt, error) {
+func (s *Server) sendTransaction(ctx context.Context, input []byte, metadata json.RawMessage) (*types.Receipt, error) {
 	txCandidate := txmgr.TxCandidate{
 		TxData:   input,
 		To:       lo.ToPtr(l2.ContractMap[s.chainID.Uint64()].AddressSettlementProxy),
 		GasLimit: s.config.Settler.GasLimit,
 		Value:    big.NewInt(0),
+		Metadata: metadata,
 	}
 
 	receipt, err := s.txManager.Send(ctx, txCandidate)
@@ -266,6 +323,14 @@ func (s *Server) sendTransaction(ctx context.Context, input []byte) (*types.Rece
 		return nil, fmt.Errorf("failed to send tx: %w", err)
 	}
 
+	checkReceipt(receipt)
+
+	// return the receipt if the transaction is successful
+	return receipt, nil
+}
+
+// checkReceipt blocks the process if the transaction is reverted
+func checkReceipt(receipt *types.Receipt) {
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		zap.L().Error("received an invalid transaction receipt", zap.String("tx", receipt.TxHash.String()))
 
@@ -274,9 +339,6 @@ func (s *Server) sendTransaction(ctx context.Context, input []byte) (*types.Rece
 		// we do not want that as it will be stuck in the same state
 		select {}
 	}
-
-	// return the receipt if the transaction is successful
-	return receipt, nil
 }
 
 // saveSettlement saves the Settlement data to the database
@@ -0,0 +1,215 @@
+package settler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// simulation is the dry run of the settler, nothing is sent on chain.
+type simulation struct {
+	// epoch to simulate, the next epoch if it is 0.
+	epoch uint64
+	// output is the file the breakdown is written to, the breakdown is written to stdout if it is empty.
+	output string
+	format string
+}
+
+// simulate calculates the Operation Rewards of the next epoch as submitEpochProof does without invoking the Settlement contract,
+// and writes the breakdown of the scores and rewards of each Node.
+// The breakdown of the next epoch is calculated from the current stats of the Nodes, which are reset every epoch,
+// so the breakdown of a settled epoch is rebuilt from the breakdowns persisted when it was settled.
+func (s *Server) simulate(ctx context.Context) error {
+	lastEpoch, err := s.databaseClient.FindEpochs(ctx, &schema.FindEpochsQuery{Limit: lo.ToPtr(1)})
+	if err != nil && !errors.Is(err, database.ErrorRowNotFound) {
+		return fmt.Errorf("get latest epoch event from database: %w", err)
+	}
+
+	var nextEpoch uint64
+
+	if len(lastEpoch) > 0 {
+		nextEpoch = lastEpoch[0].ID
+	}
+
+	nextEpoch++
+
+	epoch := lo.Ternary(s.simulation.epoch == 0, nextEpoch, s.simulation.epoch)
+
+	var breakdowns []*schema.NodeScoreBreakdown
+
+	switch {
+	case epoch == nextEpoch:
+		if breakdowns, err = s.simulateEpoch(ctx, epoch); err != nil {
+			return fmt.Errorf("simulate epoch %d: %w", epoch, err)
+		}
+	case epoch < nextEpoch:
+		if breakdowns, err = s.databaseClient.FindNodeScoreBreakdowns(ctx, epoch); err != nil {
+			return fmt.Errorf("find the breakdowns of epoch %d: %w", epoch, err)
+		}
+
+		if len(breakdowns) == 0 {
+			return fmt.Errorf("no breakdown was persisted when epoch %d was settled", epoch)
+		}
+	default:
+		return fmt.Errorf("epoch %d cannot be simulated before the next epoch %d is settled", epoch, nextEpoch)
+	}
+
+	if s.simulation.output == "" {
+		if err := writeBreakdowns(os.Stdout, s.simulation.format, breakdowns); err != nil {
+			return fmt.Errorf("write breakdown: %w", err)
+		}
+	} else if err := writeBreakdownsFile(s.simulation.output, s.simulation.format, breakdowns); err != nil {
+		return err
+	}
+
+	zap.L().Info("epoch simulated", zap.Uint64("epoch", epoch), zap.Int("nodes", len(breakdowns)))
+
+	return nil
+}
+
+// simulateEpoch constructs the Settlement data of every batch of Nodes and returns the breakdown of all Nodes.
+func (s *Server) simulateEpoch(ctx context.Context, epoch uint64) ([]*schema.NodeScoreBreakdown, error) {
+	var (
+		cursor     *string
+		breakdowns []*schema.NodeScoreBreakdown
+	)
+
+	for {
+		transactionData, batchBreakdowns, err := s.constructSettlementData(ctx, epoch, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("construct Settlement data: %w", err)
+		}
+
+		if transactionData == nil {
+			break
+		}
+
+		breakdowns = append(breakdowns, batchBreakdowns...)
+
+		if transactionData.IsFinal || len(transactionData.NodeAddress) == 0 {
+			break
+		}
+
+		cursor = lo.ToPtr(transactionData.NodeAddress[len(transactionData.NodeAddress)-1].String())
+	}
+
+	return breakdowns, nil
+}
+
+// writeBreakdownsFile writes the breakdowns in the format to the file, replacing its content.
+func writeBreakdownsFile(name, format string, breakdowns []*schema.NodeScoreBreakdown) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+
+	if err := writeBreakdowns(file, format, breakdowns); err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("write breakdown: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close output file: %w", err)
+	}
+
+	return nil
+}
+
+// writeBreakdowns writes the breakdowns in the format.
+func writeBreakdowns(writer io.Writer, format string, breakdowns []*schema.NodeScoreBreakdown) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(lo.Ternary(breakdowns == nil, []*schema.NodeScoreBreakdown{}, breakdowns))
+	case FormatCSV:
+		csvWriter := csv.NewWriter(writer)
+
+		if err := csvWriter.Write(breakdownCSVHeader); err != nil {
+			return err
+		}
+
+		for _, breakdown := range breakdowns {
+			if err := csvWriter.Write(formatBreakdownCSVRecord(breakdown)); err != nil {
+				return err
+			}
+		}
+
+		csvWriter.Flush()
+
+		return csvWriter.Error()
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+var breakdownCSVHeader = []string{
+	"epoch_id",
+	"node_address",
+	"valid_request_count",
+	"invalid_request_count",
+	"network_count",
+	"indexer_count",
+	"activity_count",
+	"uptime",
+	"is_latest_version",
+	"valid_score",
+	"invalid_score",
+	"network_score",
+	"indexer_score",
+	"activity_score",
+	"uptime_score",
+	"version_score",
+	"distribution_score",
+	"data_score",
+	"stability_score",
+	"score",
+	"operation_rewards",
+}
+
+func formatBreakdownCSVRecord(breakdown *schema.NodeScoreBreakdown) []string {
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return []string{
+		strconv.FormatUint(breakdown.EpochID, 10),
+		breakdown.NodeAddress.String(),
+		strconv.FormatInt(breakdown.ValidRequestCount, 10),
+		strconv.FormatInt(breakdown.InvalidRequestCount, 10),
+		strconv.FormatInt(breakdown.NetworkCount, 10),
+		strconv.FormatInt(breakdown.IndexerCount, 10),
+		strconv.FormatInt(breakdown.ActivityCount, 10),
+		strconv.FormatInt(breakdown.Uptime, 10),
+		strconv.FormatBool(breakdown.IsLatestVersion),
+		formatFloat(breakdown.ValidScore),
+		formatFloat(breakdown.InvalidScore),
+		formatFloat(breakdown.NetworkScore),
+		formatFloat(breakdown.IndexerScore),
+		formatFloat(breakdown.ActivityScore),
+		formatFloat(breakdown.UptimeScore),
+		formatFloat(breakdown.VersionScore),
+		formatFloat(breakdown.DistributionScore),
+		formatFloat(breakdown.DataScore),
+		formatFloat(breakdown.StabilityScore),
+		formatFloat(breakdown.Score),
+		breakdown.OperationRewards.String(),
+	}
+}
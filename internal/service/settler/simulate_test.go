@@ -0,0 +1,138 @@
+package settler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBreakdowns(t *testing.T) {
+	t.Parallel()
+
+	breakdowns := []*schema.NodeScoreBreakdown{
+		{
+			EpochID:           1,
+			NodeAddress:       common.HexToAddress("0x1"),
+			ValidRequestCount: 1300,
+			IsLatestVersion:   true,
+			ValidScore:        0.6,
+			Score:             0.74,
+			OperationRewards:  decimal.RequireFromString("1000000000000000000"),
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buffer bytes.Buffer
+		require.NoError(t, writeBreakdowns(&buffer, FormatJSON, breakdowns))
+
+		var result []*schema.NodeScoreBreakdown
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+		assert.Equal(t, breakdowns, result)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		var buffer bytes.Buffer
+		require.NoError(t, writeBreakdowns(&buffer, FormatCSV, breakdowns))
+
+		records, err := csv.NewReader(&buffer).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, breakdownCSVHeader, records[0])
+		assert.Len(t, records[1], len(breakdownCSVHeader))
+		assert.Equal(t, "0x0000000000000000000000000000000000000001", records[1][1])
+		assert.Equal(t, "0.74", records[1][19])
+		assert.Equal(t, "1000000000000000000", records[1][20])
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Error(t, writeBreakdowns(&bytes.Buffer{}, "xml", breakdowns))
+	})
+}
+
+func TestSimulateSettledEpoch(t *testing.T) {
+	t.Parallel()
+
+	breakdown := &schema.NodeScoreBreakdown{
+		EpochID:          2,
+		NodeAddress:      common.HexToAddress("0x1"),
+		Score:            0.74,
+		OperationRewards: decimal.RequireFromString("1000000000000000000"),
+	}
+
+	testcases := []struct {
+		name  string
+		epoch uint64
+		err   bool
+	}{
+		{name: "settled", epoch: 2},
+		{name: "settled without breakdowns", epoch: 1, err: true},
+		{name: "after the next epoch", epoch: 5, err: true},
+	}
+
+	for _, testcase := range testcases {
+		testcase := testcase
+
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+
+			output := filepath.Join(t.TempDir(), "breakdowns.json")
+
+			server := Server{
+				databaseClient: &simulationDatabase{
+					lastEpoch:  &schema.Epoch{ID: 3},
+					breakdowns: map[uint64][]*schema.NodeScoreBreakdown{2: {breakdown}},
+				},
+				simulation: &simulation{epoch: testcase.epoch, output: output, format: FormatJSON},
+			}
+
+			err := server.simulate(context.Background())
+			if testcase.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(output)
+			require.NoError(t, err)
+
+			var result []*schema.NodeScoreBreakdown
+			require.NoError(t, json.Unmarshal(data, &result))
+			assert.Equal(t, []*schema.NodeScoreBreakdown{breakdown}, result)
+		})
+	}
+}
+
+// simulationDatabase keeps the latest epoch and the breakdowns persisted for the settled epochs.
+type simulationDatabase struct {
+	database.Client
+
+	lastEpoch  *schema.Epoch
+	breakdowns map[uint64][]*schema.NodeScoreBreakdown
+}
+
+func (d *simulationDatabase) FindEpochs(_ context.Context, _ *schema.FindEpochsQuery) ([]*schema.Epoch, error) {
+	return []*schema.Epoch{d.lastEpoch}, nil
+}
+
+func (d *simulationDatabase) FindNodeScoreBreakdowns(_ context.Context, epochID uint64) ([]*schema.NodeScoreBreakdown, error) {
+	return d.breakdowns[epochID], nil
+}
@@ -0,0 +1,207 @@
+package tvl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/contract/l1"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/internal/price"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/sourcegraph/conc/pool"
+)
+
+// ErrMissingPrice is returned for a token not priced by the price provider, which is not valued at zero.
+var ErrMissingPrice = errors.New("missing token price")
+
+// Calculator calculates the total value locked in the Network,
+// which is the value of the tokens locked in the L1 bridge and of the power tokens minted on the VSL.
+type Calculator struct {
+	priceProvider price.Provider
+	tokens        map[common.Address]*token
+}
+
+type token struct {
+	chainID  uint64
+	decimals int32
+	// stable is true for the stablecoins, which are priced at 1 USD.
+	stable  bool
+	balance func(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// PricedTokens returns the tokens whose prices are fetched from the price provider.
+func (c *Calculator) PricedTokens() []common.Address {
+	addresses := make([]common.Address, 0, len(c.tokens))
+
+	for address, token := range c.tokens {
+		if !token.stable {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+// FetchPrices fetches the prices of the tokens in USD from the price provider.
+func (c *Calculator) FetchPrices(ctx context.Context) (map[common.Address]decimal.Decimal, error) {
+	if c.priceProvider == nil {
+		return nil, errors.New("price provider is not configured")
+	}
+
+	tokens := make([]price.Token, 0, len(c.tokens))
+
+	for address, token := range c.tokens {
+		if !token.stable {
+			tokens = append(tokens, price.Token{ChainID: token.chainID, Address: address})
+		}
+	}
+
+	prices, err := c.priceProvider.Prices(ctx, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("get token prices: %w", err)
+	}
+
+	// A token omitted by all providers is treated as the providers being unavailable.
+	for _, token := range tokens {
+		if _, exists := prices[token.Address]; !exists {
+			return nil, fmt.Errorf("%w: %s", ErrMissingPrice, token.Address)
+		}
+	}
+
+	return prices, nil
+}
+
+// Calculate calculates the total value locked with the current balances of the tokens and the prices,
+// all tokens but the stablecoins must be priced.
+func (c *Calculator) Calculate(ctx context.Context, prices map[common.Address]decimal.Decimal) (*schema.TVLSnapshot, error) {
+	var (
+		snapshot schema.TVLSnapshot
+		mu       sync.Mutex
+	)
+
+	p := pool.New().WithContext(ctx).WithCancelOnError().WithMaxGoroutines(10)
+
+	for address, token := range c.tokens {
+		address, token := address, token
+
+		price, exists := prices[address]
+		if token.stable {
+			price = decimal.NewFromInt(1)
+		} else if !exists {
+			return nil, fmt.Errorf("%w: %s", ErrMissingPrice, address)
+		}
+
+		p.Go(func(ctx context.Context) error {
+			balance, err := token.balance(&bind.CallOpts{Context: ctx})
+			if err != nil {
+				return fmt.Errorf("get balance of token %s: %w", address, err)
+			}
+
+			tvlToken := schema.TVLToken{
+				ChainID: token.chainID,
+				Address: address,
+				Balance: decimal.NewFromBigInt(balance, 0),
+				Price:   price,
+				Value:   decimal.NewFromBigInt(balance, -token.decimals).Mul(price),
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			snapshot.Tokens = append(snapshot.Tokens, &tvlToken)
+			snapshot.TVL = snapshot.TVL.Add(tvlToken.Value)
+
+			return nil
+		})
+	}
+
+	if err := p.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snapshot.Tokens, func(i, j int) bool {
+		if snapshot.Tokens[i].ChainID != snapshot.Tokens[j].ChainID {
+			return snapshot.Tokens[i].ChainID < snapshot.Tokens[j].ChainID
+		}
+
+		return snapshot.Tokens[i].Address.Cmp(snapshot.Tokens[j].Address) < 0
+	})
+
+	return &snapshot, nil
+}
+
+// SnapshotPrices returns the prices recorded in the snapshot.
+func SnapshotPrices(snapshot *schema.TVLSnapshot) map[common.Address]decimal.Decimal {
+	prices := make(map[common.Address]decimal.Decimal, len(snapshot.Tokens))
+
+	for _, token := range snapshot.Tokens {
+		prices[token.Address] = token.Price
+	}
+
+	return prices
+}
+
+func NewCalculator(ethereumL1Client, ethereumL2Client bind.ContractBackend, priceProvider price.Provider, chainL1ID, chainL2ID uint64) (*Calculator, error) {
+	contractAddressesL1 := l1.ContractMap[chainL1ID]
+	if contractAddressesL1 == nil {
+		return nil, fmt.Errorf("contract address not found for chain id: %d", chainL1ID)
+	}
+
+	contractAddressesL2 := l2.ContractMap[chainL2ID]
+	if contractAddressesL2 == nil {
+		return nil, fmt.Errorf("contract address not found for chain id: %d", chainL2ID)
+	}
+
+	calculator := Calculator{
+		priceProvider: priceProvider,
+		tokens:        make(map[common.Address]*token),
+	}
+
+	// The tokens locked in the L1 bridge.
+	for _, lockedToken := range []struct {
+		address  common.Address
+		decimals int32
+		stable   bool
+	}{
+		{contractAddressesL1.AddressGovernanceTokenProxy, 18, false},
+		{contractAddressesL1.AddressWETHToken, 18, false},
+		{contractAddressesL1.AddressUSDCToken, 6, true},
+		{contractAddressesL1.AddressUSDTToken, 6, true},
+	} {
+		contract, err := bindings.NewGovernanceToken(lockedToken.address, ethereumL1Client)
+		if err != nil {
+			return nil, fmt.Errorf("new token contract %s: %w", lockedToken.address, err)
+		}
+
+		calculator.tokens[lockedToken.address] = &token{
+			chainID:  chainL1ID,
+			decimals: lockedToken.decimals,
+			stable:   lockedToken.stable,
+			balance: func(opts *bind.CallOpts) (*big.Int, error) {
+				return contract.BalanceOf(opts, contractAddressesL1.AddressL1StandardBridgeProxy)
+			},
+		}
+	}
+
+	// The power tokens minted on the VSL.
+	powerToken, err := bindings.NewGovernanceToken(contractAddressesL2.AddressPowerToken, ethereumL2Client)
+	if err != nil {
+		return nil, fmt.Errorf("new power token contract: %w", err)
+	}
+
+	calculator.tokens[contractAddressesL2.AddressPowerToken] = &token{
+		chainID:  chainL2ID,
+		decimals: 18,
+		balance:  powerToken.TotalSupply,
+	}
+
+	return &calculator, nil
+}
@@ -0,0 +1,81 @@
+package tvl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/contract/l1"
+	"github.com/rss3-network/global-indexer/contract/l2"
+	"github.com/rss3-network/global-indexer/internal/price"
+	"github.com/rss3-network/global-indexer/internal/tvl"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculator_FetchPrices(t *testing.T) {
+	t.Parallel()
+
+	contractAddressesL1 := l1.ContractMap[l1.ChainIDMainnet]
+	contractAddressesL2 := l2.ContractMap[l2.ChainIDMainnet]
+
+	provider := price.NewStatic(map[common.Address]decimal.Decimal{
+		contractAddressesL1.AddressGovernanceTokenProxy: decimal.RequireFromString("0.1"),
+		contractAddressesL1.AddressWETHToken:            decimal.RequireFromString("2500.5"),
+		contractAddressesL2.AddressPowerToken:           decimal.RequireFromString("0.12"),
+		// The stablecoins are priced at 1 USD, not by the provider.
+		contractAddressesL1.AddressUSDCToken: decimal.RequireFromString("0.99"),
+	})
+
+	calculator, err := tvl.NewCalculator(nil, nil, provider, l1.ChainIDMainnet, l2.ChainIDMainnet)
+	require.NoError(t, err)
+	require.Len(t, calculator.PricedTokens(), 3)
+
+	result, err := calculator.FetchPrices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	require.Equal(t, "0.1", result[contractAddressesL1.AddressGovernanceTokenProxy].String())
+	require.Equal(t, "2500.5", result[contractAddressesL1.AddressWETHToken].String())
+	require.Equal(t, "0.12", result[contractAddressesL2.AddressPowerToken].String())
+
+	// The prices are recorded in the snapshots to fall back to.
+	snapshot := schema.TVLSnapshot{
+		Tokens: []*schema.TVLToken{
+			{Address: contractAddressesL1.AddressWETHToken, Price: result[contractAddressesL1.AddressWETHToken]},
+		},
+	}
+	require.Equal(t, "2500.5", tvl.SnapshotPrices(&snapshot)[contractAddressesL1.AddressWETHToken].String())
+}
+
+func TestCalculator_FetchPricesMissing(t *testing.T) {
+	t.Parallel()
+
+	contractAddressesL1 := l1.ContractMap[l1.ChainIDMainnet]
+
+	// The provider omits the prices of the other tokens.
+	provider := price.NewStatic(map[common.Address]decimal.Decimal{
+		contractAddressesL1.AddressWETHToken: decimal.RequireFromString("2500.5"),
+	})
+
+	calculator, err := tvl.NewCalculator(nil, nil, provider, l1.ChainIDMainnet, l2.ChainIDMainnet)
+	require.NoError(t, err)
+
+	_, err = calculator.FetchPrices(context.Background())
+	require.ErrorIs(t, err, tvl.ErrMissingPrice)
+
+	_, err = calculator.Calculate(context.Background(), map[common.Address]decimal.Decimal{
+		contractAddressesL1.AddressWETHToken: decimal.RequireFromString("2500.5"),
+	})
+	require.ErrorIs(t, err, tvl.ErrMissingPrice)
+}
+
+func TestCalculator_FetchPricesUnconfigured(t *testing.T) {
+	t.Parallel()
+
+	calculator, err := tvl.NewCalculator(nil, nil, nil, l1.ChainIDMainnet, l2.ChainIDMainnet)
+	require.NoError(t, err)
+
+	_, err = calculator.FetchPrices(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// ErrForbiddenAddress is returned for the webhooks resolving to an address not reachable from the Internet.
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// nonPublicNetworks are the special-purpose networks not covered by the methods of net.IP, see RFC 6890.
+var nonPublicNetworks = lo.Map([]string{
+	"0.0.0.0/8",       // This network, routed to the host itself.
+	"100.64.0.0/10",   // Shared address space of carrier-grade NATs.
+	"192.0.0.0/24",    // IETF protocol assignments.
+	"192.0.2.0/24",    // TEST-NET-1.
+	"198.18.0.0/15",   // Benchmarking.
+	"198.51.100.0/24", // TEST-NET-2.
+	"203.0.113.0/24",  // TEST-NET-3.
+	"240.0.0.0/4",     // Reserved, including the limited broadcast.
+	"64:ff9b::/96",    // NAT64, which may translate to a non-public IPv4 address.
+	"64:ff9b:1::/48",  // Local-use NAT64.
+	"100::/64",        // Discard-only.
+	"2001::/23",       // IETF protocol assignments.
+	"2001:db8::/32",   // Documentation.
+}, func(cidr string, _ int) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+})
+
+// IsPublicIP returns whether the IP address is reachable from the Internet,
+// the loopback, link-local, private, unspecified, multicast and special-purpose addresses are not, such as the metadata service 169.254.169.254.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() {
+		return false
+	}
+
+	return !lo.SomeBy(nonPublicNetworks, func(network *net.IPNet) bool {
+		return network.Contains(ip)
+	})
+}
+
+// ValidateURL checks that the webhook URL is http or https, and that all addresses of its host are public.
+func ValidateURL(ctx context.Context, rawURL string) error {
+	webhookURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+
+	if webhookURL.Scheme != "http" && webhookURL.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: %s", webhookURL.Scheme)
+	}
+
+	host := webhookURL.Hostname()
+
+	var ips []net.IP
+
+	if ip := net.ParseIP(host); ip != nil {
+		ips = append(ips, ip)
+	} else {
+		addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("lookup host %s: %w", host, err)
+		}
+
+		for _, address := range addresses {
+			ips = append(ips, address.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if !IsPublicIP(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenAddress, host, ip)
+		}
+	}
+
+	return nil
+}
+
+// NewHTTPClient returns a client delivering to the public addresses only.
+// The address is checked once it is resolved at dial time, so a host resolving to another address after the registration is still refused.
+// Redirects are not followed, and proxies are not used since they would dial on behalf of the client.
+func NewHTTPClient() *http.Client {
+	dialer := net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return fmt.Errorf("split address %s: %w", address, err)
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/schema"
+	"github.com/shopspring/decimal"
+)
+
+// StatusChangedData is the data of a node.status_changed event.
+type StatusChangedData struct {
+	Status schema.NodeStatus `json:"status"`
+}
+
+// DemotedData is the data of a node.demoted event.
+type DemotedData struct {
+	Reason   string         `json:"reason"`
+	Reporter common.Address `json:"reporter"`
+}
+
+// HeartbeatMissedData is the data of a node.heartbeat_missed event.
+type HeartbeatMissedData struct {
+	LastHeartbeat int64 `json:"last_heartbeat"`
+}
+
+// SlashedData is the data of a node.slashed event.
+type SlashedData struct {
+	PreviousStatus  schema.NodeStatus `json:"previous_status"`
+	TransactionHash common.Hash       `json:"transaction_hash"`
+	BlockNumber     uint64            `json:"block_number"`
+}
+
+// EpochRewardedData is the data of an epoch.rewarded event.
+type EpochRewardedData struct {
+	EpochID          uint64          `json:"epoch_id"`
+	TransactionHash  common.Hash     `json:"transaction_hash"`
+	OperationRewards decimal.Decimal `json:"operation_rewards"`
+	StakingRewards   decimal.Decimal `json:"staking_rewards"`
+	TaxCollected     decimal.Decimal `json:"tax_collected"`
+	RequestCount     decimal.Decimal `json:"request_count"`
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rss3-network/global-indexer/internal/database"
+	"github.com/rss3-network/global-indexer/schema"
+)
+
+const (
+	// HeaderSignature is the hex encoded HMAC-SHA256 of the timestamp and the body, joined by a dot.
+	HeaderSignature = "X-RSS3-Signature"
+	// HeaderTimestamp is the unix timestamp the delivery is attempted at.
+	HeaderTimestamp = "X-RSS3-Timestamp"
+	// HeaderEvent is the event type of the delivery.
+	HeaderEvent = "X-RSS3-Event"
+
+	// MaxAttempts is the number of attempts before a delivery is failed.
+	MaxAttempts = 8
+
+	backoffBase = 30 * time.Second
+	backoffMax  = time.Hour
+)
+
+// Event is the JSON body posted to a webhook.
+type Event struct {
+	ID          uint64                      `json:"id"`
+	Type        schema.NodeWebhookEventType `json:"type"`
+	NodeAddress common.Address              `json:"node_address"`
+	CreatedAt   int64                       `json:"created_at"`
+	Data        json.RawMessage             `json:"data"`
+}
+
+// NewEvent returns the event of the delivery.
+func NewEvent(delivery *schema.NodeWebhookDelivery) *Event {
+	return &Event{
+		ID:          delivery.ID,
+		Type:        delivery.EventType,
+		NodeAddress: delivery.NodeAddress,
+		CreatedAt:   delivery.CreatedAt,
+		Data:        delivery.Data,
+	}
+}
+
+// Emit queues the event for all webhooks of the Node subscribing to the event type.
+func Emit(ctx context.Context, databaseClient database.Client, nodeAddress common.Address, eventType schema.NodeWebhookEventType, data any) error {
+	return emit(ctx, databaseClient, nodeAddress, eventType, nil, data)
+}
+
+// EmitOnce queues the event like Emit, but only once for the same key,
+// so the events of the blocks indexed again by a backfill or after a rewind are not delivered twice.
+func EmitOnce(ctx context.Context, databaseClient database.Client, nodeAddress common.Address, eventType schema.NodeWebhookEventType, key string, data any) error {
+	return emit(ctx, databaseClient, nodeAddress, eventType, &key, data)
+}
+
+func emit(ctx context.Context, databaseClient database.Client, nodeAddress common.Address, eventType schema.NodeWebhookEventType, key *string, data any) error {
+	webhooks, err := databaseClient.FindNodeWebhooks(ctx, schema.NodeWebhookQuery{NodeAddress: &nodeAddress})
+	if err != nil {
+		return fmt.Errorf("find node webhooks: %w", err)
+	}
+
+	deliveries := make([]*schema.NodeWebhookDelivery, 0, len(webhooks))
+
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(eventType) {
+			continue
+		}
+
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("marshal event data: %w", err)
+		}
+
+		deliveries = append(deliveries, &schema.NodeWebhookDelivery{
+			WebhookID:      webhook.ID,
+			NodeAddress:    nodeAddress,
+			EventType:      eventType,
+			IdempotencyKey: key,
+			Data:           payload,
+			Status:         schema.NodeWebhookDeliveryStatusPending,
+			NextAttemptAt:  time.Now(),
+		})
+	}
+
+	if err := databaseClient.SaveNodeWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("save node webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// Sign returns the signature of the body delivered at the timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns whether the signature matches the body delivered at the timestamp.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Backoff returns the delay before the next attempt after the given number of attempts.
+func Backoff(attempts uint) time.Duration {
+	if attempts == 0 {
+		return 0
+	}
+
+	delay := backoffBase
+
+	for i := uint(1); i < attempts; i++ {
+		if delay *= 2; delay >= backoffMax {
+			return backoffMax
+		}
+	}
+
+	return delay
+}
+
+// NewSecret returns a random secret for signing the deliveries of a webhook.
+func NewSecret() (string, error) {
+	buffer := make([]byte, 32)
+
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("read random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
+
+// EmitNodeInvalidResponses queues a node.invalid_response event for each of the invalid responses.
+func EmitNodeInvalidResponses(ctx context.Context, databaseClient database.Client, nodeInvalidResponses []*schema.NodeInvalidResponse) error {
+	for _, nodeInvalidResponse := range nodeInvalidResponses {
+		if err := Emit(ctx, databaseClient, nodeInvalidResponse.Node, schema.NodeWebhookEventTypeInvalidResponse, nodeInvalidResponse); err != nil {
+			return fmt.Errorf("emit invalid response of node %s: %w", nodeInvalidResponse.Node, err)
+		}
+	}
+
+	return nil
+}
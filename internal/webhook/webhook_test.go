@@ -0,0 +1,108 @@
+package webhook_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rss3-network/global-indexer/internal/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"id":1}`)
+
+	signature := webhook.Sign("secret", 1700000000, body)
+
+	require.Len(t, signature, 64)
+	require.True(t, webhook.Verify("secret", 1700000000, body, signature))
+	require.False(t, webhook.Verify("secret", 1700000001, body, signature))
+	require.False(t, webhook.Verify("other", 1700000000, body, signature))
+	require.False(t, webhook.Verify("secret", 1700000000, []byte(`{"id":2}`), signature))
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		attempts uint
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 0},
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 4, expected: 4 * time.Minute},
+		{attempts: 7, expected: 32 * time.Minute},
+		{attempts: 8, expected: time.Hour},
+		{attempts: 64, expected: time.Hour},
+	}
+
+	for _, testcase := range testcases {
+		require.Equal(t, testcase.expected, webhook.Backoff(testcase.attempts), "attempts %d", testcase.attempts)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "8.8.8.8", expected: true},
+		{ip: "2001:4860:4860::8888", expected: true},
+		{ip: "127.0.0.1", expected: false},
+		{ip: "::1", expected: false},
+		{ip: "169.254.169.254", expected: false},
+		{ip: "10.0.0.1", expected: false},
+		{ip: "192.168.1.1", expected: false},
+		{ip: "fd00::1", expected: false},
+		{ip: "0.0.0.0", expected: false},
+		{ip: "0.1.2.3", expected: false},
+		{ip: "100.64.0.1", expected: false},
+		{ip: "100.127.255.254", expected: false},
+		{ip: "192.0.0.8", expected: false},
+		{ip: "198.18.0.1", expected: false},
+		{ip: "198.19.255.254", expected: false},
+		{ip: "255.255.255.255", expected: false},
+		{ip: "::ffff:127.0.0.1", expected: false},
+		{ip: "::ffff:100.64.0.1", expected: false},
+		{ip: "64:ff9b::a00:1", expected: false},
+		{ip: "100.128.0.1", expected: true},
+		{ip: "198.20.0.1", expected: true},
+	}
+
+	for _, testcase := range testcases {
+		require.Equal(t, testcase.expected, webhook.IsPublicIP(net.ParseIP(testcase.ip)), testcase.ip)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, webhook.ValidateURL(context.Background(), "https://8.8.8.8/webhook"))
+	require.ErrorIs(t, webhook.ValidateURL(context.Background(), "http://169.254.169.254/latest/meta-data"), webhook.ErrForbiddenAddress)
+	require.ErrorIs(t, webhook.ValidateURL(context.Background(), "http://[::1]:8080/webhook"), webhook.ErrForbiddenAddress)
+	require.Error(t, webhook.ValidateURL(context.Background(), "ftp://8.8.8.8/webhook"))
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	response, err := webhook.NewHTTPClient().Get(server.URL)
+	if response != nil {
+		_ = response.Body.Close()
+	}
+
+	require.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+}
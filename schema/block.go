@@ -0,0 +1,24 @@
+package schema
+
+import "github.com/ethereum/go-ethereum/common"
+
+type BlockImporter interface {
+	Import(block Block) error
+}
+
+type BlockExporter interface {
+	Export() (*Block, error)
+}
+
+type BlockTransformer interface {
+	BlockImporter
+	BlockExporter
+}
+
+// Block is a recent block indexed, the hashes are used to detect the reorganizations of the chain.
+type Block struct {
+	ChainID     uint64      `json:"network"`
+	BlockNumber uint64      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+	ParentHash  common.Hash `json:"parent_hash"`
+}
@@ -14,6 +14,23 @@ const (
 	BridgeTransactionTypeWithdraw BridgeTransactionType = "withdraw"
 )
 
+type BridgeWithdrawalStatus string
+
+const (
+	// BridgeWithdrawalStatusInitiated when the withdrawal is initiated on the VSL, waiting for an output root covering it to be proposed on L1.
+	BridgeWithdrawalStatusInitiated BridgeWithdrawalStatus = "initiated"
+	// BridgeWithdrawalStatusReadyToProve when the withdrawal can be proven on L1.
+	BridgeWithdrawalStatusReadyToProve BridgeWithdrawalStatus = "ready_to_prove"
+	// BridgeWithdrawalStatusProven when the withdrawal is proven in an L1 block not finalized yet.
+	BridgeWithdrawalStatusProven BridgeWithdrawalStatus = "proven"
+	// BridgeWithdrawalStatusChallengePeriod when the proof of the withdrawal is finalized and in the challenge period.
+	BridgeWithdrawalStatusChallengePeriod BridgeWithdrawalStatus = "challenge_period"
+	// BridgeWithdrawalStatusReadyToFinalize when the challenge period has passed and the withdrawal can be finalized on L1.
+	BridgeWithdrawalStatusReadyToFinalize BridgeWithdrawalStatus = "ready_to_finalize"
+	// BridgeWithdrawalStatusFinalized when the withdrawal is finalized on L1.
+	BridgeWithdrawalStatusFinalized BridgeWithdrawalStatus = "finalized"
+)
+
 type BridgeTransactionImporter interface {
 	Import(bridgeTransaction BridgeTransaction) error
 }
@@ -58,4 +75,10 @@ type BridgeTransactionsQuery struct {
 	Receiver *common.Address        `query:"receiver"`
 	Address  *common.Address        `query:"address"`
 	Type     *BridgeTransactionType `query:"type"`
+	// WithdrawalStatus filters the withdrawals by their status, which depends on ProvableBefore and FinalizableBefore.
+	WithdrawalStatus *BridgeWithdrawalStatus
+	// ProvableBefore is the time before which the withdrawals initiated are ready to prove.
+	ProvableBefore time.Time
+	// FinalizableBefore is the time before which the withdrawals proven are ready to finalize.
+	FinalizableBefore time.Time
 }
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DecentralizationSnapshot is the concentration of the Network at the end of an epoch.
+type DecentralizationSnapshot struct {
+	EpochID uint64    `json:"epoch_id"`
+	Date    time.Time `json:"date"`
+	// Nodes is the number of Nodes having tokens in their staking pools.
+	Nodes             uint64          `json:"nodes"`
+	StakingPoolTokens decimal.Decimal `json:"staking_pool_tokens"`
+	// NakamotoCoefficient is the minimum number of Nodes controlling more than half of the staking pool tokens.
+	NakamotoCoefficient uint64 `json:"nakamoto_coefficient"`
+	// Gini is the Gini coefficient of the staking pool tokens, 0 for an equal distribution and close to 1 for a single Node.
+	Gini decimal.Decimal `json:"gini"`
+	// Countries and NetworkProviders are the distributions of the online Nodes by their locations.
+	Countries        []*DecentralizationDistribution `json:"countries"`
+	NetworkProviders []*DecentralizationDistribution `json:"network_providers"`
+	// Workers is the coverage of the workers by the online Nodes, full Nodes serve every worker.
+	Workers   []*DecentralizationWorkerCoverage `json:"workers"`
+	FullNodes uint64                            `json:"full_nodes"`
+	CreatedAt time.Time                         `json:"-"`
+	UpdatedAt time.Time                         `json:"-"`
+}
+
+type DecentralizationDistribution struct {
+	Name              string          `json:"name"`
+	ASN               uint            `json:"asn,omitempty"`
+	Nodes             uint64          `json:"nodes"`
+	StakingPoolTokens decimal.Decimal `json:"staking_pool_tokens"`
+}
+
+type DecentralizationWorkerCoverage struct {
+	Network string `json:"network"`
+	Name    string `json:"name"`
+	Nodes   uint64 `json:"nodes"`
+}
+
+type DecentralizationSnapshotQuery struct {
+	EpochID *uint64
+	Cursor  *uint64
+	Limit   *int
+}
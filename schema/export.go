@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExportQuery selects the records of a staker or a Node, they are iterated in chronological order.
+type ExportQuery struct {
+	// Staker matches the user of stake transactions and the owner of staker profit snapshots.
+	Staker *common.Address
+	// Node matches the Node of stake transactions and reward records.
+	Node *common.Address
+	// Address matches the sender or the receiver of bridge transactions.
+	Address *common.Address
+	// Since and Until bound the block timestamp of the records, both inclusive.
+	Since *time.Time
+	Until *time.Time
+}
@@ -43,6 +43,12 @@ type NodeLocation struct {
 	City      string  `json:"city"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
+	// ASN and NetworkProvider are the autonomous system of the IP address, such as a hosting provider.
+	ASN             uint   `json:"asn,omitempty"`
+	NetworkProvider string `json:"network_provider,omitempty"`
+	// ASNLookedUp marks the locations looked up with the autonomous systems, even if there is no record of the IP address.
+	// It is only kept in the database.
+	ASNLookedUp bool `json:"-"`
 }
 
 //go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=NodeStatus --linecomment --output node_status_string.go --json --yaml --sql
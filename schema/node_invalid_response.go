@@ -19,6 +19,37 @@ type NodeInvalidResponse struct {
 	Node             common.Address          `json:"node"`
 	Response         json.RawMessage         `json:"response"`
 	CreatedAt        int64                   `json:"created_at"`
+	// Appeal is set once the penalized Node disputes the record.
+	Appeal *NodeInvalidResponseAppeal `json:"appeal,omitempty"`
+}
+
+// NodeInvalidResponseAppeal records a dispute raised by the penalized Node against a NodeInvalidResponse
+// The invalid response is not counted toward DemotionCountBeforeSlashing while the appeal is pending or once it is upheld
+type NodeInvalidResponseAppeal struct {
+	Status     NodeInvalidResponseAppealStatus `json:"status"`
+	Reason     string                          `json:"reason,omitempty"`
+	AppealedAt int64                           `json:"appealed_at"`
+	ResolvedAt int64                           `json:"resolved_at,omitempty"`
+}
+
+type NodeInvalidResponseAppealStatus string
+
+const (
+	// NodeInvalidResponseAppealStatusPending when the appeal is waiting to be re-verified
+	NodeInvalidResponseAppealStatusPending NodeInvalidResponseAppealStatus = "pending"
+	// NodeInvalidResponseAppealStatusUpheld when the re-verification agrees with the Node
+	NodeInvalidResponseAppealStatusUpheld NodeInvalidResponseAppealStatus = "upheld"
+	// NodeInvalidResponseAppealStatusRejected when the re-verification confirms the invalid response
+	NodeInvalidResponseAppealStatusRejected NodeInvalidResponseAppealStatus = "rejected"
+)
+
+type NodeInvalidResponsesQuery struct {
+	NodeAddress  *common.Address
+	EpochID      *uint64
+	Type         *NodeInvalidResponseType
+	AppealStatus *NodeInvalidResponseAppealStatus
+	Cursor       *uint64
+	Limit        *int
 }
 
 //go:generate go run --mod=mod github.com/dmarkham/enumer@v1.5.9 --values --type=NodeInvalidResponseType --linecomment --output node_invalid_response_type_string.go --json --yaml --sql
@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// NodeScoreBreakdown records how the Operation Rewards of a Node in an Epoch are calculated.
+type NodeScoreBreakdown struct {
+	EpochID     uint64         `json:"epoch_id"`
+	NodeAddress common.Address `json:"node_address"`
+	// The stats of the Node used to calculate the scores.
+	ValidRequestCount   int64 `json:"valid_request_count"`
+	InvalidRequestCount int64 `json:"invalid_request_count"`
+	NetworkCount        int64 `json:"network_count"`
+	IndexerCount        int64 `json:"indexer_count"`
+	ActivityCount       int64 `json:"activity_count"`
+	// Uptime in seconds since the stats of the Node were reset.
+	Uptime          int64 `json:"uptime"`
+	IsLatestVersion bool  `json:"is_latest_version"`
+	// The weighted sub-scores of the Node.
+	ValidScore    float64 `json:"valid_score"`
+	InvalidScore  float64 `json:"invalid_score"`
+	NetworkScore  float64 `json:"network_score"`
+	IndexerScore  float64 `json:"indexer_score"`
+	ActivityScore float64 `json:"activity_score"`
+	UptimeScore   float64 `json:"uptime_score"`
+	VersionScore  float64 `json:"version_score"`
+	// The component scores of the Node.
+	DistributionScore float64 `json:"distribution_score"`
+	DataScore         float64 `json:"data_score"`
+	StabilityScore    float64 `json:"stability_score"`
+	// Score is the operation score of the Node, the Operation Rewards are shared in proportion to it.
+	Score            float64         `json:"score"`
+	OperationRewards decimal.Decimal `json:"operation_rewards"`
+}
@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type NodeWebhookEventType string
+
+const (
+	// NodeWebhookEventTypeStatusChanged when the status of the Node is set on the VSL.
+	NodeWebhookEventTypeStatusChanged NodeWebhookEventType = "node.status_changed"
+	// NodeWebhookEventTypeDemoted when a demotion of the Node is submitted to the VSL.
+	NodeWebhookEventTypeDemoted NodeWebhookEventType = "node.demoted"
+	// NodeWebhookEventTypeHeartbeatMissed when the Node is set offline for missing its heartbeats.
+	NodeWebhookEventTypeHeartbeatMissed NodeWebhookEventType = "node.heartbeat_missed"
+	// NodeWebhookEventTypeInvalidResponse when an invalid response of the Node is recorded.
+	NodeWebhookEventTypeInvalidResponse NodeWebhookEventType = "node.invalid_response"
+	// NodeWebhookEventTypeSlashed when the Node is being slashed on the VSL.
+	NodeWebhookEventTypeSlashed NodeWebhookEventType = "node.slashed"
+	// NodeWebhookEventTypeEpochRewarded when the rewards of an epoch are distributed to the Node.
+	NodeWebhookEventTypeEpochRewarded NodeWebhookEventType = "epoch.rewarded"
+)
+
+// NodeWebhookEventTypes are all event types a webhook can subscribe to.
+var NodeWebhookEventTypes = []NodeWebhookEventType{
+	NodeWebhookEventTypeStatusChanged,
+	NodeWebhookEventTypeDemoted,
+	NodeWebhookEventTypeHeartbeatMissed,
+	NodeWebhookEventTypeInvalidResponse,
+	NodeWebhookEventTypeSlashed,
+	NodeWebhookEventTypeEpochRewarded,
+}
+
+// NodeWebhook is a URL registered by the operator of a Node to receive the events of the Node.
+type NodeWebhook struct {
+	ID          uint64         `json:"id"`
+	NodeAddress common.Address `json:"node_address"`
+	URL         string         `json:"url"`
+	// Secret signs the deliveries, it is only returned on registration.
+	Secret string `json:"secret,omitempty"`
+	// Events are the event types subscribed, all event types if empty.
+	Events    []NodeWebhookEventType `json:"events"`
+	CreatedAt int64                  `json:"created_at"`
+	UpdatedAt int64                  `json:"updated_at"`
+}
+
+// Subscribes returns whether the webhook subscribes to the event type.
+func (w *NodeWebhook) Subscribes(eventType NodeWebhookEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type NodeWebhookQuery struct {
+	ID          *uint64
+	NodeAddress *common.Address
+}
+
+type NodeWebhookDeliveryStatus string
+
+const (
+	// NodeWebhookDeliveryStatusPending when the event is waiting for its next attempt.
+	NodeWebhookDeliveryStatusPending NodeWebhookDeliveryStatus = "pending"
+	// NodeWebhookDeliveryStatusDelivered when the webhook accepted the event.
+	NodeWebhookDeliveryStatusDelivered NodeWebhookDeliveryStatus = "delivered"
+	// NodeWebhookDeliveryStatusFailed when all attempts failed.
+	NodeWebhookDeliveryStatusFailed NodeWebhookDeliveryStatus = "failed"
+)
+
+// NodeWebhookDelivery is an event to deliver to a webhook and the result of the latest attempt.
+type NodeWebhookDelivery struct {
+	ID          uint64               `json:"id"`
+	WebhookID   uint64               `json:"webhook_id"`
+	NodeAddress common.Address       `json:"node_address"`
+	EventType   NodeWebhookEventType `json:"event_type"`
+	// IdempotencyKey identifies the event of the delivery, a webhook receives an event once for the same key.
+	IdempotencyKey *string                   `json:"-"`
+	Data           json.RawMessage           `json:"data"`
+	Status         NodeWebhookDeliveryStatus `json:"status"`
+	Attempts       uint                      `json:"attempts"`
+	// NextAttemptAt is when the pending event is attempted next.
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      int64      `json:"created_at"`
+	UpdatedAt      int64      `json:"updated_at"`
+}
+
+type NodeWebhookDeliveryQuery struct {
+	WebhookID *uint64
+	Status    *NodeWebhookDeliveryStatus
+	// DueBefore filters the events to attempt before the time.
+	DueBefore *time.Time
+	Cursor    *uint64
+	Limit     *int
+}
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// TVLSnapshot is the total value locked in the Network at the end of an epoch, in USD, there is one snapshot per epoch.
+type TVLSnapshot struct {
+	EpochID   uint64          `json:"epoch_id"`
+	Date      time.Time       `json:"date"`
+	TVL       decimal.Decimal `json:"tvl"`
+	Tokens    []*TVLToken     `json:"tokens"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+}
+
+// TVLToken is the value of a token locked in the Network.
+type TVLToken struct {
+	ChainID uint64         `json:"chain_id"`
+	Address common.Address `json:"address"`
+	// Balance is the amount locked in the smallest unit of the token.
+	Balance decimal.Decimal `json:"balance"`
+	// Price is the price of a whole token in USD.
+	Price decimal.Decimal `json:"price"`
+	Value decimal.Decimal `json:"value"`
+}
+
+type TVLSnapshotQuery struct {
+	EpochID *uint64
+	Cursor  *uint64
+	Limit   *int
+}
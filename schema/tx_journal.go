@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type TxJournalStatus string
+
+const (
+	// TxJournalStatusPending when the transaction is being sent, or the process stopped while sending it
+	TxJournalStatusPending TxJournalStatus = "pending"
+	// TxJournalStatusConfirmed when a submission of the transaction is confirmed
+	TxJournalStatusConfirmed TxJournalStatus = "confirmed"
+	// TxJournalStatusFailed when the transaction is aborted
+	TxJournalStatusFailed TxJournalStatus = "failed"
+	// TxJournalStatusDropped when the nonce of the transaction is used by a transaction not journaled
+	TxJournalStatusDropped TxJournalStatus = "dropped"
+)
+
+// TxJournal records a transaction sent by the tx manager and each submission of it,
+// so a transaction in flight can be resumed after a restart.
+type TxJournal struct {
+	ID      uint64         `json:"id"`
+	Service string         `json:"service"`
+	ChainID uint64         `json:"chain_id"`
+	From    common.Address `json:"from"`
+	Nonce   uint64         `json:"nonce"`
+	// The candidate of the transaction.
+	To       *common.Address `json:"to"`
+	Data     []byte          `json:"data"`
+	Value    *big.Int        `json:"value"`
+	GasLimit uint64          `json:"gas_limit"`
+	// The fee caps of the latest submission.
+	GasTipCap *big.Int `json:"gas_tip_cap"`
+	GasFeeCap *big.Int `json:"gas_fee_cap"`
+	// TransactionHashes are the hashes of each submission, the first one is the original transaction.
+	TransactionHashes []common.Hash `json:"transaction_hashes"`
+	// RawTransaction is the latest signed submission.
+	RawTransaction []byte          `json:"raw_transaction"`
+	Status         TxJournalStatus `json:"status"`
+	// Metadata is recorded by the service sending the transaction, to resume its work once the transaction is recovered.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// Receipt is set once a submission is confirmed.
+	Receipt   *TxJournalReceipt `json:"receipt,omitempty"`
+	CreatedAt int64             `json:"created_at"`
+	UpdatedAt int64             `json:"updated_at"`
+}
+
+type TxJournalReceipt struct {
+	TransactionHash common.Hash `json:"transaction_hash"`
+	BlockNumber     uint64      `json:"block_number"`
+	Status          uint64      `json:"status"`
+	GasUsed         uint64      `json:"gas_used"`
+}
+
+type TxJournalsQuery struct {
+	ChainID *uint64
+	Service *string
+	Status  *TxJournalStatus
+}